@@ -0,0 +1,68 @@
+// bench runs the turbine model benchmarks outside of go test, optionally writing cpu and heap profiles
+// for go tool pprof
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/bench"
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+)
+
+func main() {
+	filter := flag.String("run", ".", "only run benchmarks matching this regexp")
+	cpuProfile := flag.String("cpuprofile", "", "write a cpu profile to this file")
+	memProfile := flag.String("memprofile", "", "write a heap profile to this file once the benchmarks are done")
+	flag.Parse()
+
+	if err := run(*filter, *cpuProfile, *memProfile); err != nil {
+		logging.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func run(filter, cpuProfile, memProfile string) error {
+	pattern, err := regexp.Compile(filter)
+	if err != nil {
+		return fmt.Errorf("Invalid -run pattern: %w", err)
+	}
+
+	if cpuProfile != "" {
+		file, err := os.Create(cpuProfile)
+		if err != nil {
+			return fmt.Errorf("Failed to create cpu profile: %w", err)
+		}
+		defer file.Close()
+		if err := pprof.StartCPUProfile(file); err != nil {
+			return fmt.Errorf("Failed to start cpu profile: %w", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	for _, benchmark := range bench.Benchmarks {
+		if !pattern.MatchString(benchmark.Name) {
+			continue
+		}
+		result := testing.Benchmark(benchmark.Run)
+		fmt.Printf("%-12s %s %s\n", benchmark.Name, result.String(), result.MemString())
+	}
+
+	if memProfile != "" {
+		file, err := os.Create(memProfile)
+		if err != nil {
+			return fmt.Errorf("Failed to create heap profile: %w", err)
+		}
+		defer file.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(file); err != nil {
+			return fmt.Errorf("Failed to write heap profile: %w", err)
+		}
+	}
+	return nil
+}
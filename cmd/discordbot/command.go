@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/presets"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// Discord drops an interaction that isn't answered within three seconds
+const searchBudget = 2 * time.Second
+
+const usageText = "Try `!turbine 9x14 enderium`, `!turbine 9x14 enderium 20000` for a steam source of 20000 mB/t " +
+	"or `!turbine preset <owner> <name>` for a preset saved on the site"
+
+// query is a parsed command, the same inputs runOptimizer takes
+type query struct {
+	MaxWidth  int32  `json:"maxWidth"`
+	MaxHeight int32  `json:"maxHeight"`
+	Coil      string `json:"coil"`
+	// steam the source provides, zero runs every turbine at its max flow
+	Flow int64 `json:"flow"`
+}
+
+// parseQuery reads "9x14 enderium [flow]" with or without the "!turbine" in front
+func parseQuery(text string) (query, error) {
+	fields := strings.Fields(text)
+	if len(fields) > 0 && strings.EqualFold(fields[0], "!turbine") {
+		fields = fields[1:]
+	}
+	if len(fields) != 2 && len(fields) != 3 {
+		return query{}, errors.New(usageText)
+	}
+
+	widthText, heightText, ok := strings.Cut(strings.ToLower(fields[0]), "x")
+	if !ok {
+		return query{}, fmt.Errorf("%q is not a size like 9x14", fields[0])
+	}
+	width, err := strconv.ParseInt(widthText, 10, 32)
+	if err != nil {
+		return query{}, fmt.Errorf("%q is not a width", widthText)
+	}
+	height, err := strconv.ParseInt(heightText, 10, 32)
+	if err != nil {
+		return query{}, fmt.Errorf("%q is not a height", heightText)
+	}
+
+	parsed := query{MaxWidth: int32(width), MaxHeight: int32(height), Coil: fields[1]}
+	if len(fields) == 3 {
+		parsed.Flow, err = strconv.ParseInt(strings.TrimSuffix(strings.ToLower(fields[2]), "mb/t"), 10, 64)
+		if err != nil || parsed.Flow <= 0 {
+			return query{}, fmt.Errorf("%q is not a flow rate in mB/t", fields[2])
+		}
+	}
+	return parsed, nil
+}
+
+// coilName matches the coil ignoring case, with the usual suggestion if nothing matches
+func coilName(config *turbine.Config, name string) (string, error) {
+	for _, material := range config.CoilMaterials() {
+		if strings.EqualFold(material.Name, name) {
+			return material.Name, nil
+		}
+	}
+	_, err := config.Coil(name)
+	return "", err
+}
+
+// optimize searches like the site does with its default options
+func optimize(request query) (turbine.SearchResult, error) {
+	config := &turbine.BiggerReactorsConfig
+	if err := config.ValidateMaxSize(request.MaxWidth, request.MaxHeight); err != nil {
+		return turbine.SearchResult{}, err
+	}
+	coil, err := config.Coil(request.Coil)
+	if err != nil {
+		return turbine.SearchResult{}, err
+	}
+
+	flowSetting := turbine.FlowSetting{Variant: turbine.UseMaxFlow}
+	if request.Flow > 0 {
+		flowSetting = turbine.FlowSetting{Variant: turbine.FindBestUnderFlow, Value: request.Flow}
+	}
+	maxSize := turbine.Size{X: request.MaxWidth, Y: request.MaxHeight, Z: request.MaxWidth}
+	options := turbine.NewOptions(turbine.MetricFitness(turbine.MaximizeEnergy, 0), func(turbine.Turbine) bool { return true }, coil, flowSetting, maxSize)
+	options.LimitNoLoadRPM = config.MaxSafeRPM > 0
+	options.TimeBudget = searchBudget
+
+	result := turbine.Search(options)
+	return result, result.Err()
+}
+
+// presetClient loads presets from the site's api, the bot never opens the presets database the site holds
+type presetClient struct {
+	site   string
+	client *http.Client
+}
+
+// load reads a preset whose inputs are a query
+func (source presetClient) load(owner, name string) (query, error) {
+	if source.site == "" {
+		return query{}, errors.New("Presets are not set up on this bot")
+	}
+	address := fmt.Sprintf("%s/api/presets/%s/%s", strings.TrimSuffix(source.site, "/"), url.PathEscape(owner), url.PathEscape(name))
+	response, err := source.client.Get(address)
+	if err != nil {
+		return query{}, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return query{}, presets.ErrNotFound
+	}
+	if response.StatusCode != http.StatusOK {
+		return query{}, fmt.Errorf("Loading the preset failed with %s", response.Status)
+	}
+
+	var preset presets.Preset
+	if err := json.NewDecoder(response.Body).Decode(&preset); err != nil {
+		return query{}, err
+	}
+	var inputs query
+	if err := json.Unmarshal(preset.Inputs, &inputs); err != nil || inputs.MaxWidth == 0 || inputs.Coil == "" {
+		return query{}, fmt.Errorf("Preset %q has no maxWidth, maxHeight and coil", name)
+	}
+	return inputs, nil
+}
+
+// answer turns the command text into the embed to reply with
+func (source presetClient) answer(text string) embed {
+	fields := strings.Fields(text)
+	if len(fields) > 0 && strings.EqualFold(fields[0], "!turbine") {
+		fields = fields[1:]
+	}
+
+	var request query
+	var err error
+	if len(fields) > 0 && strings.EqualFold(fields[0], "preset") {
+		if len(fields) != 3 {
+			return errorEmbed(errors.New(usageText))
+		}
+		request, err = source.load(fields[1], fields[2])
+	} else {
+		request, err = parseQuery(strings.Join(fields, " "))
+	}
+	if err != nil {
+		return errorEmbed(err)
+	}
+	request.Coil, err = coilName(&turbine.BiggerReactorsConfig, request.Coil)
+	if err != nil {
+		return errorEmbed(err)
+	}
+
+	result, err := optimize(request)
+	if err != nil {
+		return errorEmbed(err)
+	}
+	return resultEmbed(request, result)
+}
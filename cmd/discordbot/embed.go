@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/format"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// embed colors, the site's accent and a plain red
+const resultColor = 0x3b82f6
+const errorColor = 0xdc2626
+
+type embed struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color"`
+	Fields      []embedField `json:"fields,omitempty"`
+	Footer      *embedFooter `json:"footer,omitempty"`
+}
+
+type embedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type embedFooter struct {
+	Text string `json:"text"`
+}
+
+var locale, _ = format.LocaleByName("en")
+
+func errorEmbed(err error) embed {
+	return embed{Title: "No turbine", Description: err.Error(), Color: errorColor}
+}
+
+func resultEmbed(request query, search turbine.SearchResult) embed {
+	result := search.Turbine.Result()
+	stats := result.Stats
+
+	title := fmt.Sprintf("Best %s turbine up to %dx%d", request.Coil, request.MaxWidth, request.MaxHeight)
+	if request.Flow > 0 {
+		title += fmt.Sprintf(" on %s mB/t", locale.Number(float64(request.Flow), 0))
+	}
+
+	built := embed{
+		Title:       title,
+		Description: fmt.Sprintf("%dx%dx%d with %d coil layers (%d coils)", stats.Width, stats.Width, stats.Height, stats.CoilLayers, stats.CoilSize),
+		Color:       resultColor,
+		Fields: []embedField{
+			{"Output", locale.SI(stats.EnergyGenerated, "RF/t"), true},
+			{"Steam", locale.Number(float64(stats.FlowRate), 0) + " mB/t", true},
+			{"Rotor", locale.Number(stats.RPM, 0) + " rpm", true},
+			{"Rotor efficiency", locale.Number(stats.RotorEfficiency*100, 1) + "%", true},
+			{"Coil efficiency", locale.Number(stats.CoilEfficiency*100, 1) + "%", true},
+			{"RF per mB", locale.Number(result.EnergyPerSteam, 2), true},
+			{"Build cost", buildCostText(search.Turbine), false},
+		},
+	}
+
+	if len(result.Warnings) > 0 {
+		warnings := make([]string, len(result.Warnings))
+		for i, warning := range result.Warnings {
+			warnings[i] = warning.Message
+		}
+		built.Fields = append(built.Fields, embedField{"Warnings", strings.Join(warnings, "\n"), false})
+	}
+
+	notes := search.Notes
+	if search.Truncated {
+		notes = append(notes, "The search ran out of time, this is the best turbine it found so far")
+	}
+	if len(notes) > 0 {
+		built.Footer = &embedFooter{strings.Join(notes, "\n")}
+	}
+	return built
+}
+
+// buildCostText is one line per block with glass walls, in stacks like the site shows them
+func buildCostText(built turbine.Turbine) string {
+	var lines strings.Builder
+	for _, item := range built.BuildCostWith(turbine.GlassWalls) {
+		fmt.Fprintf(&lines, "%s: %s\n", item.Name, item.StackText())
+	}
+	return strings.TrimSuffix(lines.String(), "\n")
+}
@@ -0,0 +1,126 @@
+// discordbot answers "/turbine 9x14 enderium" slash commands with the best turbine and its build cost.
+// It runs as the interactions endpoint of a Discord application, so it only needs to be reachable over
+// https and never keeps a gateway connection open. The query takes the same text as "!turbine 9x14 enderium",
+// with or without the "!turbine".
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+)
+
+// interactions are small, anything bigger is not from Discord
+const maxInteractionBody = 1 << 16
+
+// interaction types and response types from the Discord api
+const (
+	interactionPing               = 1
+	interactionApplicationCommand = 2
+
+	responsePong           = 1
+	responseChannelMessage = 4
+)
+
+type interaction struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+}
+
+type interactionResponse struct {
+	Type int          `json:"type"`
+	Data *messageData `json:"data,omitempty"`
+}
+
+type messageData struct {
+	Embeds []embed `json:"embeds"`
+}
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to serve the interactions endpoint on")
+	keyHex := flag.String("key", os.Getenv("DISCORD_PUBLIC_KEY"), "the application's public key, from DISCORD_PUBLIC_KEY if not given")
+	site := flag.String("site", "", "address of the site whose presets \"preset <owner> <name>\" loads, presets are off without it")
+	logLevel := flag.String("log", "info", "lowest level logged: debug, info, warn, error or off")
+	flag.Parse()
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		logging.Errorf("%s", err)
+		os.Exit(2)
+	}
+	logging.SetLevel(level)
+
+	key, err := hex.DecodeString(*keyHex)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		logging.Errorf("The public key has to be %d bytes of hex", ed25519.PublicKeySize)
+		os.Exit(1)
+	}
+
+	presetClient := presetClient{*site, &http.Client{Timeout: time.Second}}
+	http.HandleFunc("POST /interactions", func(w http.ResponseWriter, r *http.Request) {
+		handleInteraction(w, r, ed25519.PublicKey(key), presetClient)
+	})
+
+	logging.Infof("Serving interactions on %s", *addr)
+	if err := http.ListenAndServe(*addr, nil); err != nil {
+		logging.Errorf("Failed to start server: %s", err)
+		os.Exit(1)
+	}
+}
+
+func handleInteraction(w http.ResponseWriter, r *http.Request, key ed25519.PublicKey, presetClient presetClient) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInteractionBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Discord signs the timestamp followed by the body and checks that unsigned requests are turned away
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+	message := append([]byte(r.Header.Get("X-Signature-Timestamp")), body...)
+	if err != nil || !ed25519.Verify(key, message, signature) {
+		http.Error(w, "Invalid request signature", http.StatusUnauthorized)
+		return
+	}
+
+	var received interaction
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&received); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response interactionResponse
+	switch received.Type {
+	case interactionPing:
+		response.Type = responsePong
+	case interactionApplicationCommand:
+		text := ""
+		for _, option := range received.Data.Options {
+			text += " " + option.Value
+		}
+		response.Type = responseChannelMessage
+		response.Data = &messageData{[]embed{presetClient.answer(text)}}
+	default:
+		http.Error(w, fmt.Sprintf("Unknown interaction type %d", received.Type), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logging.Warnf("Failed to write response: %s", err)
+	}
+}
@@ -0,0 +1,85 @@
+// golden compares the turbine model against csv tick dumps captured in game and prints the error
+// in rpm and RF/t for each dump and for each profile
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/drabart/turbine-calculator-website/pkg/golden"
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+)
+
+func main() {
+	profile := flag.String("profile", "", "compare every dump with this profile instead of the one in its header")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: golden [-profile name] dump.csv...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Args(), *profile); err != nil {
+		logging.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func run(paths []string, profile string) error {
+	byProfile := map[string]*golden.Comparison{}
+	for _, path := range paths {
+		comparison, err := compareFile(path, profile)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		printComparison(path, comparison)
+
+		if total, ok := byProfile[comparison.Profile]; ok {
+			total.Merge(comparison)
+		} else {
+			byProfile[comparison.Profile] = &comparison
+		}
+	}
+
+	fmt.Println()
+	profiles := make([]string, 0, len(byProfile))
+	for name := range byProfile {
+		profiles = append(profiles, name)
+	}
+	slices.Sort(profiles)
+	for _, name := range profiles {
+		total := byProfile[name]
+		printComparison(fmt.Sprintf("%s (%d dumps)", name, total.Dumps), *total)
+	}
+	return nil
+}
+
+func compareFile(path, profile string) (golden.Comparison, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return golden.Comparison{}, err
+	}
+	defer file.Close()
+
+	dump, err := golden.Load(file)
+	if err != nil {
+		return golden.Comparison{}, err
+	}
+	if profile != "" {
+		dump.Profile = profile
+	}
+	return golden.Compare(dump)
+}
+
+func printComparison(name string, comparison golden.Comparison) {
+	rpm := comparison.RPM.Stats()
+	energy := comparison.Energy.Stats()
+	fmt.Printf("%s: %d samples\n", name, rpm.Samples)
+	fmt.Printf("  rpm   mean %10.2f  rms %10.2f  max %10.2f  relative %6.2f%%\n", rpm.MeanAbsolute, rpm.RMS, rpm.MaxAbsolute, rpm.MeanRelative*100)
+	fmt.Printf("  RF/t  mean %10.2f  rms %10.2f  max %10.2f  relative %6.2f%%\n", energy.MeanAbsolute, energy.RMS, energy.MaxAbsolute, energy.MeanRelative*100)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+	turbinev1 "github.com/drabart/turbine-calculator-website/proto/turbine/v1"
+)
+
+const Port = ":9090"
+
+func main() {
+	port := flag.String("port", Port, "address the service listens on")
+	maxBudget := flag.Duration("budget", 30*time.Second, "longest an Optimize call may search, shorter time budgets in the request are kept")
+	logLevel := flag.String("log", "info", "lowest level logged: debug, info, warn, error or off")
+	flag.Parse()
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		logging.Errorf("%s", err)
+		return
+	}
+	logging.SetLevel(level)
+
+	listener, err := net.Listen("tcp", *port)
+	if err != nil {
+		logging.Errorf("Failed to listen on %s: %s", *port, err)
+		return
+	}
+
+	server := grpc.NewServer()
+	turbinev1.RegisterTurbineServiceServer(server, &turbineService{maxBudget: *maxBudget})
+
+	logging.Infof("Starting gRPC server on port %s", *port)
+	if err := server.Serve(listener); err != nil {
+		logging.Errorf("Failed to serve: %s", err)
+	}
+}
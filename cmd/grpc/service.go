@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+	turbinev1 "github.com/drabart/turbine-calculator-website/proto/turbine/v1"
+)
+
+// turbineService answers the calls of proto/turbine/v1 with pkg/turbine
+type turbineService struct {
+	turbinev1.UnimplementedTurbineServiceServer
+	// an Optimize call asking for no time budget or a longer one gets this
+	maxBudget time.Duration
+}
+
+func (service *turbineService) Evaluate(ctx context.Context, request *turbinev1.EvaluateRequest) (*turbinev1.Result, error) {
+	designTurbine, err := turbineFromRequest(request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return resultToProto(designTurbine.Result()), nil
+}
+
+func (service *turbineService) Optimize(ctx context.Context, request *turbinev1.OptimizeRequest) (*turbinev1.OptimizeResponse, error) {
+	options, err := optionsFromRequest(request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if options.TimeBudget <= 0 || options.TimeBudget > service.maxBudget {
+		options.TimeBudget = service.maxBudget
+	}
+	// a client hanging up stops the search
+	options.Cancelled = func() bool {
+		return ctx.Err() != nil
+	}
+
+	result := turbine.Search(options)
+	if err := result.Err(); err != nil {
+		return nil, statusError(err)
+	}
+	return &turbinev1.OptimizeResponse{
+		Result:      resultToProto(result.Turbine.Result()),
+		Truncated:   result.Truncated,
+		Evaluations: result.Evaluations,
+		Notes:       result.Notes,
+	}, nil
+}
+
+func (service *turbineService) Sweep(request *turbinev1.SweepRequest, stream grpc.ServerStreamingServer[turbinev1.FlowPoint]) error {
+	if request.Step <= 0 {
+		return status.Error(codes.InvalidArgument, "Step has to be positive")
+	}
+	designTurbine, err := turbineFromRequest(request.Turbine)
+	if err != nil {
+		return statusError(err)
+	}
+
+	// SweepFlow can't be stopped, the points after a failed send are skipped
+	var sendErr error
+	designTurbine.SweepFlow(request.From, request.To, request.Step, func(point turbine.FlowPoint) {
+		if sendErr == nil {
+			sendErr = stream.Context().Err()
+		}
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&turbinev1.FlowPoint{
+			FlowRate:        point.FlowRate,
+			Rpm:             point.RPM,
+			EnergyGenerated: point.EnergyGenerated,
+			RotorEfficiency: point.RotorEfficiency,
+			CoilEfficiency:  point.CoilEfficiency,
+		})
+	})
+	return sendErr
+}
+
+// configFromProfile is the named profile's config, the default one for an empty name
+func configFromProfile(name string) (*turbine.Config, error) {
+	if name == "" {
+		name = turbine.DefaultProfileName
+	}
+	profile, err := turbine.ProfileByName(name)
+	if err != nil {
+		return nil, turbine.ValidationError{Field: "profile", Message: err.Error()}
+	}
+	return profile.Config, nil
+}
+
+func turbineFromRequest(request *turbinev1.EvaluateRequest) (turbine.Turbine, error) {
+	if request.GetDesign() == nil {
+		return turbine.Turbine{}, turbine.ValidationError{Field: "design", Message: "Design is missing"}
+	}
+	config, err := configFromProfile(request.Profile)
+	if err != nil {
+		return turbine.Turbine{}, err
+	}
+	design := request.Design
+	return turbine.New(config, turbine.Design{
+		Width:      design.Width,
+		Height:     design.Height,
+		CoilLayers: design.CoilLayers,
+		Coil:       design.Coil,
+		Blade:      design.Blade,
+		Fluid:      design.Fluid,
+		FlowRate:   design.FlowRate,
+	})
+}
+
+var flowModes = map[turbinev1.FlowMode]turbine.FlowSettingVariant{
+	turbinev1.FlowMode_FLOW_MODE_UNSPECIFIED: turbine.UseMaxFlow,
+	turbinev1.FlowMode_FLOW_MODE_MAX:         turbine.UseMaxFlow,
+	turbinev1.FlowMode_FLOW_MODE_BEST:        turbine.FindBestFlow,
+	turbinev1.FlowMode_FLOW_MODE_SET:         turbine.UseSetFlow,
+	turbinev1.FlowMode_FLOW_MODE_BEST_UNDER:  turbine.FindBestUnderFlow,
+	turbinev1.FlowMode_FLOW_MODE_TARGET_RPM:  turbine.UseTargetRPM,
+}
+
+var fitnessMetrics = map[turbinev1.FitnessMetric]turbine.FitnessMetric{
+	turbinev1.FitnessMetric_FITNESS_METRIC_UNSPECIFIED:       turbine.MaximizeEnergy,
+	turbinev1.FitnessMetric_FITNESS_METRIC_ENERGY:            turbine.MaximizeEnergy,
+	turbinev1.FitnessMetric_FITNESS_METRIC_ENERGY_PER_STEAM:  turbine.MaximizeEnergyPerSteam,
+	turbinev1.FitnessMetric_FITNESS_METRIC_ENERGY_PER_VOLUME: turbine.MaximizeEnergyPerVolume,
+}
+
+// optionsFromRequest reads an Optimize call the way searchOptionsFromJS reads runOptimizer's arguments
+func optionsFromRequest(request *turbinev1.OptimizeRequest) (turbine.Options, error) {
+	config, err := configFromProfile(request.Profile)
+	if err != nil {
+		return turbine.Options{}, err
+	}
+	if err := config.ValidateMaxSize(request.MaxWidth, request.MaxHeight); err != nil {
+		return turbine.Options{}, err
+	}
+	maxDepth := request.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = request.MaxWidth
+	}
+	coilType, err := config.Coil(request.Coil)
+	if err != nil {
+		return turbine.Options{}, err
+	}
+
+	variant, ok := flowModes[request.FlowMode]
+	if !ok {
+		return turbine.Options{}, turbine.ValidationError{Field: "flowMode", Message: fmt.Sprintf("Unknown flow mode %d", request.FlowMode)}
+	}
+	// every mode but max flow steps through or holds flowValue
+	if variant != turbine.UseMaxFlow && request.FlowValue <= 0 {
+		return turbine.Options{}, turbine.ValidationError{Field: "flowValue", Message: "Flow value has to be positive"}
+	}
+	if request.FlowTolerance < 0 {
+		return turbine.Options{}, turbine.ValidationError{Field: "flowTolerance", Message: "Flow tolerance cannot be negative"}
+	}
+	flowSetting := turbine.FlowSetting{Variant: variant, Value: request.FlowValue, Tolerance: request.FlowTolerance}
+
+	fitnessMetric, ok := fitnessMetrics[request.Fitness]
+	if !ok {
+		return turbine.Options{}, turbine.ValidationError{Field: "fitness", Message: fmt.Sprintf("Unknown fitness metric %d", request.Fitness)}
+	}
+	constraintsFunction := func(turbine turbine.Turbine) bool {
+		return true
+	}
+	maxSize := turbine.Size{X: request.MaxWidth, Y: request.MaxHeight, Z: maxDepth}
+
+	options := turbine.NewOptions(turbine.MetricFitness(fitnessMetric, request.MinEnergy), constraintsFunction, coilType, flowSetting, maxSize)
+	options.Config = config
+	// never recommend a rotor that breaks when the coils trip
+	options.LimitNoLoadRPM = config.MaxSafeRPM > 0
+	if request.Blade != "" {
+		if options.Blade, err = config.Blade(request.Blade); err != nil {
+			return turbine.Options{}, err
+		}
+	}
+	if request.Fluid != "" {
+		if options.Fluid, err = config.Fluid(request.Fluid); err != nil {
+			return turbine.Options{}, err
+		}
+	}
+	if request.MaxCoilLayers > 0 && request.MinCoilLayers > request.MaxCoilLayers {
+		return turbine.Options{}, turbine.ValidationError{Field: "minCoilLayers", Message: "minCoilLayers cannot be larger than maxCoilLayers"}
+	}
+	options.MinCoilLayers = request.MinCoilLayers
+	options.MaxCoilLayers = request.MaxCoilLayers
+	options.MaxEvaluations = request.MaxEvaluations
+	options.TimeBudget = time.Duration(request.TimeBudgetMs) * time.Millisecond
+	return options, nil
+}
+
+// statusError maps searches that found nothing to NotFound, the other errors come from the request's values
+func statusError(err error) error {
+	var validationErr turbine.ValidationError
+	var infeasibleErr turbine.InfeasibleError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &infeasibleErr) && infeasibleErr.Constraint == "cancelled":
+		return status.Error(codes.Canceled, err.Error())
+	case errors.As(err, &infeasibleErr) && infeasibleErr.Constraint == "budget":
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, turbine.ErrNoTurbine):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func resultToProto(result turbine.Result) *turbinev1.Result {
+	stats := result.Stats
+	message := &turbinev1.Result{
+		Stats: &turbinev1.Stats{
+			Width:           stats.Width,
+			Height:          stats.Height,
+			Rpm:             stats.RPM,
+			CoilSize:        stats.CoilSize,
+			CoilLayers:      stats.CoilLayers,
+			OuterRingCoils:  stats.OuterRingCoils,
+			FlowRate:        stats.FlowRate,
+			MaxFlowRate:     stats.MaxFlowRate,
+			RotorShafts:     stats.RotorShafts,
+			Blade:           stats.Blade,
+			Fluid:           stats.Fluid,
+			EnergyGenerated: stats.EnergyGenerated,
+			RotorEfficiency: stats.RotorEfficiency,
+			InductorDrag:    stats.InductorDrag,
+			FrictionDrag:    stats.FrictionDrag,
+			AeroDrag:        stats.AeroDrag,
+			CoilEfficiency:  stats.CoilEfficiency,
+		},
+		EnergyPerSteam: result.EnergyPerSteam,
+		RotorCapacity:  result.RotorCapacity,
+		SweetSpotFlow:  result.SweetSpotFlow,
+		NoLoadRpm:      result.NoLoadRPM,
+		Explanation:    result.Explanation,
+	}
+	for _, peakFlow := range result.PeakFlows {
+		message.PeakFlows = append(message.PeakFlows, &turbinev1.PeakFlow{Rpm: peakFlow.RPM, FlowRate: peakFlow.FlowRate, Reachable: peakFlow.Reachable})
+	}
+	for _, warning := range result.Warnings {
+		message.Warnings = append(message.Warnings, &turbinev1.Warning{Code: warning.Code.String(), Message: warning.Message})
+	}
+	return message
+}
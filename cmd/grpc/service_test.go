@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	turbinev1 "github.com/drabart/turbine-calculator-website/proto/turbine/v1"
+)
+
+// testClient serves the service over an in-memory connection
+func testClient(t *testing.T) turbinev1.TurbineServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	turbinev1.RegisterTurbineServiceServer(server, &turbineService{maxBudget: 5 * time.Second})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	dial := func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}
+	connection, err := grpc.NewClient("passthrough:///bufconn", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { connection.Close() })
+	return turbinev1.NewTurbineServiceClient(connection)
+}
+
+var testDesign = &turbinev1.Design{Width: 5, Height: 10, CoilLayers: 2, Coil: "Gold", FlowRate: 1000}
+
+func TestEvaluate(t *testing.T) {
+	client := testClient(t)
+
+	result, err := client.Evaluate(context.Background(), &turbinev1.EvaluateRequest{Design: testDesign})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := result.Stats; stats.Width != 5 || stats.Height != 10 || stats.CoilLayers != 2 || stats.FlowRate != 1000 {
+		t.Errorf("Evaluate returned %v for %v", stats, testDesign)
+	}
+	if result.Stats.EnergyGenerated <= 0 || result.Stats.Rpm <= 0 {
+		t.Errorf("Evaluate returned a turbine making %v RF/t at %v rpm", result.Stats.EnergyGenerated, result.Stats.Rpm)
+	}
+
+	_, err = client.Evaluate(context.Background(), &turbinev1.EvaluateRequest{Design: &turbinev1.Design{Width: 5, Height: 10, CoilLayers: 2, Coil: "Cheese", FlowRate: 1000}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Evaluate with an unknown coil returned %v, want InvalidArgument", err)
+	}
+	_, err = client.Evaluate(context.Background(), &turbinev1.EvaluateRequest{Design: testDesign, Profile: "NoSuchMod"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Evaluate with an unknown profile returned %v, want InvalidArgument", err)
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	client := testClient(t)
+
+	response, err := client.Optimize(context.Background(), &turbinev1.OptimizeRequest{MaxWidth: 7, MaxHeight: 12, Coil: "Gold", FlowMode: turbinev1.FlowMode_FLOW_MODE_MAX})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := response.Result.Stats
+	if stats.Width > 7 || stats.Height > 12 || stats.EnergyGenerated <= 0 {
+		t.Errorf("Optimize returned a %dx%d turbine making %v RF/t for a 7x12 room", stats.Width, stats.Height, stats.EnergyGenerated)
+	}
+	if response.Evaluations == 0 {
+		t.Error("Optimize reported no evaluations")
+	}
+
+	// best flow steps through multiples of the flow value, zero would never end
+	_, err = client.Optimize(context.Background(), &turbinev1.OptimizeRequest{MaxWidth: 7, MaxHeight: 12, Coil: "Gold", FlowMode: turbinev1.FlowMode_FLOW_MODE_BEST})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Optimize with a zero flow step returned %v, want InvalidArgument", err)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	client := testClient(t)
+
+	stream, err := client.Sweep(context.Background(), &turbinev1.SweepRequest{Turbine: &turbinev1.EvaluateRequest{Design: testDesign}, From: 100, To: 500, Step: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var flowRates []int64
+	for {
+		point, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		flowRates = append(flowRates, point.FlowRate)
+	}
+	if len(flowRates) != 5 || flowRates[0] != 100 || flowRates[4] != 500 {
+		t.Errorf("Sweep from 100 to 500 in steps of 100 sent %v", flowRates)
+	}
+
+	stream, err = client.Sweep(context.Background(), &turbinev1.SweepRequest{Turbine: &turbinev1.EvaluateRequest{Design: testDesign}, From: 100, To: 500})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Sweep without a step returned %v, want InvalidArgument", err)
+	}
+}
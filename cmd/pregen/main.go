@@ -0,0 +1,110 @@
+// pregen searches the best turbine for a grid of common room sizes and coils and writes each result as json,
+// so the site can show typical answers before the wasm module has loaded
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// the rooms people ask about most, as max width and max height
+var widths = []int32{5, 7, 9, 11, 13, 15}
+var heights = []int32{8, 12, 16, 20, 24, 32}
+
+// inputs are the runOptimizer arguments the result answers, flow is zero as the site runs at max flow by default
+type inputs struct {
+	MaxWidth  int32  `json:"maxWidth"`
+	MaxHeight int32  `json:"maxHeight"`
+	Coil      string `json:"coil"`
+	Flow      int64  `json:"flow"`
+}
+
+type pregenerated struct {
+	Inputs    inputs         `json:"inputs"`
+	Result    turbine.Result `json:"result"`
+	BuildCost build.Cost     `json:"buildCost"`
+}
+
+// index lists the grid so the site knows which queries it can answer without wasm
+type index struct {
+	Profile string   `json:"profile"`
+	Version string   `json:"version"`
+	Widths  []int32  `json:"widths"`
+	Heights []int32  `json:"heights"`
+	Coils   []string `json:"coils"`
+	// file names under the index, by "coil/widthxheight"
+	Files map[string]string `json:"files"`
+}
+
+func main() {
+	out := flag.String("out", "../../assets/pregen", "directory to write the json files to")
+	profileName := flag.String("profile", turbine.DefaultProfileName, "profile to search with")
+	flag.Parse()
+
+	if err := run(*out, *profileName); err != nil {
+		logging.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, profileName string) error {
+	profile, err := turbine.ProfileByName(profileName)
+	if err != nil {
+		return err
+	}
+	config := profile.Config
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+
+	generated := index{Profile: profile.Name, Version: turbine.Version, Widths: widths, Heights: heights, Files: map[string]string{}}
+	for _, coil := range config.CoilMaterials() {
+		generated.Coils = append(generated.Coils, coil.Name)
+		for _, width := range widths {
+			for _, height := range heights {
+				name := fmt.Sprintf("%s_%dx%d.json", strings.ToLower(coil.Name), width, height)
+				if err := generate(filepath.Join(out, name), config, coil, width, height); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				generated.Files[fmt.Sprintf("%s/%dx%d", coil.Name, width, height)] = name
+			}
+		}
+		logging.Infof("Generated %s", coil.Name)
+	}
+	return writeJSON(filepath.Join(out, "index.json"), generated)
+}
+
+// generate searches one room the way runOptimizer does with no options
+func generate(path string, config *turbine.Config, coil turbine.CoilMaterial, width, height int32) error {
+	maxSize := turbine.Size{X: width, Y: height, Z: width}
+	options := turbine.NewOptions(turbine.MetricFitness(turbine.MaximizeEnergy, 0), func(turbine.Turbine) bool { return true },
+		coil.CoilData, turbine.FlowSetting{Variant: turbine.UseMaxFlow}, maxSize)
+	options.Config = config
+	options.LimitNoLoadRPM = config.MaxSafeRPM > 0
+
+	best, err := turbine.Optimize(options)
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, pregenerated{
+		Inputs:    inputs{width, height, coil.Name, 0},
+		Result:    best.Result(),
+		BuildCost: best.BuildCostWith(turbine.GlassWalls),
+	})
+}
+
+func writeJSON(path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// mod config files are a few kilobytes
+const maxConfigBody = 1 << 20
+
+// how long each reference search of a balance report may take, a report runs around twenty of them
+const balanceSearchBudget = 2 * time.Second
+
+// a whole report is cut off after this, well under the twenty searches at their full budget
+const balanceDeadline = 20 * time.Second
+
+// reports running at once, each keeps a core busy for its searches
+const maxConcurrentBalances = 2
+
+// reports kept for configs uploaded again, pack makers tend to post the same file while they tune it
+const balanceCacheSize = 64
+
+type balanceResponse struct {
+	turbine.BalanceReport
+	// keys of the uploaded config that were left at the base profile's values
+	Ignored []string `json:"ignored"`
+}
+
+// balanceCache keeps the most recent reports by their imported config, the oldest goes first when it's full
+type balanceCache struct {
+	mutex   sync.Mutex
+	reports map[string]turbine.BalanceReport
+	keys    []string
+}
+
+func (cache *balanceCache) get(key string) (turbine.BalanceReport, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	report, ok := cache.reports[key]
+	return report, ok
+}
+
+func (cache *balanceCache) put(key string, report turbine.BalanceReport) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if _, ok := cache.reports[key]; ok {
+		return
+	}
+	if len(cache.keys) == balanceCacheSize {
+		delete(cache.reports, cache.keys[0])
+		cache.keys = cache.keys[1:]
+	}
+	cache.reports[key] = report
+	cache.keys = append(cache.keys, key)
+}
+
+// registerBalanceRoutes adds
+//
+//	POST /api/balance?base=BiggerReactors-0.6   the mod's TOML config in the body, returns the best turbine for
+//	                                            each size tier and every coil in a reference room
+func registerBalanceRoutes(mux *http.ServeMux) {
+	cache := &balanceCache{reports: map[string]turbine.BalanceReport{}}
+	running := make(chan struct{}, maxConcurrentBalances)
+
+	mux.HandleFunc("POST /api/balance", func(w http.ResponseWriter, r *http.Request) {
+		baseName := r.URL.Query().Get("base")
+		if baseName == "" {
+			baseName = turbine.DefaultProfileName
+		}
+		base, err := turbine.ProfileByName(baseName)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+
+		text, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConfigBody))
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		// the import validates the limits and constants, a config that would only give NaN steady states is a 400
+		config, ignored, err := turbine.ImportModConfig(string(text), base.Config)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+
+		// the same values in another order, with other comments or on another base come out as the same config
+		normalized, err := json.Marshal(config)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		key := string(normalized)
+		if report, ok := cache.get(key); ok {
+			writeJSON(w, http.StatusOK, balanceResponse{report, append([]string{}, ignored...)})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), balanceDeadline)
+		defer cancel()
+		select {
+		case running <- struct{}{}:
+			defer func() { <-running }()
+		case <-ctx.Done():
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "Too many balance reports are running, try again later"})
+			return
+		}
+
+		report := turbine.Balance(config, balanceSearchBudget, func() bool {
+			return ctx.Err() != nil
+		})
+		if report.Cancelled {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "The balance report took too long"})
+			return
+		}
+		// a report that doesn't encode is never cached, the next upload searches again
+		response, err := json.Marshal(balanceResponse{report, append([]string{}, ignored...)})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		cache.put(key, report)
+		writeJSON(w, http.StatusOK, json.RawMessage(response))
+	})
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBalanceRejectsDegenerateConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	registerBalanceRoutes(mux)
+
+	// both rotor masses at zero made every steady state NaN, it used to come back as an empty 200
+	body := "[Turbine]\nrotorAxialMassPerShaft = 0\nrotorAxialMassPerBlade = 0\n"
+	request := httptest.NewRequest(http.MethodPost, "/api/balance", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+	var response map[string]string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("body %q: %s", recorder.Body.String(), err)
+	}
+	if !strings.Contains(response["error"], "rotorAxialMass") {
+		t.Errorf("error %q doesn't name the rotor mass", response["error"])
+	}
+}
+
+func TestWriteJSONUnencodable(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	writeJSON(recorder, http.StatusOK, map[string]float64{"rpm": math.NaN()})
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, want %d", recorder.Code, http.StatusInternalServerError)
+	}
+	if !json.Valid(recorder.Body.Bytes()) {
+		t.Errorf("body %q is not json", recorder.Body.String())
+	}
+}
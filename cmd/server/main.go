@@ -1,18 +1,52 @@
 package main
 
 import (
-	"fmt"
+	"flag"
 	"net/http"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+	"github.com/drabart/turbine-calculator-website/pkg/presets"
+	"github.com/drabart/turbine-calculator-website/pkg/usage"
 )
 
 const Port = ":8080"
 
 func main() {
-	fmt.Println("Starting server on port", Port)
-	err := http.ListenAndServe(Port, http.FileServer(http.Dir("../../assets")))
+	dbPath := flag.String("db", "presets.db", "file the saved presets are kept in")
+	usagePath := flag.String("usage", "usage.db", "file the usage counts are kept in")
+	logLevel := flag.String("log", "info", "lowest level logged: debug, info, warn, error or off")
+	flag.Parse()
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		logging.Errorf("%s", err)
+		return
+	}
+	logging.SetLevel(level)
+
+	store, err := presets.Open(*dbPath)
+	if err != nil {
+		logging.Errorf("Failed to open presets: %s", err)
+		return
+	}
+	defer store.Close()
+
+	usageStore, err := usage.Open(*usagePath)
 	if err != nil {
-		fmt.Println("Failed to start server", err)
+		logging.Errorf("Failed to open usage counts: %s", err)
 		return
 	}
-	fmt.Println("Started server on port ", Port)
+	defer usageStore.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.Dir("../../assets")))
+	registerPresetRoutes(mux, store)
+	registerUsageRoutes(mux, usageStore)
+	registerBalanceRoutes(mux)
+
+	logging.Infof("Starting server on port %s", Port)
+	err = http.ListenAndServe(Port, mux)
+	if err != nil {
+		logging.Errorf("Failed to start server: %s", err)
+	}
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+	"github.com/drabart/turbine-calculator-website/pkg/presets"
+	"github.com/drabart/turbine-calculator-website/pkg/usage"
+)
+
+// presets are small, anything bigger than this is not a preset
+const maxPresetBody = 1 << 20
+
+// writeJSON encodes value before sending the header, so a value that can't be encoded gets a 500 instead of an
+// empty 200
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		logging.Errorf("Failed to encode response: %s", err)
+		status = http.StatusInternalServerError
+		body = []byte(`{"error":"Internal server error"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		logging.Warnf("Failed to write response: %s", err)
+	}
+}
+
+// writeError answers 404 for a missing preset, 403 for another owner's token and 400 for values the stores turn
+// down. Anything else is the server failing, it is logged and answered with a 500 that doesn't show it.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, presets.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, presets.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, presets.ErrInvalid), errors.Is(err, usage.ErrInvalid):
+		status = http.StatusBadRequest
+	}
+	if status == http.StatusInternalServerError {
+		logging.Errorf("Request failed: %s", err)
+		writeJSON(w, status, map[string]string{"error": "Internal server error"})
+		return
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeBadRequest answers 400 for errors that can only come from the request, like a body that doesn't decode
+func writeBadRequest(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+}
+
+// ownerToken is the bearer token of the Authorization header, empty without one
+func ownerToken(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
+
+// registerPresetRoutes adds
+//
+//	GET    /api/presets/{owner}         every preset of the owner
+//	GET    /api/presets/{owner}/{name}  one preset
+//	PUT    /api/presets/{owner}/{name}  save {inputs, pinned}
+//	DELETE /api/presets/{owner}/{name}
+//
+// PUT and DELETE need "Authorization: Bearer <token>" with the token of the owner's first save
+func registerPresetRoutes(mux *http.ServeMux, store *presets.Store) {
+	mux.HandleFunc("GET /api/presets/{owner}", func(w http.ResponseWriter, r *http.Request) {
+		list, err := store.List(r.PathValue("owner"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, list)
+	})
+
+	mux.HandleFunc("GET /api/presets/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		preset, err := store.Load(r.PathValue("owner"), r.PathValue("name"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, preset)
+	})
+
+	mux.HandleFunc("PUT /api/presets/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var preset presets.Preset
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPresetBody)).Decode(&preset); err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		preset.Name = r.PathValue("name")
+
+		if err := store.Save(r.PathValue("owner"), ownerToken(r), preset); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("DELETE /api/presets/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if err := store.Delete(r.PathValue("owner"), ownerToken(r), r.PathValue("name")); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
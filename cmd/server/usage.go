@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+	"github.com/drabart/turbine-calculator-website/pkg/usage"
+)
+
+// a query is a handful of numbers
+const maxUsageBody = 4 << 10
+
+const defaultPopularLimit = 10
+const maxPopularLimit = 100
+
+// registerUsageRoutes adds
+//
+//	POST /api/usage?profile=BiggerReactors-0.6   count one optimizer run, the site only sends it if the user
+//	                                             opted in. The coil and sizes have to fit the profile.
+//	GET  /api/popular?limit=10                   the most common coils, size ranges and designs
+func registerUsageRoutes(mux *http.ServeMux, store *usage.Store) {
+	mux.HandleFunc("POST /api/usage", func(w http.ResponseWriter, r *http.Request) {
+		profileName := r.URL.Query().Get("profile")
+		if profileName == "" {
+			profileName = turbine.DefaultProfileName
+		}
+		profile, err := turbine.ProfileByName(profileName)
+		if err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+
+		var query usage.Query
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUsageBody)).Decode(&query); err != nil {
+			writeBadRequest(w, err)
+			return
+		}
+		if err := store.Record(profile.Config, query); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /api/popular", func(w http.ResponseWriter, r *http.Request) {
+		limit := defaultPopularLimit
+		if value := r.URL.Query().Get("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit has to be a positive number"})
+				return
+			}
+			limit = min(parsed, maxPopularLimit)
+		}
+
+		popular, err := store.Popular(limit)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, popular)
+	})
+}
@@ -0,0 +1,161 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"math"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// optional settings are passed from js as a plain object in the last argument
+func optionsArg(args []js.Value, index int) js.Value {
+	if len(args) <= index || args[index].Type() != js.TypeObject {
+		return js.Undefined()
+	}
+	return args[index]
+}
+
+func optionalString(options js.Value, key string) (string, bool) {
+	if options.Type() != js.TypeObject {
+		return "", false
+	}
+	value := options.Get(key)
+	if value.Type() != js.TypeString {
+		return "", false
+	}
+	return value.String(), true
+}
+
+// jsInt reads a js number clamped to the int32 range, so sizes and rates built from it cannot overflow.
+// NaN reads as zero.
+func jsInt(value js.Value) int {
+	number := value.Float()
+	if math.IsNaN(number) {
+		return 0
+	}
+	return int(min(max(number, math.MinInt32), math.MaxInt32))
+}
+
+func optionalInt(options js.Value, key string) (int, bool) {
+	if options.Type() != js.TypeObject {
+		return 0, false
+	}
+	value := options.Get(key)
+	if value.Type() != js.TypeNumber {
+		return 0, false
+	}
+	return jsInt(value), true
+}
+
+func optionalFloat(options js.Value, key string) (float64, bool) {
+	if options.Type() != js.TypeObject {
+		return 0, false
+	}
+	value := options.Get(key)
+	if value.Type() != js.TypeNumber {
+		return 0, false
+	}
+	return value.Float(), true
+}
+
+func optionalBool(options js.Value, key string) (bool, bool) {
+	if options.Type() != js.TypeObject {
+		return false, false
+	}
+	value := options.Get(key)
+	if value.Type() != js.TypeBoolean {
+		return false, false
+	}
+	return value.Bool(), true
+}
+
+// applyRoomOptions reads the room shape: "maxDepth" when it isn't as deep as it is wide
+// and "keepDoorway" to leave a passage along a wall. "chunks" (ignore, prefer or require) keeps
+// the turbine within one chunk starting "chunkOffsetX" and "chunkOffsetZ" blocks into it.
+func applyRoomOptions(options *turbine.Options, jsOptions js.Value) error {
+	if maxDepth, ok := optionalInt(jsOptions, "maxDepth"); ok {
+		options.MaxSize.Z = int32(maxDepth)
+	}
+	if keepDoorway, ok := optionalBool(jsOptions, "keepDoorway"); ok {
+		options.KeepDoorway = keepDoorway
+	}
+
+	if name, ok := optionalString(jsOptions, "chunks"); ok {
+		mode, err := turbine.ParseChunkMode(name)
+		if err != nil {
+			return fieldError("chunks", err)
+		}
+		options.Chunks.Mode = mode
+	}
+	offsetX, _ := optionalInt(jsOptions, "chunkOffsetX")
+	offsetZ, _ := optionalInt(jsOptions, "chunkOffsetZ")
+	options.Chunks.OffsetX = int32(offsetX)
+	options.Chunks.OffsetZ = int32(offsetZ)
+	return nil
+}
+
+// configFromOptions picks the config from the "profile" or "modVariant" option,
+// with "maxSafeRPM" applied on a copy if given
+func configFromOptions(options js.Value) (*turbine.Config, error) {
+	config, err := baseConfigFromOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	if maxSafeRPM, ok := optionalFloat(options, "maxSafeRPM"); ok {
+		config = config.Clone()
+		config.MaxSafeRPM = maxSafeRPM
+	}
+	return stageConfig(config, options)
+}
+
+// stageConfig keeps the coils of the "gameStage" option, early, mid or late, or an array of the coil materials
+// the player unlocked
+func stageConfig(config *turbine.Config, options js.Value) (*turbine.Config, error) {
+	if options.Type() != js.TypeObject {
+		return config, nil
+	}
+	stage := options.Get("gameStage")
+	switch {
+	case stage.Type() == js.TypeString:
+		gameStage, err := turbine.ParseGameStage(stage.String())
+		if err != nil {
+			return nil, fieldError("gameStage", err)
+		}
+		return config.ForStage(gameStage)
+	case stage.InstanceOf(js.Global().Get("Array")):
+		names := make([]string, stage.Length())
+		for i := range names {
+			if stage.Index(i).Type() != js.TypeString {
+				return nil, apiError{Code: codeInvalidArguments, Message: "gameStage has to list coil material names", Field: "gameStage"}
+			}
+			names[i] = stage.Index(i).String()
+		}
+		return config.WithUnlockedCoils(names)
+	case stage.Type() != js.TypeUndefined && stage.Type() != js.TypeNull:
+		return nil, apiError{Code: codeInvalidArguments, Message: "gameStage has to be early, mid, late or a list of coil materials", Field: "gameStage"}
+	}
+	return config, nil
+}
+
+func baseConfigFromOptions(options js.Value) (*turbine.Config, error) {
+	profileName, ok := optionalString(options, "profile")
+	if !ok {
+		if variantName, ok := optionalString(options, "modVariant"); ok {
+			variant, err := turbine.ParseModVariant(variantName)
+			if err != nil {
+				return nil, err
+			}
+			return turbine.ConfigFor(variant)
+		}
+		// looked up rather than the bundled config, loadProfiles may have replaced the default profile
+		profileName = turbine.DefaultProfileName
+	}
+
+	profile, err := turbine.ProfileByName(profileName)
+	if err != nil {
+		return nil, err
+	}
+	return profile.Config, nil
+}
@@ -0,0 +1,57 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// float64Buffer copies values into a new ArrayBuffer as little-endian float64s in a single copy,
+// js wraps it with new Float64Array(buffer) or reads it through a DataView
+func float64Buffer(values []float64) js.Value {
+	bytes := make([]byte, 8*len(values))
+	for i, value := range values {
+		binary.LittleEndian.PutUint64(bytes[8*i:], math.Float64bits(value))
+	}
+	array := js.Global().Get("Uint8Array").New(len(bytes))
+	js.CopyBytesToJS(array, bytes)
+	return array.Get("buffer")
+}
+
+// binaryTable is the descriptor {columns, rows, buffer} for values laid out row by row
+func binaryTable(columns []string, values []float64) js.Value {
+	table := toJS(map[string]any{
+		"columns": columns,
+		"rows":    len(values) / len(columns),
+	})
+	table.Set("buffer", float64Buffer(values))
+	return table
+}
+
+var flowPointColumns = []string{"flowRate", "rpm", "energyGenerated", "rotorEfficiency", "coilEfficiency"}
+
+func flowPointTable(points []turbine.FlowPoint) js.Value {
+	values := make([]float64, 0, len(points)*len(flowPointColumns))
+	for _, point := range points {
+		values = append(values, float64(point.FlowRate), point.RPM, point.EnergyGenerated, point.RotorEfficiency, point.CoilEfficiency)
+	}
+	return binaryTable(flowPointColumns, values)
+}
+
+// heatMapTable is the heat map with its axes as usual and the values as a binaryTable, one column per x
+func heatMapTable(heatMap turbine.HeatMap, values [][]float64) js.Value {
+	flat := make([]float64, 0, len(heatMap.X)*len(values))
+	for _, row := range values {
+		flat = append(flat, row...)
+	}
+	heatMap.Values = nil
+	result := toJS(heatMap)
+	result.Set("rows", len(values))
+	result.Set("columns", len(heatMap.X))
+	result.Set("buffer", float64Buffer(flat))
+	return result
+}
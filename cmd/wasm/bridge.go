@@ -0,0 +1,29 @@
+//go:build js && wasm && !tinygo
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// toJS hands a go value to js through its json encoding, so result types only need json tags
+func toJS(value any) js.Value {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		// an apiError always encodes, so this can't recurse
+		return toJS(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	return js.Global().Get("JSON").Call("parse", string(encoded))
+}
+
+// plainResult is value as decoded json, for the unit conversion and formatting to walk through
+func plainResult(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	err = json.Unmarshal(encoded, &decoded)
+	return decoded, err
+}
@@ -0,0 +1,24 @@
+//go:build js && wasm && tinygo
+
+package main
+
+import (
+	"syscall/js"
+)
+
+// toJS builds the js value straight from the go value instead of encoding it to text and parsing it again.
+// Only this package keeps encoding/json out of the tinygo build, pkg/turbine, pkg/build, pkg/share and
+// pkg/machines still import it for their own encodings.
+func toJS(value any) js.Value {
+	plain, err := plainValue(value)
+	if err != nil {
+		// an apiError always converts, so this can't recurse
+		return toJS(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	return js.ValueOf(plain)
+}
+
+// plainResult is value as decoded json, for the unit conversion and formatting to walk through
+func plainResult(value any) (any, error) {
+	return plainValue(value)
+}
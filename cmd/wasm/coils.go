@@ -0,0 +1,112 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func getCoilMaterialsWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		config, err := configFromOptions(optionsArg(args, 0))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+
+		return toJS(config.CoilMaterials())
+	})
+}
+
+// compareCoils(size, options) takes {width, height, coilLayers} and an optional flowRate, the max flow rate
+// if not given, and returns {coils} with one row per coil material from the worst to the best
+func compareCoilsWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 1)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+
+		size := args[0]
+		width, okWidth := optionalInt(size, "width")
+		height, okHeight := optionalInt(size, "height")
+		coilLayers, okCoilLayers := optionalInt(size, "coilLayers")
+		if !okWidth || !okHeight || !okCoilLayers {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "Size needs width, height and coilLayers", Field: "size"})
+		}
+		if err := config.ValidateDesignSize(int32(width), int32(height)); err != nil {
+			return jsError(err)
+		}
+		flowRate, _ := optionalInt(size, "flowRate")
+
+		rows, err := turbine.CompareCoils(config, int32(height), int32(width), int32(coilLayers), int64(flowRate))
+		if err != nil {
+			return jsError(fieldError("coilLayers", err))
+		}
+		result, err := toJSResult(map[string]any{"coils": rows}, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
+
+// planCoilUpgrades(design, options) returns {upgrades}, every better coil material for the design
+// with the RF/t it gains and the ingots it takes, the most RF/t per ingot first
+func planCoilUpgradesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 1)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		upgrades, err := designTurbine.CoilUpgrades()
+		if err != nil {
+			return jsError(apiError{Code: codeInternal, Message: err.Error()})
+		}
+		result, err := toJSResult(map[string]any{"upgrades": upgrades}, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
+
+// getBladeMaterials(options) lists the rotor blade types of the profile
+func getBladeMaterialsWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		config, err := configFromOptions(optionsArg(args, 0))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+
+		return toJS(config.BladeMaterials())
+	})
+}
+
+// getFluids(options) lists steam and the other fluids the profile's turbines can run on
+func getFluidsWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		config, err := configFromOptions(optionsArg(args, 0))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+
+		return toJS(config.FluidMaterials())
+	})
+}
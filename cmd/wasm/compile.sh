@@ -1,3 +1,37 @@
 #!/bin/bash
 
+# ./compile.sh check builds every variant to a scratch directory without touching the assets. The tinygo files are
+# always compiled with the gc compiler under the tinygo tag, tinygo itself only builds them where it's installed.
+if [ "$1" = "check" ]; then
+	scratch=$(mktemp -d)
+	trap 'rm -rf "$scratch"' EXIT
+	GOOS=js GOARCH=wasm go build -o "$scratch/main.wasm" . || exit 1
+	GOOS=js GOARCH=wasm go build -tags debug -o "$scratch/debug.wasm" . || exit 1
+	# the gc compiler also builds the tinygo files, which catches type errors without tinygo
+	GOOS=js GOARCH=wasm go build -tags tinygo -o "$scratch/tinygo-files.wasm" . || exit 1
+	if command -v tinygo > /dev/null; then
+		tinygo build -o "$scratch/tinygo.wasm" -target wasm -no-debug -opt=z . || exit 1
+	else
+		echo "tinygo is not installed, only the tinygo tag was compiled" >&2
+	fi
+	ls -l "$scratch"
+	exit
+fi
+
+# ./compile.sh tinygo builds with tinygo, it needs tinygo's wasm_exec.js instead of go's
+if [ "$1" = "tinygo" ]; then
+	tinygo build -o ../../assets/main.wasm -target wasm -no-debug -opt=z .
+	cp "$(tinygo env TINYGOROOT)/targets/wasm_exec.js" ../../assets/wasm_exec.js
+	exit
+fi
+
+# ./compile.sh debug panics on physics going out of range, with a dump of the turbine in the console
+if [ "$1" = "debug" ]; then
+	GOOS=js GOARCH=wasm go build -tags debug -o ../../assets/main.wasm
+	exit
+fi
+
+# the default build also refreshes the results for common rooms shown while the module loads, they don't depend on
+# the compiler so the other variants leave them alone
+go run ../pregen -out ../../assets/pregen || exit 1
 GOOS=js GOARCH=wasm go build -o  ../../assets/main.wasm
@@ -0,0 +1,144 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// how long the optimizer waits after an update for the next one, a dragged slider sends many in a row
+const debounceInterval = 30 * time.Millisecond
+
+// continuousOptimizer is one newContinuousOptimizer instance, it searches again after every burst of updates
+type continuousOptimizer struct {
+	optimizer
+	onResult js.Value
+
+	mutex sync.Mutex
+	// arguments of the newest update not searched yet, nil when there are none
+	pending []js.Value
+	running bool
+	// fitness options of the cached scores, the cache can't tell when the fitness function changes
+	fitness string
+}
+
+// newContinuousOptimizer(options, onResult) returns an object that keeps searching as its arguments change:
+//
+//	update(maxWidth, maxHeight, coil, flow, options) takes the runOptimizer arguments and returns at once
+//	lastResult(options) is the result of the last finished search
+//	release() frees the methods once the instance isn't needed any more
+//
+// A search starts once updates stop coming for a moment, a newer update cuts the running one short. Every geometry's
+// best flow rate is cached, so searching a slightly bigger room only scores the new geometries. onResult(result)
+// gets the result of each search that wasn't cut short, or onResult(undefined, error) when it failed.
+func newContinuousOptimizerWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 || args[1].Type() != js.TypeFunction {
+			return jsError(errArgumentCount)
+		}
+		instance := &continuousOptimizer{optimizer: optimizer{options: optionsArg(args, 0)}, onResult: args[1]}
+		instance.lastSearch.continuous = turbine.NewContinuous()
+		return instance.toJS()
+	})
+}
+
+func (instance *continuousOptimizer) toJS() js.Value {
+	object := js.Global().Get("Object").New()
+	methods := map[string]func(args []js.Value) any{
+		"update": instance.update,
+		"lastResult": func(args []js.Value) any {
+			return instance.lastSearch.lastResult(instance.withOptions(args, 0))
+		},
+		"release": func(args []js.Value) any {
+			for _, function := range instance.funcs {
+				function.Release()
+			}
+			instance.funcs = nil
+			return nil
+		},
+	}
+	for name, method := range methods {
+		function := js.FuncOf(func(this js.Value, args []js.Value) any {
+			return method(args)
+		})
+		instance.funcs = append(instance.funcs, function)
+		object.Set(name, function)
+	}
+	return object
+}
+
+func (instance *continuousOptimizer) update(args []js.Value) any {
+	if len(args) != 4 && len(args) != 5 {
+		return jsError(errArgumentCount)
+	}
+
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	instance.pending = instance.withOptions(args, 4)
+	if !instance.running {
+		instance.running = true
+		go instance.work()
+	}
+	return nil
+}
+
+// next takes the newest update, or stops the worker when there is none
+func (instance *continuousOptimizer) next() []js.Value {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	args := instance.pending
+	instance.pending = nil
+	if args == nil {
+		instance.running = false
+	}
+	return args
+}
+
+func (instance *continuousOptimizer) superseded() bool {
+	instance.mutex.Lock()
+	defer instance.mutex.Unlock()
+	return instance.pending != nil
+}
+
+func (instance *continuousOptimizer) work() {
+	for {
+		// sleeping also hands control back to the event loop so the next update can arrive
+		time.Sleep(debounceInterval)
+		args := instance.next()
+		if args == nil {
+			return
+		}
+
+		jsOptions := optionsArg(args, 4)
+		fitnessName, _ := optionalString(jsOptions, "fitness")
+		minEnergy, _ := optionalFloat(jsOptions, "minEnergy")
+		if fitness := fmt.Sprint(fitnessName, minEnergy); fitness != instance.fitness {
+			instance.lastSearch.continuous.Reset()
+			instance.fitness = fitness
+		}
+
+		lastYield := time.Now()
+		cancelled := func() bool {
+			if time.Since(lastYield) >= yieldInterval {
+				time.Sleep(time.Millisecond)
+				lastYield = time.Now()
+			}
+			return instance.superseded()
+		}
+		result := runOptimizer(&instance.lastSearch, args, cancelled)
+		// the geometries a cut short search finished stay cached for the newer one
+		if instance.superseded() {
+			continue
+		}
+		if isError(result) {
+			instance.onResult.Invoke(js.Undefined(), result)
+			continue
+		}
+		instance.onResult.Invoke(result)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+type coolingResult struct {
+	turbine.CoolingComparison
+	// one of the turbines, null when none fits the room
+	Turbine *optimizerResult `json:"turbine"`
+}
+
+// compareCooling(maxWidth, maxHeight, coil, steamFlow, passiveEnergy, options) takes the runOptimizer arguments
+// with the steam of the reactor actively cooled, or a "steamSource" option, and what it makes passively
+func compareCoolingWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 5 && len(args) != 6 {
+			return jsError(errArgumentCount)
+		}
+		if args[4].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "passiveEnergy has to be a number", Field: "passiveEnergy"})
+		}
+
+		jsOptions := optionsArg(args, 5)
+		options, err := searchOptionsFromJS(args[:4], jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		walls, err := wallsFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("walls", err))
+		}
+		steamFlow := int64(jsInt(args[3]))
+		if options.Flow.Variant == turbine.FindBestUnderFlow {
+			steamFlow = options.Flow.Value
+		}
+
+		comparison, search := turbine.CompareCooling(options, args[4].Float(), steamFlow)
+		result := coolingResult{CoolingComparison: comparison}
+		if search.Found {
+			found := newOptimizerResult(search, walls)
+			if err := found.expandBuildCost(jsOptions); err != nil {
+				return jsError(fieldError("recipes", err))
+			}
+			result.Turbine = &found
+		}
+		converted, err := toJSResult(result, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return converted
+	})
+}
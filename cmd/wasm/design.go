@@ -0,0 +1,68 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// designFromJS builds a turbine from a plain object {width, height, coilLayers, coil, flowRate},
+// which is what runOptimizer returns plus the coil material name. "outerRingCoils", "engagedCoilLayers", "blade"
+// and "fluid" are optional.
+func designFromJS(design js.Value, config *turbine.Config) (turbine.Turbine, error) {
+	if design.Type() != js.TypeObject {
+		return turbine.Turbine{}, errors.New("Expected a design object")
+	}
+
+	width, okWidth := optionalInt(design, "width")
+	height, okHeight := optionalInt(design, "height")
+	coilLayers, okCoilLayers := optionalInt(design, "coilLayers")
+	coilMaterial, okCoil := optionalString(design, "coil")
+	flowRate, okFlowRate := optionalInt(design, "flowRate")
+	if !okWidth || !okHeight || !okCoilLayers || !okCoil || !okFlowRate {
+		return turbine.Turbine{}, errors.New("Design needs width, height, coilLayers, coil and flowRate")
+	}
+
+	coilType, err := config.Coil(coilMaterial)
+	if err != nil {
+		return turbine.Turbine{}, err
+	}
+	if err := config.ValidateDesignSize(int32(width), int32(height)); err != nil {
+		return turbine.Turbine{}, err
+	}
+
+	// the outer ring of the top coil layer is full unless the design says otherwise
+	outerRingCoils, ok := optionalInt(design, "outerRingCoils")
+	if !ok {
+		outerRingCoils = int(turbine.OuterRingSize(int32(width)))
+	}
+
+	designTurbine, err := turbine.NewTurbineWithOuterRing(config, int32(height), int32(width), int32(coilLayers), int64(outerRingCoils), coilType)
+	if err != nil {
+		return designTurbine, err
+	}
+	if engagedCoilLayers, ok := optionalInt(design, "engagedCoilLayers"); ok {
+		if err := designTurbine.EngageCoilLayers(int32(engagedCoilLayers)); err != nil {
+			return designTurbine, err
+		}
+	}
+	if bladeName, ok := optionalString(design, "blade"); ok {
+		blade, err := config.Blade(bladeName)
+		if err != nil {
+			return designTurbine, err
+		}
+		designTurbine.SetBlade(blade)
+	}
+	if fluidName, ok := optionalString(design, "fluid"); ok {
+		fluid, err := config.Fluid(fluidName)
+		if err != nil {
+			return designTurbine, err
+		}
+		designTurbine.SetFluid(fluid)
+	}
+	designTurbine.SetNominalFlowRate(int64(flowRate))
+	return designTurbine, nil
+}
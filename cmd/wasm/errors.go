@@ -0,0 +1,85 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// error codes the frontend can switch on, the message is only for showing
+const (
+	// wrong number or type of positional arguments
+	codeInvalidArguments = "invalid_arguments"
+	// an input has a value that can't be used, field names it
+	codeInvalidValue = "invalid_value"
+	// the inputs are fine on their own but no turbine satisfies all of them
+	codeNoTurbine = "no_turbine"
+	// a follow-up call came before any search
+	codeNoSession = "no_session"
+	// cancel() was called on the optimizer before it found anything
+	codeCancelled = "cancelled"
+	// the call would take more memory than the "memoryBudgetMB" option allows
+	codeMemoryBudget = "memory_budget"
+	// anything that isn't the caller's fault
+	codeInternal = "internal"
+)
+
+// apiError is what every function returns to js when it fails
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// the argument or option at fault, empty when it isn't down to one input
+	Field string `json:"field,omitempty"`
+	// a value for field that would work, when there is an obvious one
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (err apiError) Error() string {
+	return err.Message
+}
+
+var errArgumentCount = apiError{Code: codeInvalidArguments, Message: "Invalid no of arguments passed"}
+var errNoTurbine = apiError{Code: codeNoTurbine, Message: "No turbine fits the given constraints"}
+var errCancelled = apiError{Code: codeCancelled, Message: "The search was cancelled"}
+
+// infeasibleFields are the options behind each constraint a search can be stuck on
+var infeasibleFields = map[string]string{
+	"chunks":         "chunks",
+	"coilLayers":     "minCoilLayers",
+	"flow":           "flow",
+	"limitNoLoadRPM": "maxSafeRPM",
+	"targetPeak":     "targetPeak",
+	"fitness":        "minEnergy",
+	"budget":         "timeBudgetMs",
+}
+
+// noTurbineError blames a failed search on the option that ruled out its last candidates
+func noTurbineError(err error) apiError {
+	var infeasible turbine.InfeasibleError
+	if !errors.As(err, &infeasible) {
+		return errNoTurbine
+	}
+	return apiError{Code: codeNoTurbine, Message: infeasible.Message, Field: infeasibleFields[infeasible.Constraint]}
+}
+
+// fieldError blames err on one input, unless it already names one
+func fieldError(field string, err error) error {
+	var apiErr apiError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	var validationErr turbine.ValidationError
+	if errors.As(err, &validationErr) {
+		return apiError{codeInvalidValue, validationErr.Message, validationErr.Field, validationErr.Suggestion}
+	}
+	return apiError{Code: codeInvalidValue, Message: err.Error(), Field: field}
+}
+
+// jsError turns err into the {code, message, field} object handed back to js,
+// errors that weren't tagged with a code are taken to be bad input
+func jsError(err error) js.Value {
+	return toJS(fieldError("", err))
+}
@@ -0,0 +1,51 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// what searching costs in this browser, measured when the module starts
+var searchCost turbine.SearchCost
+
+// searches expected to take longer than this come with a warning
+const slowSearch = 10 * time.Second
+
+type searchEstimate struct {
+	turbine.SearchEstimate
+	EstimatedMs float64 `json:"estimatedMs"`
+	// empty unless the search is slow enough to warn about
+	Message string `json:"message,omitempty"`
+}
+
+// estimateSearch(maxWidth, maxHeight, coil, flow, options) takes the runOptimizer arguments and tells how many
+// evaluations the search makes and about how long they take, without searching
+func estimateSearchWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 4 && len(args) != 5 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 4)
+		if machineType, ok := optionalString(jsOptions, "machineType"); ok && machineType != "turbine" {
+			return jsError(apiError{Code: codeInvalidValue, Message: "Only turbine searches can be estimated", Field: "machineType"})
+		}
+		options, err := searchOptionsFromJS(args, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+
+		estimate := turbine.EstimateSearch(options)
+		duration := estimate.Duration(searchCost, options)
+		result := searchEstimate{SearchEstimate: estimate, EstimatedMs: float64(duration) / float64(time.Millisecond)}
+		if duration > slowSearch {
+			result.Message = fmt.Sprintf("This search tries %d designs and may take about %.0f seconds", estimate.Evaluations, duration.Seconds())
+		}
+		return toJS(result)
+	})
+}
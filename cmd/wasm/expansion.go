@@ -0,0 +1,47 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+)
+
+// planExpansion(design, targetEnergy, options) returns the upgrades that take a built turbine to targetEnergy RF/t,
+// best RF/t per block changed first. The options maxHeight and steamLimit bound how tall it may grow and the steam
+// it gets, with no limit when left out.
+func planExpansionWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+		jsOptions := optionsArg(args, 2)
+
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		maxHeight := config.MaxHeight
+		if height, ok := optionalInt(jsOptions, "maxHeight"); ok {
+			maxHeight = int32(height)
+		}
+		steamLimit := 0
+		if limit, ok := optionalInt(jsOptions, "steamLimit"); ok {
+			steamLimit = limit
+		}
+
+		plan, err := designTurbine.PlanExpansion(args[1].Float(), maxHeight, int64(steamLimit))
+		if err != nil {
+			return jsError(err)
+		}
+		result, err := toJSResult(plan, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
@@ -0,0 +1,107 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/format"
+	"github.com/drabart/turbine-calculator-website/pkg/share"
+)
+
+// exportResult(format, options) returns the last result as text to paste elsewhere, format is "csv",
+// "markdown", "reddit" or "bbcode". The "walls" option picks the build cost, "energyUnit" and "fluidUnit"
+// the units and "locale" how the tables write numbers, english if not given. "name" and "notes" are written
+// above the stats
+func exportResultWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.exportResult(args)
+	})
+}
+
+func (lastSearch *session) exportResult(args []js.Value) any {
+	if len(args) != 1 && len(args) != 2 {
+		return jsError(errArgumentCount)
+	}
+	if args[0].Type() != js.TypeString {
+		return jsError(apiError{Code: codeInvalidArguments, Message: "format has to be a string", Field: "format"})
+	}
+	exportFormat, err := share.ParseFormat(args[0].String())
+	if err != nil {
+		return jsError(fieldError("format", err))
+	}
+	if !lastSearch.found {
+		return jsError(errNoSession)
+	}
+
+	jsOptions := optionsArg(args, 1)
+	system, err := unitSystemFromOptions(jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	localeName, ok := optionalString(jsOptions, "locale")
+	if !ok {
+		localeName = "en"
+	}
+	locale, err := format.LocaleByName(localeName)
+	if err != nil {
+		return jsError(fieldError("locale", err))
+	}
+
+	cost := lastSearch.result.BuildCost
+	if _, ok := optionalString(jsOptions, "walls"); ok {
+		walls, err := wallsFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("walls", err))
+		}
+		cost = lastSearch.search.Turbine.BuildCostWith(walls)
+	}
+
+	sheet := share.NewSheet(lastSearch.result.Result, coilName(lastSearch.options.Config, lastSearch.options.Coil), cost, system)
+	metadata := share.Permalink{}
+	metadata.Name, _ = optionalString(jsOptions, "name")
+	metadata.Notes, _ = optionalString(jsOptions, "notes")
+	if err := metadata.Validate(); err != nil {
+		return jsError(fieldError("name", err))
+	}
+	sheet.Name, sheet.Notes = metadata.Name, metadata.Notes
+	text, err := sheet.Text(exportFormat, locale)
+	if err != nil {
+		return jsError(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	return text
+}
+
+// exportScript(computer, options) returns a Lua program running the last result's turbine from a computer, computer
+// is "computercraft" or "opencomputers". The "name" option replaces the size and coil in its first line.
+func exportScriptWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.exportScript(args)
+	})
+}
+
+func (lastSearch *session) exportScript(args []js.Value) any {
+	if len(args) != 1 && len(args) != 2 {
+		return jsError(errArgumentCount)
+	}
+	if args[0].Type() != js.TypeString {
+		return jsError(apiError{Code: codeInvalidArguments, Message: "computer has to be a string", Field: "computer"})
+	}
+	computer, err := share.ParseComputer(args[0].String())
+	if err != nil {
+		return jsError(fieldError("computer", err))
+	}
+	if !lastSearch.found {
+		return jsError(errNoSession)
+	}
+
+	script := share.NewControlScript(lastSearch.result.Result, coilName(lastSearch.options.Config, lastSearch.options.Coil))
+	if name, ok := optionalString(optionsArg(args, 1), "name"); ok && name != "" {
+		script.Title = name
+	}
+	text, err := script.Lua(computer)
+	if err != nil {
+		return jsError(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	return text
+}
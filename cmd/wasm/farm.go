@@ -0,0 +1,63 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// free blocks between the turbines of a farm unless the "spacing" option says otherwise, room for a pipe
+const defaultFarmSpacing = 1
+
+type farmResult struct {
+	turbine.FarmPlan
+	// the turbine the farm is built from
+	Turbine optimizerResult `json:"turbine"`
+}
+
+// planFarm(maxWidth, maxHeight, coil, flow, targetEnergy, options) takes the runOptimizer arguments for the turbine
+// to build and the RF/t the whole base needs, and returns how many to build, their steam, blocks and layouts
+func planFarmWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 5 && len(args) != 6 {
+			return jsError(errArgumentCount)
+		}
+		if args[4].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "targetEnergy has to be a number", Field: "targetEnergy"})
+		}
+
+		jsOptions := optionsArg(args, 5)
+		options, err := searchOptionsFromJS(args[:4], jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		walls, err := wallsFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("walls", err))
+		}
+		spacing, ok := optionalInt(jsOptions, "spacing")
+		if !ok {
+			spacing = defaultFarmSpacing
+		}
+
+		plan, search, err := turbine.PlanFarm(options, walls, args[4].Float(), int32(spacing))
+		if errors.As(err, new(turbine.InfeasibleError)) {
+			return jsError(noTurbineError(err))
+		}
+		if err != nil {
+			return jsError(err)
+		}
+		result := farmResult{FarmPlan: plan, Turbine: newOptimizerResult(search, walls)}
+		if err := result.Turbine.expandBuildCost(jsOptions); err != nil {
+			return jsError(fieldError("recipes", err))
+		}
+		converted, err := toJSResult(result, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return converted
+	})
+}
@@ -1,3 +0,0 @@
-module turbine-calculator
-
-go 1.23.2
@@ -0,0 +1,95 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// streamHeatMapFromJS runs the heat map picked by the "axes" option: "heightWidth" (the default)
+// or "widthCoils" at the "height" option, maxHeight if not given
+func streamHeatMapFromJS(options turbine.Options, jsOptions js.Value, start func(turbine.HeatMap), emit func(turbine.HeatMapRow)) (turbine.HeatMap, error) {
+	axes, _ := optionalString(jsOptions, "axes")
+	switch axes {
+	case "", "heightWidth":
+		return turbine.StreamHeatMapHeightWidth(options, start, emit), nil
+	case "widthCoils":
+		height := options.MaxSize.Y
+		if value, ok := optionalInt(jsOptions, "height"); ok {
+			height = int32(value)
+		}
+		return turbine.StreamHeatMapWidthCoils(options, height, start, emit), nil
+	default:
+		return turbine.HeatMap{}, apiError{Code: codeInvalidValue, Message: fmt.Sprintf("Unknown heat map axes %q", axes), Field: "axes"}
+	}
+}
+
+// heatMap(maxWidth, maxHeight, coil, flow, options) takes the runOptimizer arguments, plus "axes" and "height" in the options.
+// With the "binary" option the values come as a float64 buffer of rows by columns instead of nested arrays.
+// A map too big for the "memoryBudgetMB" option only maps every stride-th size, streamHeatMap can map them all.
+func heatMapWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 4 && len(args) != 5 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 4)
+		options, err := searchOptionsFromJS(args, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		left, err := memoryLeft(jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		options.MaxHeatMapCells = max(1, int(left/bytesPerHeatMapCell))
+
+		values := [][]float64{}
+		heatMap, err := streamHeatMapFromJS(options, jsOptions, func(turbine.HeatMap) {}, func(row turbine.HeatMapRow) {
+			values = append(values, row.Values)
+		})
+		if err != nil {
+			return jsError(err)
+		}
+		if binary, _ := optionalBool(jsOptions, "binary"); binary {
+			return heatMapTable(heatMap, values)
+		}
+		heatMap.Values = values
+		return toJS(heatMap)
+	})
+}
+
+// streamHeatMap(maxWidth, maxHeight, coil, flow, options, onRow) calls onRow(row, axes) for every row as soon as
+// it is computed instead of building the whole heat map, and returns the axes
+func streamHeatMapWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 6 || args[5].Type() != js.TypeFunction {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 4)
+		options, err := searchOptionsFromJS(args, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		// rows aren't kept, but there has to be room for one
+		if _, err := memoryLeft(jsOptions); err != nil {
+			return jsError(err)
+		}
+
+		onRow := args[5]
+		axes := js.Undefined()
+		heatMap, err := streamHeatMapFromJS(options, jsOptions, func(heatMap turbine.HeatMap) {
+			axes = toJS(heatMap)
+		}, func(row turbine.HeatMapRow) {
+			onRow.Invoke(toJS(row), axes)
+		})
+		if err != nil {
+			return jsError(err)
+		}
+		return toJS(heatMap)
+	})
+}
@@ -0,0 +1,263 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+)
+
+// localStorage key the history is kept under
+const historyKey = "turbineCalculator.history"
+
+// how many searches the history keeps, the oldest goes first
+const maxHistory = 20
+
+// historyEntry is one search of the recent calculations panel
+type historyEntry struct {
+	ID int `json:"id"`
+	// milliseconds since the epoch, like Date.now()
+	Time float64 `json:"time"`
+	// the runOptimizer arguments, options included, as plainFromJS gives them
+	Query   any            `json:"query"`
+	Summary historySummary `json:"summary"`
+}
+
+// historySummary is what the panel shows of a result without searching again
+type historySummary struct {
+	Coil            string  `json:"coil"`
+	Width           int32   `json:"width"`
+	Height          int32   `json:"height"`
+	CoilLayers      int32   `json:"coilLayers"`
+	FlowRate        int64   `json:"flowRate"`
+	RPM             float64 `json:"rpm"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+}
+
+// searchHistory is loaded from localStorage on first use and written back after every change. Without
+// localStorage, in node or a locked down browser, it only lasts as long as the page.
+type searchHistory struct {
+	mutex   sync.Mutex
+	loaded  bool
+	entries []historyEntry
+	nextID  int
+}
+
+var history = &searchHistory{}
+
+// storageCall runs a localStorage method, the browser throws when storage is disabled or full
+func storageCall(method string, args ...any) (value js.Value, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("localStorage.%s failed: %v", method, recovered)
+		}
+	}()
+	storage := js.Global().Get("localStorage")
+	if storage.Type() != js.TypeObject {
+		return js.Undefined(), fmt.Errorf("There is no localStorage")
+	}
+	return storage.Call(method, args...), nil
+}
+
+// load reads the stored history once, a broken one is dropped rather than kept failing
+func (history *searchHistory) load() {
+	if history.loaded {
+		return
+	}
+	history.loaded = true
+	history.nextID = 1
+
+	stored, err := storageCall("getItem", historyKey)
+	if err != nil || stored.Type() != js.TypeString {
+		return
+	}
+	entries, err := decodeHistory(stored.String())
+	if err != nil {
+		logging.Warnf("Dropping the stored history: %v", err)
+		return
+	}
+	history.entries = entries
+	for _, entry := range entries {
+		history.nextID = max(history.nextID, entry.ID+1)
+	}
+}
+
+// decodeHistory reads the entries save wrote, JSON.parse throws on text that isn't json
+func decodeHistory(text string) (entries []historyEntry, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("Stored history is not valid json: %v", recovered)
+		}
+	}()
+	stored := js.Global().Get("JSON").Call("parse", text)
+	if !js.Global().Get("Array").Call("isArray", stored).Bool() {
+		return nil, errors.New("Stored history is not a list")
+	}
+	for i := range stored.Length() {
+		jsEntry := stored.Index(i)
+		id, ok := optionalInt(jsEntry, "id")
+		if !ok {
+			return nil, errors.New("Stored history entry has no id")
+		}
+		entry := historyEntry{ID: id, Query: plainFromJS(jsEntry.Get("query"))}
+		entry.Time, _ = optionalFloat(jsEntry, "time")
+
+		jsSummary := jsEntry.Get("summary")
+		entry.Summary.Coil, _ = optionalString(jsSummary, "coil")
+		width, _ := optionalInt(jsSummary, "width")
+		height, _ := optionalInt(jsSummary, "height")
+		coilLayers, _ := optionalInt(jsSummary, "coilLayers")
+		flowRate, _ := optionalInt(jsSummary, "flowRate")
+		entry.Summary.Width, entry.Summary.Height, entry.Summary.CoilLayers = int32(width), int32(height), int32(coilLayers)
+		entry.Summary.FlowRate = int64(flowRate)
+		entry.Summary.RPM, _ = optionalFloat(jsSummary, "rpm")
+		entry.Summary.EnergyGenerated, _ = optionalFloat(jsSummary, "energyGenerated")
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (history *searchHistory) save() {
+	if _, err := storageCall("setItem", historyKey, stringify(toJS(history.entries))); err != nil {
+		logging.Debugf("History isn't persisted: %v", err)
+	}
+}
+
+// record adds a search that found a turbine, args are the runOptimizer arguments it ran with
+func (history *searchHistory) record(args []js.Value, lastSearch *session) {
+	query := js.Global().Get("Array").New()
+	for _, arg := range args {
+		query.Call("push", arg)
+	}
+	// functions and other values json can't hold are left out
+	plainQuery := plainFromJS(js.Global().Get("JSON").Call("parse", stringify(query)))
+
+	stats := lastSearch.result.Stats
+	summary := historySummary{
+		Coil:            coilName(lastSearch.options.Config, lastSearch.options.Coil),
+		Width:           stats.Width,
+		Height:          stats.Height,
+		CoilLayers:      stats.CoilLayers,
+		FlowRate:        stats.FlowRate,
+		RPM:             stats.RPM,
+		EnergyGenerated: stats.EnergyGenerated,
+	}
+
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	history.load()
+	history.entries = append(history.entries, historyEntry{
+		ID:      history.nextID,
+		Time:    js.Global().Get("Date").Call("now").Float(),
+		Query:   plainQuery,
+		Summary: summary,
+	})
+	history.nextID++
+	if len(history.entries) > maxHistory {
+		history.entries = slices.Delete(history.entries, 0, len(history.entries)-maxHistory)
+	}
+	history.save()
+}
+
+// query finds the runOptimizer arguments of an entry, nil when no entry has the id
+func (history *searchHistory) query(id int) any {
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	history.load()
+	for _, entry := range history.entries {
+		if entry.ID == id {
+			return entry.Query
+		}
+	}
+	return nil
+}
+
+// listHistory(options) returns {history}, the recorded searches newest first. runOptimizer records every search
+// that finds a turbine unless its "history" option is false.
+func listHistoryWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 1 {
+			return jsError(errArgumentCount)
+		}
+
+		history.mutex.Lock()
+		history.load()
+		entries := append([]historyEntry{}, history.entries...)
+		history.mutex.Unlock()
+		slices.Reverse(entries)
+
+		result, err := toJSResult(map[string]any{"history": entries}, optionsArg(args, 0))
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
+
+// reloadHistory(id, options) runs the search of an entry again and returns its result, which becomes the last
+// result. options override the ones the search was recorded with, for the units or locale.
+func reloadHistoryWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+		if args[0].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "id has to be a number", Field: "id"})
+		}
+		query := history.query(jsInt(args[0]))
+		if query == nil {
+			return jsError(apiError{Code: codeInvalidValue, Message: "No history entry has this id", Field: "id"})
+		}
+
+		jsQuery := toJS(query)
+		queryArgs := make([]js.Value, jsQuery.Length())
+		for i := range queryArgs {
+			queryArgs[i] = jsQuery.Index(i)
+		}
+		if len(queryArgs) < 4 {
+			return jsError(apiError{Code: codeInternal, Message: "The history entry has no query"})
+		}
+		object := js.Global().Get("Object")
+		options := object.Call("assign", object.New(), optionsArg(queryArgs, 4), optionsArg(args, 1))
+		// running it again isn't a new search to remember
+		options.Set("history", false)
+		queryArgs = append(queryArgs[:4], options)
+		return runOptimizer(defaultSession, queryArgs, nil)
+	})
+}
+
+// deleteHistory(id) removes an entry, deleteHistory() clears the whole history
+func deleteHistoryWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 1 {
+			return jsError(errArgumentCount)
+		}
+
+		history.mutex.Lock()
+		defer history.mutex.Unlock()
+		history.load()
+		if len(args) == 0 {
+			history.entries = nil
+			if _, err := storageCall("removeItem", historyKey); err != nil {
+				logging.Debugf("History isn't persisted: %v", err)
+			}
+			return nil
+		}
+		if args[0].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "id has to be a number", Field: "id"})
+		}
+		id := jsInt(args[0])
+		index := slices.IndexFunc(history.entries, func(entry historyEntry) bool { return entry.ID == id })
+		if index < 0 {
+			return jsError(apiError{Code: codeInvalidValue, Message: "No history entry has this id", Field: "id"})
+		}
+		history.entries = slices.Delete(history.entries, index, index+1)
+		history.save()
+		return nil
+	})
+}
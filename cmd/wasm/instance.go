@@ -0,0 +1,152 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"sync/atomic"
+	"syscall/js"
+	"time"
+)
+
+// how long a search runs before it sleeps so the page can handle events, including a cancel()
+const yieldInterval = 50 * time.Millisecond
+
+// optimizer is one newOptimizer instance, it shares nothing with the global functions or other instances
+type optimizer struct {
+	options    js.Value
+	lastSearch session
+	cancelled  atomic.Bool
+	funcs      []js.Func
+}
+
+// newOptimizer(options) returns an object with its own last result:
+//
+//	run(maxWidth, maxHeight, coil, flow, options) resolves to the result, or rejects with an error object
+//	cancel() stops a running search, it resolves with the best turbine so far or rejects as "cancelled"
+//	sweep(design, from, to, step, options, onChunk) is streamFlowSweep
+//	lastResult(options) and withCoil(coil, options) work on this instance's last run
+//	pinResult(options), unpinResult(id), listPinned(options) and compare(ids, options) keep this instance's pins
+//	exportResult(format, options) writes this instance's last result as text
+//	exportScript(computer, options) writes a Lua control program for this instance's last result
+//	recompute(base, overrides, options) is a what-if on a design or, with a null base, this instance's last result
+//	release() frees the methods once the instance isn't needed any more
+//
+// options given to newOptimizer are the defaults for every call, the options of a call override them
+func newOptimizerWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 1 {
+			return jsError(errArgumentCount)
+		}
+		instance := &optimizer{options: optionsArg(args, 0)}
+		return instance.toJS()
+	})
+}
+
+func (instance *optimizer) toJS() js.Value {
+	object := js.Global().Get("Object").New()
+	methods := map[string]func(args []js.Value) any{
+		"run":    instance.run,
+		"cancel": instance.cancel,
+		"sweep": func(args []js.Value) any {
+			return streamFlowSweep(instance.withOptions(args, 4))
+		},
+		"lastResult": func(args []js.Value) any {
+			return instance.lastSearch.lastResult(instance.withOptions(args, 0))
+		},
+		"withCoil": func(args []js.Value) any {
+			return instance.lastSearch.withCoil(instance.withOptions(args, 1))
+		},
+		"pinResult": func(args []js.Value) any {
+			return instance.lastSearch.pinResult(instance.withOptions(args, 0))
+		},
+		"unpinResult": instance.lastSearch.unpinResult,
+		"listPinned": func(args []js.Value) any {
+			return instance.lastSearch.listPinned(instance.withOptions(args, 0))
+		},
+		"compare": func(args []js.Value) any {
+			return instance.lastSearch.compare(instance.withOptions(args, 1))
+		},
+		"exportResult": func(args []js.Value) any {
+			return instance.lastSearch.exportResult(instance.withOptions(args, 1))
+		},
+		"exportScript": func(args []js.Value) any {
+			return instance.lastSearch.exportScript(instance.withOptions(args, 1))
+		},
+		"recompute": func(args []js.Value) any {
+			return instance.lastSearch.recompute(instance.withOptions(args, 2))
+		},
+		"release": func(args []js.Value) any {
+			for _, function := range instance.funcs {
+				function.Release()
+			}
+			instance.funcs = nil
+			return nil
+		},
+	}
+	for name, method := range methods {
+		function := js.FuncOf(func(this js.Value, args []js.Value) any {
+			return method(args)
+		})
+		instance.funcs = append(instance.funcs, function)
+		object.Set(name, function)
+	}
+	return object
+}
+
+// withOptions merges the instance options under the options argument at index, padding args to reach it
+func (instance *optimizer) withOptions(args []js.Value, index int) []js.Value {
+	if instance.options.Type() != js.TypeObject {
+		return args
+	}
+	merged := make([]js.Value, max(len(args), index+1))
+	copy(merged, args)
+	for i := len(args); i < len(merged); i++ {
+		merged[i] = js.Undefined()
+	}
+
+	callOptions := merged[index]
+	if callOptions.Type() != js.TypeObject {
+		callOptions = js.Global().Get("Object").New()
+	}
+	merged[index] = js.Global().Get("Object").Call("assign", js.Global().Get("Object").New(), instance.options, callOptions)
+	return merged
+}
+
+func (instance *optimizer) run(args []js.Value) any {
+	if len(args) != 4 && len(args) != 5 {
+		return jsError(errArgumentCount)
+	}
+	args = instance.withOptions(args, 4)
+
+	var executor js.Func
+	executor = js.FuncOf(func(this js.Value, promiseArgs []js.Value) any {
+		resolve, reject := promiseArgs[0], promiseArgs[1]
+		go func() {
+			defer executor.Release()
+			instance.cancelled.Store(false)
+			lastYield := time.Now()
+			cancelled := func() bool {
+				// sleeping hands control back to the event loop, otherwise cancel() could never run
+				if time.Since(lastYield) >= yieldInterval {
+					time.Sleep(time.Millisecond)
+					lastYield = time.Now()
+				}
+				return instance.cancelled.Load()
+			}
+
+			result := runOptimizer(&instance.lastSearch, args, cancelled)
+			if isError(result) {
+				reject.Invoke(result)
+				return
+			}
+			resolve.Invoke(result)
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(executor)
+}
+
+func (instance *optimizer) cancel(args []js.Value) any {
+	instance.cancelled.Store(true)
+	return nil
+}
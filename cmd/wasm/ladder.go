@@ -0,0 +1,56 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// ladderStep is one height of the growth path chart, stats are null when no turbine of that height fits
+type ladderStep struct {
+	Height         int32          `json:"height"`
+	Stats          *turbine.Stats `json:"stats"`
+	EnergyPerSteam float64        `json:"energyPerSteam"`
+	TotalBlocks    int64          `json:"totalBlocks"`
+	// RF/t over the step below that found a turbine, zero for the first
+	EnergyGain float64 `json:"energyGain"`
+}
+
+// sizeLadder(maxWidth, maxHeight, coil, flow, options) takes the runOptimizer arguments and returns {steps},
+// the best turbine at every height from the lowest up to maxHeight
+func sizeLadderWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 4 && len(args) != 5 {
+			return jsError(errArgumentCount)
+		}
+		jsOptions := optionsArg(args, 4)
+		options, err := searchOptionsFromJS(args, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+
+		steps := []ladderStep{}
+		previous := 0.0
+		for _, step := range turbine.SizeLadder(options) {
+			ladder := ladderStep{Height: step.Height}
+			if step.Found {
+				stats := step.Turbine.Stats()
+				ladder.Stats = &stats
+				ladder.EnergyPerSteam = step.Turbine.EnergyPerSteam()
+				ladder.TotalBlocks = step.Turbine.BlockCount()
+				if previous > 0 {
+					ladder.EnergyGain = stats.EnergyGenerated - previous
+				}
+				previous = stats.EnergyGenerated
+			}
+			steps = append(steps, ladder)
+		}
+		result, err := toJSResult(map[string]any{"steps": steps}, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
@@ -0,0 +1,40 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+)
+
+// consoleSink writes to the browser console, warnings and errors with their own methods so devtools can filter them
+func consoleSink(level logging.Level, message string) {
+	method := "log"
+	switch level {
+	case logging.Debug:
+		method = "debug"
+	case logging.Warn:
+		method = "warn"
+	case logging.Error:
+		method = "error"
+	}
+	js.Global().Get("console").Call(method, message)
+}
+
+// setLogLevel(level) drops console output below "debug", "info", "warn" or "error", "off" silences it.
+// Returns the level it replaced.
+func setLogLevelWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 || args[0].Type() != js.TypeString {
+			return jsError(errArgumentCount)
+		}
+		level, err := logging.ParseLevel(args[0].String())
+		if err != nil {
+			return jsError(fieldError("level", err))
+		}
+		previous := logging.CurrentLevel()
+		logging.SetLevel(level)
+		return previous.String()
+	})
+}
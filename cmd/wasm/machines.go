@@ -0,0 +1,58 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/machine"
+	_ "github.com/drabart/turbine-calculator-website/pkg/machines"
+)
+
+func listMachinesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return toJS(machine.List())
+	})
+}
+
+// runMachine(name, request) where the request fields depend on the machine
+func runMachineWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 || args[0].Type() != js.TypeString {
+			return jsError(errArgumentCount)
+		}
+
+		request := js.Global().Get("JSON").Call("stringify", args[1]).String()
+		report, err := machine.Run(args[0].String(), []byte(request))
+		if err != nil {
+			return jsError(fieldError("request", err))
+		}
+		return toJS(report)
+	})
+}
+
+// runMachineOptimizer handles runOptimizer for everything but the reactor turbine,
+// the arguments keep their meaning: max width, max height, (unused coil) and steam flow
+func runMachineOptimizer(machineType string, args []js.Value, options js.Value) any {
+	steamFlow := int64(jsInt(args[3]))
+
+	sourceFlow, ok, err := steamFlowFromOptions(options)
+	if err != nil {
+		return jsError(fieldError("steamSource", err))
+	}
+	if ok {
+		steamFlow = sourceFlow
+	}
+
+	request := stringify(js.ValueOf(map[string]any{
+		"maxWidth":  jsInt(args[0]),
+		"maxHeight": jsInt(args[1]),
+		"steamFlow": steamFlow,
+	}))
+
+	report, err := machine.Run(machineType, []byte(request))
+	if err != nil {
+		return jsError(fieldError("machineType", err))
+	}
+	return toJS(report)
+}
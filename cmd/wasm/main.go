@@ -3,164 +3,354 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"math"
 	"syscall/js"
-	// "os"
-	// "runtime/pprof"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/logging"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
 )
 
-var coilTypes = map[string]CoilData{
-	// {efficiency, bonus, extractionRate}
-	"Iron":         {0.33, 1, 0.1},
-	"Copper":       {0.396, 1, 0.12},
-	"Osmium":       {0.462, 1, 0.12},
-	"Steel":        {0.495, 1, 0.13},
-	"Invar":        {0.495, 1, 0.14},
-	"Silver":       {0.561, 1, 0.15},
-	"Gold":         {0.66, 1, 0.175},
-	"Electrum":     {0.825, 1, 0.2},
-	"Platinum":     {0.99, 1, 0.25},
-	"Enderium":     {0.99, 1.02, 0.3},
-	"Ludicrite":    {1.15, 1.02, 0.35},
-	"AllTheModium": {1.2, 1.02, 0.4},
-	"Vibranium":    {1.35, 1.04, 0.5},
-	"Unobtanium":   {1.5, 1.06, 0.7},
+type optimizerResult struct {
+	turbine.Result
+	// the search ran out of budget and this is only the best turbine found so far
+	Truncated bool       `json:"truncated"`
+	Notes     []string   `json:"notes"`
+	BuildCost build.Cost `json:"buildCost"`
+	turbine.BlockSummary
+	// chunks the footprint crosses from the "chunkOffsetX" and "chunkOffsetZ" options
+	ChunkSpan turbine.ChunkSpan `json:"chunkSpan"`
+	// the build cost crafted down to raw materials, only when recipes are given
+	RawMaterials build.Cost `json:"rawMaterials,omitempty"`
+	// what the search covered, to see how thorough it was
+	Search searchTelemetry `json:"search"`
 }
 
-const minHeight int = 4
-const minWidth int = 5
-
-type FlowSettingVariant int64
-
-const (
-	UseMaxFlow FlowSettingVariant = iota
-	FindBestFlow
-	UseSetFlow
-	FindBestUnderFlow
-)
+// searchTelemetry adds the evaluation counts to turbine.SearchTelemetry
+type searchTelemetry struct {
+	Evaluations int64 `json:"evaluations"`
+	Reused      int64 `json:"reused"`
+	turbine.SearchTelemetry
+}
 
-type FlowSetting struct {
-	variant FlowSettingVariant
-	value   int64
+func newOptimizerResult(searchResult turbine.SearchResult, walls turbine.WallMaterial) optimizerResult {
+	return optimizerResult{
+		Result:       searchResult.Turbine.Result(),
+		Truncated:    searchResult.Truncated,
+		Notes:        searchResult.Notes,
+		BuildCost:    searchResult.Turbine.BuildCostWith(walls),
+		BlockSummary: searchResult.Turbine.BlockSummary(walls),
+		ChunkSpan:    searchResult.ChunkSpan,
+		Search:       searchTelemetry{searchResult.Evaluations, searchResult.Reused, searchResult.Telemetry},
+	}
 }
 
-func findOptimalTurbine(fitnessFunction func(Turbine) float64, constraintsFunction func(Turbine) bool, coilType CoilData, flowSetting FlowSetting, maxSize Size) Turbine {
-	var bestTurbine Turbine
-	bestFitness := math.Inf(-1)
-
-	for height := minHeight; height <= int(maxSize.y); height++ {
-		for width := minWidth; width <= int(maxSize.x); width += 2 {
-			for coilLayers := 1; coilLayers <= height-3; coilLayers++ {
-				turbine, err := NewTurbine(int32(height), int32(width), int32(coilLayers), coilType)
-				if err != nil {
-					fmt.Println(err.Error())
-					fmt.Printf("Couldn't form a valid turbine %d %d %d\n", height, width, coilLayers)
-					continue
-				}
+// expandBuildCost fills in the raw materials if the options carry a "recipes" object
+func (result *optimizerResult) expandBuildCost(options js.Value) error {
+	if options.Type() != js.TypeObject || options.Get("recipes").Type() != js.TypeObject {
+		return nil
+	}
 
-				if !constraintsFunction(turbine) {
-					continue
-				}
+	recipes, err := recipesFromJS(options.Get("recipes"))
+	if err != nil {
+		return err
+	}
 
-				flowRates := []int64{}
-				switch flowSetting.variant {
-				case UseMaxFlow:
-					flowRates = append(flowRates, turbine.maxMaxFlowRate)
-				case FindBestFlow:
-					for flowRate := flowSetting.value; flowRate <= turbine.maxMaxFlowRate; flowRate += flowSetting.value {
-						flowRates = append(flowRates, int64(flowRate))
-					}
-				case UseSetFlow:
-					flowRates = append(flowRates, flowSetting.value)
-				case FindBestUnderFlow:
-					for flowRate := max(0, flowSetting.value-10000); flowRate <= min(turbine.maxMaxFlowRate, flowSetting.value); flowRate += 100 {
-						flowRates = append(flowRates, int64(flowRate))
-					}
-				default:
-					panic("Invalid FlowSettingVariant")
-				}
+	rawMaterials, err := result.BuildCost.Expand(recipes)
+	if err != nil {
+		return err
+	}
+	result.RawMaterials = rawMaterials
+	return nil
+}
 
-				for _, flowRate := range flowRates {
-					// set the rate to test
-					turbine.SetNominalFlowRate(flowRate)
-
-					// calculate the rpm from the closed form
-					calculatedRPM := turbine.FinalRPM()
-					// set the final energy for the final rpm
-					turbine.SetEnergyForRPM(calculatedRPM)
-					// tick the turbine to get all the bonus data
-					turbine.Tick()
-
-					// evaluate the turbine with the provided fitness function
-					turbineFitness := fitnessFunction(turbine)
-
-					if turbineFitness > bestFitness {
-						// turbine.PrintStats()
-						bestTurbine = turbine
-						bestFitness = turbineFitness
-					}
+// recipesFromJS reads {item: {output, ingredients: [{name, count}]}}
+func recipesFromJS(value js.Value) (build.Recipes, error) {
+	recipes := build.Recipes{}
+	names := js.Global().Get("Object").Call("keys", value)
+	for i := range names.Length() {
+		name := names.Index(i).String()
+		jsRecipe := value.Get(name)
+		if jsRecipe.Type() != js.TypeObject {
+			return nil, fmt.Errorf("Recipe for %s has to be an object", name)
+		}
+		output, _ := optionalInt(jsRecipe, "output")
+		recipe := build.Recipe{Output: int64(output)}
+		if ingredients := jsRecipe.Get("ingredients"); ingredients.Type() == js.TypeObject {
+			for j := range ingredients.Length() {
+				ingredientName, okName := optionalString(ingredients.Index(j), "name")
+				count, okCount := optionalInt(ingredients.Index(j), "count")
+				if !okName || !okCount {
+					return nil, errors.New("Recipe ingredients need a name and a count")
 				}
+				recipe.Ingredients = append(recipe.Ingredients, build.Item{Name: ingredientName, Count: int64(count)})
 			}
 		}
+		recipes[name] = recipe
 	}
+	return recipes, nil
+}
 
-	return bestTurbine
+// wallsFromOptions reads the "walls" option, glass walls if not given
+func wallsFromOptions(options js.Value) (turbine.WallMaterial, error) {
+	name, ok := optionalString(options, "walls")
+	if !ok {
+		return turbine.GlassWalls, nil
+	}
+	return turbine.ParseWallMaterial(name)
 }
 
-func optimizerWrapper() js.Func {
-	jsonFunc := js.FuncOf(func(this js.Value, args []js.Value) any {
-		// fmt.Println(len(args))
-		if len(args) != 4 {
-			return "Invalid no of arguments passed"
+// searchOptionsFromJS reads the runOptimizer arguments (maxWidth, maxHeight, coil, flow) and the options object
+func searchOptionsFromJS(args []js.Value, jsOptions js.Value) (turbine.Options, error) {
+	config, err := configFromOptions(jsOptions)
+	if err != nil {
+		return turbine.Options{}, fieldError("profile", err)
+	}
+
+	for i, name := range []string{"maxWidth", "maxHeight"} {
+		if args[i].Type() != js.TypeNumber {
+			return turbine.Options{}, apiError{Code: codeInvalidArguments, Message: fmt.Sprintf("%s has to be a number", name), Field: name}
 		}
+	}
+	maxWidth := jsInt(args[0])
+	maxHeight := jsInt(args[1])
+	if err := config.ValidateMaxSize(int32(maxWidth), int32(maxHeight)); err != nil {
+		return turbine.Options{}, err
+	}
 
-		maxWidth := args[0].Int()
-		maxHeight := args[1].Int()
+	if args[2].Type() != js.TypeString {
+		return turbine.Options{}, apiError{Code: codeInvalidArguments, Message: "coil has to be a coil material name", Field: "coil"}
+	}
+	coilType, err := config.Coil(args[2].String())
+	if err != nil {
+		return turbine.Options{}, err
+	}
 
-		coilMaterial := args[2].String()
-		coilType := coilTypes[coilMaterial]
+	if args[3].Type() != js.TypeNumber {
+		return turbine.Options{}, apiError{Code: codeInvalidArguments, Message: "flow has to be a number", Field: "flow"}
+	}
+	flowValue := jsInt(args[3])
 
-		flowValue := args[3].Int()
+	fitnessMetric := turbine.MaximizeEnergy
+	if name, ok := optionalString(jsOptions, "fitness"); ok {
+		fitnessMetric, err = turbine.ParseFitnessMetric(name)
+		if err != nil {
+			return turbine.Options{}, fieldError("fitness", err)
+		}
+	}
+	// RF/t floor so a steam efficiency search doesn't settle on a tiny turbine
+	minEnergy, _ := optionalFloat(jsOptions, "minEnergy")
+	fitnessFunction := turbine.MetricFitness(fitnessMetric, minEnergy)
+	constraintsFunction := func(turbine turbine.Turbine) bool {
+		return true
+	}
+	maxSize := turbine.Size{X: int32(maxWidth), Y: int32(maxHeight), Z: int32(maxWidth)}
 
-		fitnessFunction := func(turbine Turbine) float64 {
-			return turbine.energyGeneratedLastTick
+	flowSetting := turbine.FlowSetting{Variant: turbine.UseMaxFlow, Value: int64(flowValue)}
+	steamFlow, ok, err := steamFlowFromOptions(jsOptions)
+	if err != nil {
+		return turbine.Options{}, fieldError("steamSource", err)
+	}
+	if ok {
+		// the source can provide up to steamFlow, the turbine may run best a bit under it
+		flowSetting = turbine.FlowSetting{Variant: turbine.FindBestUnderFlow, Value: steamFlow}
+	}
+	if targetRPM, ok := optionalInt(jsOptions, "targetRPM"); ok {
+		flowSetting = turbine.FlowSetting{Variant: turbine.UseTargetRPM, Value: int64(targetRPM)}
+	}
+	// how finely a steam source search closes in on the best flow rate, in mB/t
+	if tolerance, ok := optionalInt(jsOptions, "flowTolerance"); ok {
+		if tolerance < 1 {
+			return turbine.Options{}, apiError{Code: codeInvalidValue, Message: "flowTolerance has to be at least 1", Field: "flowTolerance"}
 		}
-		constraintsFunction := func(turbine Turbine) bool {
-			return true
+		flowSetting.Tolerance = int64(tolerance)
+	}
+	options := turbine.NewOptions(fitnessFunction, constraintsFunction, coilType, flowSetting, maxSize)
+	options.Config = config
+	if err := applyRoomOptions(&options, jsOptions); err != nil {
+		return turbine.Options{}, err
+	}
+	// never recommend a rotor that breaks when the coils trip
+	options.LimitNoLoadRPM = config.MaxSafeRPM > 0
+	// 1 keeps the rotor near the highest coil efficiency peak, 2 near the one below for turbines that can't get there
+	if targetPeak, ok := optionalInt(jsOptions, "targetPeak"); ok {
+		if peaks := len(config.EfficiencyPeakRPMs()); targetPeak < 0 || targetPeak > peaks {
+			return turbine.Options{}, apiError{Code: codeInvalidValue, Message: fmt.Sprintf("targetPeak has to be between 0 and %d", peaks), Field: "targetPeak"}
 		}
-		maxSize := Size{int32(maxWidth), int32(maxHeight), int32(maxWidth)}
-
-		turbine := findOptimalTurbine(fitnessFunction, constraintsFunction, coilType, FlowSetting{UseMaxFlow, int64(flowValue)}, maxSize)
-
-		// turbine.PrintStats()
-		// turbine.PrintBuildCost()
-
-		turbineGoToJS := js.FuncOf(func(this js.Value, args []js.Value) any {
-			this.Set("width", turbine.size.x+2)
-			this.Set("height", turbine.size.y+2)
-			this.Set("rpm", turbine.RPM())
-			this.Set("coilSize", turbine.coilSize)
-			this.Set("flowRate", turbine.maxFlowRate)
-			this.Set("maxFlowRate", turbine.maxMaxFlowRate)
-			this.Set("rotorShafts", turbine.rotorShafts)
-			this.Set("energyGenerated", turbine.energyGeneratedLastTick)
-			this.Set("rotorEfficiency", turbine.rotorEfficiencyLastTick)
-			this.Set("inductorDrag", turbine.inductorDragLastTick)
-			this.Set("frictionDrag", turbine.frictionDragLastTick)
-			this.Set("aeroDrag", turbine.aeroDragLastTick)
-			this.Set("coilEfficiency", turbine.coilEfficiencyLastTick)
-			return this
-		})
-
-		return turbineGoToJS.New()
+		options.TargetPeak = int32(targetPeak)
+	}
+	if minCoilLayers, ok := optionalInt(jsOptions, "minCoilLayers"); ok {
+		options.MinCoilLayers = int32(minCoilLayers)
+	}
+	if maxCoilLayers, ok := optionalInt(jsOptions, "maxCoilLayers"); ok {
+		options.MaxCoilLayers = int32(maxCoilLayers)
+	}
+	if bladeName, ok := optionalString(jsOptions, "blade"); ok {
+		options.Blade, err = config.Blade(bladeName)
+		if err != nil {
+			return turbine.Options{}, err
+		}
+	}
+	if fluidName, ok := optionalString(jsOptions, "fluid"); ok {
+		options.Fluid, err = config.Fluid(fluidName)
+		if err != nil {
+			return turbine.Options{}, err
+		}
+	}
+	if partialRings, ok := optionalBool(jsOptions, "partialRings"); ok {
+		options.PartialRings = partialRings
+	}
+	if partialEngagement, ok := optionalBool(jsOptions, "partialEngagement"); ok {
+		options.PartialEngagement = partialEngagement
+	}
+	if options.MaxCoilLayers > 0 && options.MinCoilLayers > options.MaxCoilLayers {
+		return turbine.Options{}, apiError{Code: codeInvalidValue, Message: "minCoilLayers cannot be larger than maxCoilLayers", Field: "minCoilLayers"}
+	}
+
+	if maxEvaluations, ok := optionalInt(jsOptions, "maxEvaluations"); ok {
+		options.MaxEvaluations = int64(maxEvaluations)
+	}
+	if timeBudget, ok := optionalInt(jsOptions, "timeBudgetMs"); ok {
+		options.TimeBudget = time.Duration(timeBudget) * time.Millisecond
+	}
+	// "exact" ranks the candidates with the math the results are shown with, slower but never off by a rounding
+	if precisionName, ok := optionalString(jsOptions, "precision"); ok {
+		options.SearchPrecision, err = turbine.ParsePrecision(precisionName)
+		if err != nil {
+			return turbine.Options{}, fieldError("precision", err)
+		}
+	}
+
+	return options, nil
+}
+
+// runOptimizer(maxWidth, maxHeight, coil, flow, options)
+func optimizerWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return runOptimizer(defaultSession, args, nil)
 	})
+}
 
-	return jsonFunc
+// runOptimizer searches and keeps the result in lastSearch, cancelled is polled during the search and may be nil
+func runOptimizer(lastSearch *session, args []js.Value, cancelled func() bool) any {
+	if len(args) != 4 && len(args) != 5 {
+		return jsError(errArgumentCount)
+	}
+
+	jsOptions := optionsArg(args, 4)
+	if machineType, ok := optionalString(jsOptions, "machineType"); ok && machineType != "turbine" {
+		return runMachineOptimizer(machineType, args, jsOptions)
+	}
 
+	options, err := searchOptionsFromJS(args, jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	options.Cancelled = cancelled
+	walls, err := wallsFromOptions(jsOptions)
+	if err != nil {
+		return jsError(fieldError("walls", err))
+	}
+
+	var searchResult turbine.SearchResult
+	if targetEnergy, ok := optionalFloat(jsOptions, "targetEnergy"); ok {
+		metric := turbine.MinimizeBlocks
+		if metricName, ok := optionalString(jsOptions, "sizeMetric"); ok {
+			metric, err = turbine.ParseSizeMetric(metricName)
+			if err != nil {
+				return jsError(fieldError("sizeMetric", err))
+			}
+		}
+		searchResult, err = turbine.FindSmallestTurbine(options, targetEnergy, metric)
+		if err != nil {
+			return jsError(apiError{Code: codeNoTurbine, Message: err.Error(), Field: "targetEnergy"})
+		}
+	} else if lastSearch.continuous != nil {
+		searchResult = lastSearch.continuous.Search(options)
+	} else {
+		searchResult = turbine.Search(options)
+	}
+
+	if logging.Enabled(logging.Debug) {
+		logging.Debugf("search ran %d evaluations, best %+v", searchResult.Evaluations, searchResult.Turbine.Stats())
+	}
+
+	if !searchResult.Found {
+		if searchResult.Cancelled {
+			return jsError(errCancelled)
+		}
+		return jsError(noTurbineError(searchResult.Err()))
+	}
+	result := newOptimizerResult(searchResult, walls)
+	lastSearch.remember(searchResult, options, walls, result)
+	// a continuous optimizer searches on every update, too often for the history
+	if record, ok := optionalBool(jsOptions, "history"); (record || !ok) && lastSearch.continuous == nil {
+		history.record(args, lastSearch)
+	}
+	return finishResult(result, jsOptions)
 }
 
 func main() {
-	js.Global().Set("runOptimizer", optimizerWrapper())
+	logging.SetSink(consoleSink)
+	searchCost = turbine.CalibrateSearch()
+
+	export("runOptimizer", optimizerWrapper())
+	export("listProfiles", listProfilesWrapper())
+	export("loadProfiles", loadProfilesWrapper())
+	export("importModConfig", importModConfigWrapper())
+	export("getCoilMaterials", getCoilMaterialsWrapper())
+	export("getBladeMaterials", getBladeMaterialsWrapper())
+	export("getFluids", getFluidsWrapper())
+	export("compareCoils", compareCoilsWrapper())
+	export("planCoilUpgrades", planCoilUpgradesWrapper())
+	export("simulateDutyCycle", simulateDutyCycleWrapper())
+	export("simulateStartStop", simulateStartStopWrapper())
+	export("simulateTicks", simulateTicksWrapper())
+	export("simulateSink", simulateSinkWrapper())
+	export("simulateFlowJitter", simulateFlowJitterWrapper())
+	export("simulateController", simulateControllerWrapper())
+	export("auditEnergy", auditEnergyWrapper())
+	export("simulateFlywheel", simulateFlywheelWrapper())
+	export("listSteamSources", listSteamSourcesWrapper())
+	export("recommendBoiler", recommendBoilerWrapper())
+	export("compareCooling", compareCoolingWrapper())
+	export("planFarm", planFarmWrapper())
+	export("listMachines", listMachinesWrapper())
+	export("runMachine", runMachineWrapper())
+	export("refineSearch", refineSearchWrapper())
+	export("heatMap", heatMapWrapper())
+	export("estimateSearch", estimateSearchWrapper())
+	export("sizeLadder", sizeLadderWrapper())
+	export("planExpansion", planExpansionWrapper())
+	export("resizeOptions", resizeOptionsWrapper())
+	export("streamHeatMap", streamHeatMapWrapper())
+	export("streamFlowSweep", streamFlowSweepWrapper())
+	export("lastResult", lastResultWrapper())
+	export("withCoil", withCoilWrapper())
+	export("clearSession", clearSessionWrapper())
+	export("pinResult", pinResultWrapper())
+	export("unpinResult", unpinResultWrapper())
+	export("listPinned", listPinnedWrapper())
+	export("compare", compareWrapper())
+	export("exportResult", exportResultWrapper())
+	export("exportScript", exportScriptWrapper())
+	export("encodePermalink", encodePermalinkWrapper())
+	export("decodePermalink", decodePermalinkWrapper())
+	export("recompute", recomputeWrapper())
+	export("memoryUsage", memoryUsageWrapper())
+	export("setLogLevel", setLogLevelWrapper())
+	export("listHistory", listHistoryWrapper())
+	export("reloadHistory", reloadHistoryWrapper())
+	export("deleteHistory", deleteHistoryWrapper())
+	export("pushState", pushStateWrapper())
+	export("undoState", undoStateWrapper())
+	export("redoState", redoStateWrapper())
+	export("undoStatus", undoStatusWrapper())
+	export("clearStates", clearStatesWrapper())
+	export("newOptimizer", newOptimizerWrapper())
+	export("newContinuousOptimizer", newContinuousOptimizerWrapper())
+	js.Global().Set("handleRequest", handleRequestWrapper())
 	<-make(chan struct{})
 }
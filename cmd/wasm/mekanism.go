@@ -0,0 +1,24 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/mekanism"
+)
+
+// recommendBoiler(steamFlow)
+func recommendBoilerWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 || args[0].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "Expected the steam flow in mB/t", Field: "steamFlow"})
+		}
+
+		boiler, err := mekanism.RecommendBoiler(int64(jsInt(args[0])))
+		if err != nil {
+			return jsError(fieldError("steamFlow", err))
+		}
+		return toJS(boiler)
+	})
+}
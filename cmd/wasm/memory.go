@@ -0,0 +1,77 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"syscall/js"
+)
+
+// how far the wasm heap may grow for one call unless the "memoryBudgetMB" option says otherwise,
+// browsers start failing to grow the linear memory somewhere past a gigabyte
+const defaultMemoryBudgetMB = 256
+
+// rough size of one value once it's converted for js, the float64 with its share of the JSON text and js array
+const bytesPerHeatMapCell = 64
+const bytesPerFlowPoint = 512
+
+const bytesPerMB = 1 << 20
+
+// memoryBudget reads the "memoryBudgetMB" option in bytes
+func memoryBudget(options js.Value) (uint64, error) {
+	budget, ok := optionalInt(options, "memoryBudgetMB")
+	if !ok {
+		return defaultMemoryBudgetMB * bytesPerMB, nil
+	}
+	if budget < 1 {
+		return 0, apiError{Code: codeInvalidValue, Message: "memoryBudgetMB has to be at least 1", Field: "memoryBudgetMB"}
+	}
+	return uint64(budget) * bytesPerMB, nil
+}
+
+// memoryLeft is how much of the options' budget the heap doesn't use yet, garbage is collected first when it's
+// over half used. A spent budget is an error so the call fails instead of the tab.
+func memoryLeft(options js.Value) (uint64, error) {
+	budget, err := memoryBudget(options)
+	if err != nil {
+		return 0, err
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if stats.HeapAlloc >= budget/2 {
+		runtime.GC()
+		runtime.ReadMemStats(&stats)
+	}
+	if stats.HeapAlloc >= budget {
+		return 0, apiError{
+			Code:    codeMemoryBudget,
+			Message: fmt.Sprintf("The calculator already uses %d MB of its %d MB memory budget", stats.HeapAlloc/bytesPerMB, budget/bytesPerMB),
+			Field:   "memoryBudgetMB",
+		}
+	}
+	return budget - stats.HeapAlloc, nil
+}
+
+// memoryUsage(options) returns {heapMB, systemMB, budgetMB}, the heap in use, what the runtime took from the
+// browser and the budget the options give
+func memoryUsageWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 1 {
+			return jsError(errArgumentCount)
+		}
+		budget, err := memoryBudget(optionsArg(args, 0))
+		if err != nil {
+			return jsError(err)
+		}
+
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return toJS(map[string]float64{
+			"heapMB":   float64(stats.HeapAlloc) / bytesPerMB,
+			"systemMB": float64(stats.Sys) / bytesPerMB,
+			"budgetMB": float64(budget) / bytesPerMB,
+		})
+	})
+}
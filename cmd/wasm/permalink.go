@@ -0,0 +1,71 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/share"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// encodePermalink(design, options) checks the design against the "profile" option and returns it as url safe
+// text. The design may carry a "name" and "notes" to keep with it.
+func encodePermalinkWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 1)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		design := args[0]
+		if _, err := designFromJS(design, config); err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		width, _ := optionalInt(design, "width")
+		height, _ := optionalInt(design, "height")
+		coilLayers, _ := optionalInt(design, "coilLayers")
+		coil, _ := optionalString(design, "coil")
+		flowRate, _ := optionalInt(design, "flowRate")
+		permalink := share.Permalink{
+			Design: turbine.Design{Width: int32(width), Height: int32(height), CoilLayers: int32(coilLayers), Coil: coil, FlowRate: int64(flowRate)},
+		}
+		permalink.Blade, _ = optionalString(design, "blade")
+		permalink.Fluid, _ = optionalString(design, "fluid")
+		if outerRingCoils, ok := optionalInt(design, "outerRingCoils"); ok {
+			coils := int64(outerRingCoils)
+			permalink.OuterRingCoils = &coils
+		}
+		permalink.Profile, _ = optionalString(jsOptions, "profile")
+		permalink.Name, _ = optionalString(design, "name")
+		permalink.Notes, _ = optionalString(design, "notes")
+
+		text, err := permalink.Encode()
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+		return text
+	})
+}
+
+// decodePermalink(text) returns the design encodePermalink was given, with its "profile" if it had one
+func decodePermalinkWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 {
+			return jsError(errArgumentCount)
+		}
+		if args[0].Type() != js.TypeString {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "permalink has to be a string", Field: "permalink"})
+		}
+		permalink, err := share.DecodePermalink(args[0].String())
+		if err != nil {
+			return jsError(fieldError("permalink", err))
+		}
+		return toJS(permalink)
+	})
+}
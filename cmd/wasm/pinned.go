@@ -0,0 +1,245 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// how many results can be pinned at once, the compare view has no room for more
+const maxPinned = 8
+
+// pinnedResult is a result kept for comparison, it stays when a new search replaces the last result
+type pinnedResult struct {
+	ID     int    `json:"id"`
+	Label  string `json:"label"`
+	Coil   string `json:"coil"`
+	result optimizerResult
+}
+
+// comparisonRow is one pinned result in the compare table, the columns the compare view shows
+type comparisonRow struct {
+	ID              int     `json:"id"`
+	Label           string  `json:"label"`
+	Coil            string  `json:"coil"`
+	Width           int32   `json:"width"`
+	Height          int32   `json:"height"`
+	CoilLayers      int32   `json:"coilLayers"`
+	Blade           string  `json:"blade,omitempty"`
+	Fluid           string  `json:"fluid"`
+	FlowRate        int64   `json:"flowRate"`
+	RPM             float64 `json:"rpm"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	EnergyPerSteam  float64 `json:"energyPerSteam"`
+	RotorEfficiency float64 `json:"rotorEfficiency"`
+	CoilEfficiency  float64 `json:"coilEfficiency"`
+	TotalBlocks     int64   `json:"totalBlocks"`
+	BlocksPerRFt    float64 `json:"blocksPerRFt"`
+	// items in the build cost, with the walls the result was pinned with
+	BuildItems int64 `json:"buildItems"`
+}
+
+// bestColumn names the best row of a column, a list and not a map by column so the column names aren't
+// taken for fields to convert units of
+type bestColumn struct {
+	Column string `json:"column"`
+	ID     int    `json:"id"`
+}
+
+// comparisonColumns says which way is better for the columns compare picks a best row for
+var comparisonColumns = []struct {
+	name   string
+	higher bool
+	value  func(row comparisonRow) float64
+}{
+	{"energyGenerated", true, func(row comparisonRow) float64 { return row.EnergyGenerated }},
+	{"energyPerSteam", true, func(row comparisonRow) float64 { return row.EnergyPerSteam }},
+	{"rotorEfficiency", true, func(row comparisonRow) float64 { return row.RotorEfficiency }},
+	{"coilEfficiency", true, func(row comparisonRow) float64 { return row.CoilEfficiency }},
+	{"totalBlocks", false, func(row comparisonRow) float64 { return float64(row.TotalBlocks) }},
+	{"blocksPerRFt", false, func(row comparisonRow) float64 { return row.BlocksPerRFt }},
+	{"buildItems", false, func(row comparisonRow) float64 { return float64(row.BuildItems) }},
+}
+
+func (pinned pinnedResult) row() comparisonRow {
+	stats := pinned.result.Stats
+	return comparisonRow{
+		ID:              pinned.ID,
+		Label:           pinned.Label,
+		Coil:            pinned.Coil,
+		Width:           stats.Width,
+		Height:          stats.Height,
+		CoilLayers:      stats.CoilLayers,
+		Blade:           stats.Blade,
+		Fluid:           stats.Fluid,
+		FlowRate:        stats.FlowRate,
+		RPM:             stats.RPM,
+		EnergyGenerated: stats.EnergyGenerated,
+		EnergyPerSteam:  pinned.result.EnergyPerSteam,
+		RotorEfficiency: stats.RotorEfficiency,
+		CoilEfficiency:  stats.CoilEfficiency,
+		TotalBlocks:     pinned.result.TotalBlocks,
+		BlocksPerRFt:    pinned.result.BlocksPerRFt,
+		BuildItems:      pinned.result.BuildCost.Total(),
+	}
+}
+
+// coilName finds the material name of the coil data a search ran with
+func coilName(config *turbine.Config, coil turbine.CoilData) string {
+	for _, material := range config.CoilMaterials() {
+		if material.CoilData == coil {
+			return material.Name
+		}
+	}
+	return ""
+}
+
+func (lastSearch *session) pinIndex(id int) int {
+	return slices.IndexFunc(lastSearch.pinned, func(pinned pinnedResult) bool { return pinned.ID == id })
+}
+
+// pinResult(options) pins the last result with the "label" option, or "Turbine <id>", and returns its {id}
+func pinResultWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.pinResult(args)
+	})
+}
+
+func (lastSearch *session) pinResult(args []js.Value) any {
+	if len(args) > 1 {
+		return jsError(errArgumentCount)
+	}
+	if !lastSearch.found {
+		return jsError(errNoSession)
+	}
+	if len(lastSearch.pinned) >= maxPinned {
+		return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("Only %d results can be pinned, unpin one first", maxPinned)})
+	}
+
+	lastSearch.nextPin++
+	label, ok := optionalString(optionsArg(args, 0), "label")
+	if !ok {
+		label = fmt.Sprintf("Turbine %d", lastSearch.nextPin)
+	}
+	lastSearch.pinned = append(lastSearch.pinned, pinnedResult{
+		ID:     lastSearch.nextPin,
+		Label:  label,
+		Coil:   coilName(lastSearch.options.Config, lastSearch.options.Coil),
+		result: lastSearch.result,
+	})
+	return toJS(map[string]any{"id": lastSearch.nextPin})
+}
+
+// unpinResult(id) removes a pinned result, ids of the others stay the same
+func unpinResultWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.unpinResult(args)
+	})
+}
+
+func (lastSearch *session) unpinResult(args []js.Value) any {
+	if len(args) != 1 {
+		return jsError(errArgumentCount)
+	}
+	if args[0].Type() != js.TypeNumber {
+		return jsError(apiError{Code: codeInvalidArguments, Message: "id has to be a number", Field: "id"})
+	}
+	index := lastSearch.pinIndex(jsInt(args[0]))
+	if index < 0 {
+		return jsError(apiError{Code: codeInvalidValue, Message: "No pinned result has this id", Field: "id"})
+	}
+	lastSearch.pinned = slices.Delete(lastSearch.pinned, index, index+1)
+	return nil
+}
+
+// listPinned(options) returns {pinned} with the full result of every pinned turbine, in the order they were pinned
+func listPinnedWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.listPinned(args)
+	})
+}
+
+func (lastSearch *session) listPinned(args []js.Value) any {
+	if len(args) > 1 {
+		return jsError(errArgumentCount)
+	}
+	jsOptions := optionsArg(args, 0)
+
+	type listed struct {
+		ID    int    `json:"id"`
+		Label string `json:"label"`
+		Coil  string `json:"coil"`
+		optimizerResult
+	}
+	pinned := make([]listed, len(lastSearch.pinned))
+	for i, pin := range lastSearch.pinned {
+		pinned[i] = listed{pin.ID, pin.Label, pin.Coil, pin.result}
+		if err := pinned[i].expandBuildCost(jsOptions); err != nil {
+			return jsError(fieldError("recipes", err))
+		}
+	}
+	result, err := toJSResult(map[string]any{"pinned": pinned}, jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	return result
+}
+
+// compare(ids, options) returns {rows, best} for the pinned results with the given ids, or all of them if ids
+// isn't an array. rows hold the numbers the compare view shows and best is {column, id} of the best row for
+// every column where more or less is better, the first row wins a tie
+func compareWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.compare(args)
+	})
+}
+
+func (lastSearch *session) compare(args []js.Value) any {
+	if len(args) > 2 {
+		return jsError(errArgumentCount)
+	}
+
+	pinned := lastSearch.pinned
+	if len(args) > 0 && args[0].InstanceOf(js.Global().Get("Array")) {
+		pinned = nil
+		for i := range args[0].Length() {
+			id := args[0].Index(i)
+			if id.Type() != js.TypeNumber {
+				return jsError(apiError{Code: codeInvalidArguments, Message: "ids has to be an array of numbers", Field: "ids"})
+			}
+			index := lastSearch.pinIndex(jsInt(id))
+			if index < 0 {
+				return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("No pinned result has id %d", jsInt(id)), Field: "ids"})
+			}
+			pinned = append(pinned, lastSearch.pinned[index])
+		}
+	}
+
+	rows := make([]comparisonRow, len(pinned))
+	for i, pin := range pinned {
+		rows[i] = pin.row()
+	}
+	best := []bestColumn{}
+	for _, column := range comparisonColumns {
+		if len(rows) == 0 {
+			break
+		}
+		bestRow := rows[0]
+		for _, row := range rows[1:] {
+			if column.higher && column.value(row) > column.value(bestRow) || !column.higher && column.value(row) < column.value(bestRow) {
+				bestRow = row
+			}
+		}
+		best = append(best, bestColumn{column.name, bestRow.ID})
+	}
+
+	result, err := toJSResult(map[string]any{"rows": rows, "best": best}, optionsArg(args, 1))
+	if err != nil {
+		return jsError(err)
+	}
+	return result
+}
@@ -0,0 +1,207 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// plainValue turns a go value into what json.Unmarshal would give back for its encoding (maps, slices,
+// float64s, strings, bools and nil) without going through the text, following the same json tags
+func plainValue(value any) (any, error) {
+	return plainReflect(reflect.ValueOf(value))
+}
+
+// jsonMarshaler is json.Marshaler, named here so the tinygo build doesn't import encoding/json for it
+type jsonMarshaler interface {
+	MarshalJSON() ([]byte, error)
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*jsonMarshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func plainReflect(value reflect.Value) (any, error) {
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if value.Type().Implements(jsonMarshalerType) {
+		// custom encodings are rare, decoding them is simpler than mirroring them
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return nil, nil
+		}
+		encoded, err := value.Interface().(jsonMarshaler).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return plainFromJS(js.Global().Get("JSON").Call("parse", string(encoded))), nil
+	}
+	if value.Type().Implements(textMarshalerType) {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return nil, nil
+		}
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return value.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return plainReflect(value.Elem())
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, nil
+		}
+		fallthrough
+	case reflect.Array:
+		elements := make([]any, value.Len())
+		for i := range elements {
+			element, err := plainReflect(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return elements, nil
+	case reflect.Map:
+		if value.IsNil() {
+			return nil, nil
+		}
+		fields := make(map[string]any, value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			key, err := mapKey(iterator.Key())
+			if err != nil {
+				return nil, err
+			}
+			if fields[key], err = plainReflect(iterator.Value()); err != nil {
+				return nil, err
+			}
+		}
+		return fields, nil
+	case reflect.Struct:
+		fields := map[string]any{}
+		return fields, addStructFields(fields, value)
+	default:
+		return nil, fmt.Errorf("Can't convert a %s to js", value.Type())
+	}
+}
+
+// addStructFields adds the exported fields, and those of embedded structs as if they were its own
+// unless the outer struct has a field of the same name
+func addStructFields(fields map[string]any, value reflect.Value) error {
+	structType := value.Type()
+	var embedded []reflect.Value
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, value.Field(i))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if strings.Contains(options, "omitempty") && isEmpty(fieldValue) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		plain, err := plainReflect(fieldValue)
+		if err != nil {
+			return err
+		}
+		fields[name] = plain
+	}
+
+	for _, value := range embedded {
+		inner := map[string]any{}
+		if err := addStructFields(inner, value); err != nil {
+			return err
+		}
+		for name, plain := range inner {
+			if _, ok := fields[name]; !ok {
+				fields[name] = plain
+			}
+		}
+	}
+	return nil
+}
+
+func mapKey(key reflect.Value) (string, error) {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("Can't convert a %s map key to js", key.Type())
+	}
+}
+
+// isEmpty is omitempty's idea of empty
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	default:
+		return value.IsZero() && value.Kind() != reflect.Struct
+	}
+}
+
+// plainFromJS is the go value JSON.parse's result would decode to, functions and undefined become nil
+func plainFromJS(value js.Value) any {
+	switch value.Type() {
+	case js.TypeBoolean:
+		return value.Bool()
+	case js.TypeNumber:
+		return value.Float()
+	case js.TypeString:
+		return value.String()
+	case js.TypeObject:
+		if js.Global().Get("Array").Call("isArray", value).Bool() {
+			elements := make([]any, value.Length())
+			for i := range elements {
+				elements[i] = plainFromJS(value.Index(i))
+			}
+			return elements
+		}
+		keys := js.Global().Get("Object").Call("keys", value)
+		fields := make(map[string]any, keys.Length())
+		for i := range keys.Length() {
+			key := keys.Index(i).String()
+			fields[key] = plainFromJS(value.Get(key))
+		}
+		return fields
+	default:
+		return nil
+	}
+}
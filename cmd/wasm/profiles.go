@@ -0,0 +1,64 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func listProfilesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		list := []any{}
+		for _, profile := range turbine.Profiles() {
+			list = append(list, map[string]any{
+				"name":        profile.Name,
+				"description": profile.Description,
+				"variant":     profile.Config.Variant.String(),
+			})
+		}
+		return list
+	})
+}
+
+func loadProfilesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 || args[0].Type() != js.TypeString {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "Expected a json string with the profiles", Field: "profiles"})
+		}
+		if err := turbine.LoadProfiles([]byte(args[0].String())); err != nil {
+			return jsError(fieldError("profiles", err))
+		}
+		return nil
+	})
+}
+
+// importModConfig(name, text, options) adds a profile from the text of the mod's TOML config file, over the
+// profile in the "base" option or the default one, with the "description" option. It returns {name, ignored},
+// ignored lists the keys of the file the calculator has no use for.
+func importModConfigWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+		if args[0].Type() != js.TypeString {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "name has to be a string", Field: "name"})
+		}
+		if args[1].Type() != js.TypeString {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "Expected the text of the config file", Field: "config"})
+		}
+
+		options := optionsArg(args, 2)
+		base, _ := optionalString(options, "base")
+		if _, err := turbine.ProfileByName(base); base != "" && err != nil {
+			return jsError(fieldError("base", err))
+		}
+		description, _ := optionalString(options, "description")
+		ignored, err := turbine.ImportProfile(args[0].String(), description, args[1].String(), base)
+		if err != nil {
+			return jsError(fieldError("config", err))
+		}
+		return toJS(map[string]any{"name": args[0].String(), "ignored": append([]string{}, ignored...)})
+	})
+}
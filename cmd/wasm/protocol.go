@@ -0,0 +1,88 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+)
+
+// exported holds every global function by name so handleRequest can call them
+var exported = map[string]js.Func{}
+
+func export(name string, function js.Func) {
+	exported[name] = function
+	js.Global().Set(name, function)
+}
+
+// functions that take a callback or return one can't go through text
+var callbackFunctions = map[string]bool{
+	"streamHeatMap":   true,
+	"streamFlowSweep": true,
+	"newOptimizer":    true,
+}
+
+// handleRequest(text) is every other global function behind one string in and one string out, for web workers
+// that pass messages around as text. The request is {"id", "function", "args"} and the response is
+// {"id", "result"} or {"id", "error"} with the same error object the function would have returned
+func handleRequestWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		response := js.Global().Get("Object").New()
+		if len(args) != 1 || args[0].Type() != js.TypeString {
+			response.Set("error", jsError(errArgumentCount))
+			return stringify(response)
+		}
+
+		jsonObject := js.Global().Get("JSON")
+		request, err := parseRequest(jsonObject, args[0].String())
+		if err != nil {
+			response.Set("error", jsError(err))
+			return stringify(response)
+		}
+		response.Set("id", request.Get("id"))
+
+		name := request.Get("function")
+		function, ok := exported[name.String()]
+		if name.Type() != js.TypeString || !ok || callbackFunctions[name.String()] {
+			response.Set("error", jsError(apiError{Code: codeInvalidValue, Message: "Unknown function", Field: "function"}))
+			return stringify(response)
+		}
+
+		var functionArgs []any
+		if requestArgs := request.Get("args"); requestArgs.Type() == js.TypeObject {
+			for i := range requestArgs.Length() {
+				functionArgs = append(functionArgs, requestArgs.Index(i))
+			}
+		}
+		result := function.Invoke(functionArgs...)
+		if isError(result) {
+			response.Set("error", result)
+		} else {
+			response.Set("result", result)
+		}
+		return stringify(response)
+	})
+}
+
+// parseRequest turns the text into an object, JSON.parse throws on bad json and that would escape into js
+func parseRequest(jsonObject js.Value, text string) (request js.Value, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = apiError{Code: codeInvalidArguments, Message: "Request is not valid json"}
+		}
+	}()
+	request = jsonObject.Call("parse", text)
+	if request.Type() != js.TypeObject {
+		return js.Undefined(), apiError{Code: codeInvalidArguments, Message: "Request has to be an object"}
+	}
+	return request, nil
+}
+
+func stringify(value js.Value) string {
+	return js.Global().Get("JSON").Call("stringify", value).String()
+}
+
+// isError tells the error objects from jsError apart from results, results never carry a code
+func isError(value any) bool {
+	jsValue, ok := value.(js.Value)
+	return ok && jsValue.Type() == js.TypeObject && jsValue.Get("code").Type() == js.TypeString
+}
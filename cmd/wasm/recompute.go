@@ -0,0 +1,94 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// whatIfResult is the lighter result a what-if returns, it leaves out the explanation and neighbours so a
+// slider can call it on every step
+type whatIfResult struct {
+	turbine.Stats
+	Coil           string  `json:"coil"`
+	EnergyPerSteam float64 `json:"energyPerSteam"`
+	RotorCapacity  float64 `json:"rotorCapacity"`
+	NoLoadRPM      float64 `json:"noLoadRPM"`
+}
+
+// recompute(base, overrides, options) reruns base with the overrides {flowRate, coil, heightDelta} without
+// searching again. base is a design like designFromJS takes, or null for the last result. heightDelta moves the
+// height a block up or down. The last result stays as it was.
+func recomputeWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.recompute(args)
+	})
+}
+
+func (lastSearch *session) recompute(args []js.Value) any {
+	if len(args) != 2 && len(args) != 3 {
+		return jsError(errArgumentCount)
+	}
+	if args[1].Type() != js.TypeObject {
+		return jsError(apiError{Code: codeInvalidArguments, Message: "Expected an overrides object", Field: "overrides"})
+	}
+	jsOptions := optionsArg(args, 2)
+
+	var base turbine.Turbine
+	var config *turbine.Config
+	var coil string
+	if args[0].IsNull() || args[0].IsUndefined() {
+		if !lastSearch.found {
+			return jsError(errNoSession)
+		}
+		base = lastSearch.search.Turbine
+		config = lastSearch.options.Config
+		coil = coilName(config, lastSearch.options.Coil)
+	} else {
+		var err error
+		config, err = configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		base, err = designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+		coil, _ = optionalString(args[0], "coil")
+	}
+
+	overrides := turbine.Overrides{}
+	if flowRate, ok := optionalInt(args[1], "flowRate"); ok {
+		overrides.FlowRate = int64(flowRate)
+	}
+	if heightDelta, ok := optionalInt(args[1], "heightDelta"); ok {
+		overrides.HeightDelta = int32(heightDelta)
+	}
+	if name, ok := optionalString(args[1], "coil"); ok {
+		coilType, err := config.Coil(name)
+		if err != nil {
+			return jsError(err)
+		}
+		overrides.Coil = &coilType
+		coil = name
+	}
+
+	recomputed, err := base.Recompute(overrides)
+	if err != nil {
+		return jsError(err)
+	}
+	result := whatIfResult{
+		Stats:          recomputed.Stats(),
+		Coil:           coil,
+		EnergyPerSteam: recomputed.EnergyPerSteam(),
+		RotorCapacity:  recomputed.RotorCapacity(),
+		NoLoadRPM:      recomputed.FinalRPMNoLoad(),
+	}
+	converted, err := toJSResult(result, jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	return converted
+}
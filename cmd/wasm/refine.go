@@ -0,0 +1,69 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// refineSearch(design, options) takes a runOptimizer result plus its coil and searches the designs
+// next to it with exact math and a finer flow sweep. The options are runOptimizer's, with the room in
+// "maxWidth" and "maxHeight".
+func refineSearchWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 1)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		previous, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+		walls, err := wallsFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("walls", err))
+		}
+
+		// the room defaults to the largest turbine the config allows, the flow argument only matters to max flow
+		maxWidth, ok := optionalInt(jsOptions, "maxWidth")
+		if !ok {
+			maxWidth = int(config.MaxWidth)
+		}
+		maxHeight, ok := optionalInt(jsOptions, "maxHeight")
+		if !ok {
+			maxHeight = int(config.MaxHeight)
+		}
+		// designFromJS already checked the coil name
+		coilMaterial, _ := optionalString(args[0], "coil")
+		searchArgs := []js.Value{js.ValueOf(maxWidth), js.ValueOf(maxHeight), js.ValueOf(coilMaterial), js.ValueOf(0)}
+		options, err := searchOptionsFromJS(searchArgs, jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		// a max flow search is refined with a fine sweep around the design's flow rate
+		if options.Flow.Variant == turbine.UseMaxFlow {
+			options.Flow = turbine.FlowSetting{Variant: turbine.FindBestFlow}
+		}
+		// without fitness options the designs are ranked like the search that found the result
+		_, hasFitness := optionalString(jsOptions, "fitness")
+		_, hasMinEnergy := optionalFloat(jsOptions, "minEnergy")
+		if !hasFitness && !hasMinEnergy && defaultSession.found {
+			options.Fitness = defaultSession.options.Fitness
+		}
+
+		searchResult := turbine.Refine(options, previous)
+		if !searchResult.Found {
+			return jsError(noTurbineError(searchResult.Err()))
+		}
+		result := newOptimizerResult(searchResult, walls)
+		defaultSession.remember(searchResult, options, walls, result)
+		return finishResult(result, jsOptions)
+	})
+}
@@ -0,0 +1,45 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+)
+
+// resizeOptions(design, options) lists the taller and wider turbines a built one can grow into without moving its
+// shaft, with the options maxWidth and maxHeight bounding them and steamLimit capping their flow
+func resizeOptionsWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+		jsOptions := optionsArg(args, 1)
+
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		maxWidth, maxHeight := config.MaxWidth, config.MaxHeight
+		if width, ok := optionalInt(jsOptions, "maxWidth"); ok {
+			maxWidth = int32(width)
+		}
+		if height, ok := optionalInt(jsOptions, "maxHeight"); ok {
+			maxHeight = int32(height)
+		}
+		steamLimit := 0
+		if limit, ok := optionalInt(jsOptions, "steamLimit"); ok {
+			steamLimit = limit
+		}
+
+		result, err := toJSResult(designTurbine.ResizeOptions(maxWidth, maxHeight, int64(steamLimit)), jsOptions)
+		if err != nil {
+			return jsError(err)
+		}
+		return result
+	})
+}
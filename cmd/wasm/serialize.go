@@ -0,0 +1,83 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/format"
+)
+
+// what the result fields measure, for unit conversion and formatting
+var fieldQuantities = map[string]format.Quantity{
+	"energyGenerated":    format.EnergyRate,
+	"energyGain":         format.EnergyRate,
+	"passiveEnergy":      format.EnergyRate,
+	"activeEnergy":       format.EnergyRate,
+	"gainPerIngot":       format.EnergyRate,
+	"averageEnergy":      format.EnergyRate,
+	"averageDelivered":   format.EnergyRate,
+	"averageWasted":      format.EnergyRate,
+	"extractionRate":     format.EnergyRate,
+	"requiredExtraction": format.EnergyRate,
+	"batteryCapacity":    format.Energy,
+	"recommendedStorage": format.Energy,
+	"flowRate":           format.FluidRate,
+	"maxFlowRate":        format.FluidRate,
+	"steamFlow":          format.FluidRate,
+	"returnFlow":         format.FluidRate,
+	"pipeThroughput":     format.FluidRate,
+	"waterBuffer":        format.Fluid,
+	"rotorCapacity":      format.FluidRate,
+	"sweetSpotFlow":      format.FluidRate,
+	"energyPerSteam":     format.EnergyPerFluid,
+}
+
+// unitSystemFromOptions reads the "energyUnit" and "fluidUnit" options
+func unitSystemFromOptions(options js.Value) (format.UnitSystem, error) {
+	energyUnit, _ := optionalString(options, "energyUnit")
+	fluidUnit, _ := optionalString(options, "fluidUnit")
+	system, err := format.ParseUnitSystem(energyUnit, fluidUnit)
+	if err != nil {
+		// the energy name is checked first
+		if _, energyErr := format.ParseUnitSystem(energyUnit, ""); energyErr != nil {
+			return system, fieldError("energyUnit", err)
+		}
+		return system, fieldError("fluidUnit", err)
+	}
+	return system, nil
+}
+
+// toJSResult is toJS for results that follow the display options: "energyUnit" (RF, FE or J) and
+// "fluidUnit" (mB or B) convert the fields, and "locale" adds a "formatted" object holding every number as text
+func toJSResult(value any, options js.Value) (js.Value, error) {
+	system, err := unitSystemFromOptions(options)
+	if err != nil {
+		return js.Undefined(), err
+	}
+	localeName, formatted := optionalString(options, "locale")
+	if system == format.DefaultUnits && !formatted {
+		return toJS(value), nil
+	}
+
+	plain, err := plainResult(value)
+	if err != nil {
+		return js.Undefined(), apiError{Code: codeInternal, Message: err.Error()}
+	}
+	decoded, ok := plain.(map[string]any)
+	if !ok {
+		return js.Undefined(), apiError{Code: codeInternal, Message: "Only objects can be formatted"}
+	}
+	system.Convert(decoded, fieldQuantities)
+
+	if formatted {
+		locale, err := format.LocaleByName(localeName)
+		if err != nil {
+			return js.Undefined(), fieldError("locale", err)
+		}
+		units := system.Units(fieldQuantities)
+		units["rpm"] = format.Unit{Symbol: "RPM"}
+		decoded["formatted"] = locale.Tree(decoded, units)
+	}
+	return toJS(decoded), nil
+}
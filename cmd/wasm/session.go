@@ -0,0 +1,135 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// session keeps the last search so follow-up calls can answer without searching again
+type session struct {
+	found   bool
+	search  turbine.SearchResult
+	options turbine.Options
+	walls   turbine.WallMaterial
+	// the result before recipes and display options are applied, Result() converges neighbours so it isn't free
+	result optimizerResult
+	// pinned results outlive the last result, ids count up from 1 and are never reused
+	pinned  []pinnedResult
+	nextPin int
+	// set for a continuous optimizer, runOptimizer takes the geometries it already scored from it
+	continuous *turbine.Continuous
+}
+
+// defaultSession backs the global functions, every newOptimizer instance has its own
+var defaultSession = &session{}
+
+var errNoSession = apiError{Code: codeNoSession, Message: "Run the optimizer first"}
+
+func (lastSearch *session) remember(search turbine.SearchResult, options turbine.Options, walls turbine.WallMaterial, result optimizerResult) {
+	lastSearch.found = true
+	lastSearch.search = search
+	lastSearch.options = options
+	lastSearch.walls = walls
+	lastSearch.result = result
+}
+
+// finishResult applies the recipes and display options to a copy of result
+func finishResult(result optimizerResult, jsOptions js.Value) any {
+	if err := result.expandBuildCost(jsOptions); err != nil {
+		return jsError(fieldError("recipes", err))
+	}
+	converted, err := toJSResult(result, jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	return converted
+}
+
+// lastResult(options) returns the last runOptimizer or refineSearch result again, with the units, locale,
+// walls and recipes from the new options
+func lastResultWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.lastResult(args)
+	})
+}
+
+func (lastSearch *session) lastResult(args []js.Value) any {
+	if len(args) > 1 {
+		return jsError(errArgumentCount)
+	}
+	if !lastSearch.found {
+		return jsError(errNoSession)
+	}
+
+	jsOptions := optionsArg(args, 0)
+	result := lastSearch.result
+	if _, ok := optionalString(jsOptions, "walls"); ok {
+		walls, err := wallsFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("walls", err))
+		}
+		result.BuildCost = lastSearch.search.Turbine.BuildCostWith(walls)
+		result.BlockSummary = lastSearch.search.Turbine.BlockSummary(walls)
+	}
+	return finishResult(result, jsOptions)
+}
+
+// withCoil(coil, options) rebuilds the last turbine with another coil material at the same size and flow rate,
+// the rebuilt turbine becomes the last result
+func withCoilWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return defaultSession.withCoil(args)
+	})
+}
+
+func (lastSearch *session) withCoil(args []js.Value) any {
+	if len(args) != 1 && len(args) != 2 {
+		return jsError(errArgumentCount)
+	}
+	if args[0].Type() != js.TypeString {
+		return jsError(apiError{Code: codeInvalidArguments, Message: "coil has to be a coil material name", Field: "coil"})
+	}
+	if !lastSearch.found {
+		return jsError(errNoSession)
+	}
+
+	config := lastSearch.options.Config
+	coilType, err := config.Coil(args[0].String())
+	if err != nil {
+		return jsError(err)
+	}
+
+	stats := lastSearch.search.Turbine.Stats()
+	rebuilt, err := turbine.NewTurbineWithOuterRing(config, stats.Height, stats.Width, stats.CoilLayers, stats.OuterRingCoils, coilType)
+	if err != nil {
+		return jsError(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	if err := rebuilt.EngageCoilLayers(stats.EngagedCoilLayers); err != nil {
+		return jsError(apiError{Code: codeInternal, Message: err.Error()})
+	}
+	rebuilt.SetBlade(lastSearch.search.Turbine.Blade())
+	rebuilt.SetFluid(lastSearch.search.Turbine.Fluid())
+	rebuilt.SetNominalFlowRate(stats.FlowRate)
+	rebuilt.Converge()
+
+	search := lastSearch.search
+	search.Turbine = rebuilt
+	search.Notes = nil
+	options := lastSearch.options
+	options.Coil = coilType
+
+	result := newOptimizerResult(search, lastSearch.walls)
+	lastSearch.remember(search, options, lastSearch.walls, result)
+	return finishResult(result, optionsArg(args, 1))
+}
+
+// clearSession() forgets the last result and the pinned ones
+func clearSessionWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		*defaultSession = session{}
+		return nil
+	})
+}
@@ -0,0 +1,288 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// simulateDutyCycle(design, onTicks, offTicks, options) ticks at most maxSimulatedTicks, a cycle that takes long to
+// settle comes back with settled false
+func simulateDutyCycleWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 3 && len(args) != 4 {
+			return jsError(errArgumentCount)
+		}
+
+		config, err := configFromOptions(optionsArg(args, 3))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		onTicks := jsInt(args[1])
+		offTicks := jsInt(args[2])
+		if onTicks < 0 || offTicks < 0 || onTicks+offTicks == 0 {
+			return jsError(apiError{Code: codeInvalidValue, Message: "Duty cycle needs a positive number of ticks", Field: "onTicks"})
+		}
+		// the measured cycles alone have to fit
+		if maxCycle := maxSimulatedTicks / turbine.MeasuredDutyCycles; onTicks+offTicks > maxCycle {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("onTicks and offTicks can add up to at most %d", maxCycle), Field: "onTicks"})
+		}
+
+		return toJS(designTurbine.SimulateDutyCycle(onTicks, offTicks, maxSimulatedTicks))
+	})
+}
+
+// simulateStartStop(design, runTicks, options)
+func simulateStartStopWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		config, err := configFromOptions(optionsArg(args, 2))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		runTicks := jsInt(args[1])
+		if runTicks < 0 || runTicks > maxSimulatedTicks {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("runTicks must be between 0 and %d", maxSimulatedTicks), Field: "runTicks"})
+		}
+
+		return toJS(designTurbine.SimulateStartStop(runTicks))
+	})
+}
+
+// the series is copied into js, keep it to something a chart can show
+const maxSimulatedTicks = 100000
+
+// simulateTicks(design, ticks, options)
+func simulateTicksWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		config, err := configFromOptions(optionsArg(args, 2))
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		ticks := jsInt(args[1])
+		if ticks < 0 || ticks > maxSimulatedTicks {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("ticks must be between 0 and %d", maxSimulatedTicks), Field: "ticks"})
+		}
+
+		return toJS(designTurbine.SimulateTicks(ticks))
+	})
+}
+
+// simulateFlywheel(design, coilLayers, options) spins the design up without load, coilLayers may be zero in it,
+// and runs it down with coilLayers of the design's coil added, or of the "coil" option's
+func simulateFlywheelWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 2)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+		if args[1].Type() != js.TypeNumber {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "coilLayers has to be a number", Field: "coilLayers"})
+		}
+
+		added, _ := optionalString(args[0], "coil")
+		if name, ok := optionalString(jsOptions, "coil"); ok {
+			added = name
+		}
+		coil, err := config.Coil(added)
+		if err != nil {
+			return jsError(err)
+		}
+
+		report, err := designTurbine.Flywheel(coil, int32(jsInt(args[1])))
+		if err != nil {
+			return jsError(fieldError("coilLayers", err))
+		}
+		return toJS(report)
+	})
+}
+
+// auditEnergy(design, options) runs the design for the "ticks" option, 100 if not given, and returns where the
+// energy went. It starts from the steady state, or from rest if "fromRest" is true.
+func auditEnergyWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 && len(args) != 2 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 1)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		ticks, ok := optionalInt(jsOptions, "ticks")
+		if !ok {
+			ticks = 100
+		}
+		if ticks < 1 || ticks > maxSimulatedTicks {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("ticks must be between 1 and %d", maxSimulatedTicks), Field: "ticks"})
+		}
+
+		designTurbine.SetPrecision(turbine.PrecisionExact)
+		designTurbine.SetActive(true)
+		designTurbine.SetCoilEngaged(true)
+		if fromRest, _ := optionalBool(jsOptions, "fromRest"); fromRest {
+			designTurbine.Reset()
+		} else {
+			designTurbine.SetEnergyForRPM(designTurbine.FinalRPM())
+		}
+		return toJS(designTurbine.Audit(ticks))
+	})
+}
+
+// simulateSink(design, extractionRate, options) with an optional sinkBehavior of "clamp" or "disengage"
+func simulateSinkWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 2)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		extractionRate := args[1].Float()
+		if extractionRate < 0 {
+			return jsError(apiError{Code: codeInvalidValue, Message: "extractionRate cannot be negative", Field: "extractionRate"})
+		}
+
+		behavior := turbine.SinkClampsOutput
+		if name, ok := optionalString(jsOptions, "sinkBehavior"); ok {
+			behavior, err = turbine.ParseSinkBehavior(name)
+			if err != nil {
+				return jsError(fieldError("sinkBehavior", err))
+			}
+		}
+
+		return toJS(designTurbine.SimulateSink(extractionRate, behavior))
+	})
+}
+
+// every run is ticked in full, keep the total near a few million ticks
+const maxJitterRuns = 100
+
+// simulateFlowJitter(design, jitterPercent, options) runs the design with its flow rate off by up to jitterPercent
+// each tick, for the "ticks" option (1200 if not given) over the "runs" option (20 if not given). The "seed" option
+// picks the noise, the same seed gives the same result.
+func simulateFlowJitterWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 2)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		jitter := args[1].Float()
+		if !(jitter >= 0 && jitter <= 100) {
+			return jsError(apiError{Code: codeInvalidValue, Message: "jitterPercent must be between 0 and 100", Field: "jitterPercent"})
+		}
+		ticks, ok := optionalInt(jsOptions, "ticks")
+		if !ok {
+			ticks = 1200
+		}
+		if ticks < 1 || ticks > maxSimulatedTicks {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("ticks must be between 1 and %d", maxSimulatedTicks), Field: "ticks"})
+		}
+		runs, ok := optionalInt(jsOptions, "runs")
+		if !ok {
+			runs = 20
+		}
+		if runs < 1 || runs > maxJitterRuns {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("runs must be between 1 and %d", maxJitterRuns), Field: "runs"})
+		}
+		seed, _ := optionalInt(jsOptions, "seed")
+
+		return toJS(designTurbine.SimulateFlowJitter(jitter/100, ticks, runs, uint64(seed)))
+	})
+}
+
+// simulateController(design, controller, options) spins the design up from rest with a PI controller
+// {targetRPM, proportionalGain, integralGain} setting its flow rate, for the "ticks" option (1200 if not given)
+func simulateControllerWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 && len(args) != 3 {
+			return jsError(errArgumentCount)
+		}
+
+		jsOptions := optionsArg(args, 2)
+		config, err := configFromOptions(jsOptions)
+		if err != nil {
+			return jsError(fieldError("profile", err))
+		}
+		designTurbine, err := designFromJS(args[0], config)
+		if err != nil {
+			return jsError(fieldError("design", err))
+		}
+
+		targetRPM, ok := optionalFloat(args[1], "targetRPM")
+		if !ok || targetRPM <= 0 {
+			return jsError(apiError{Code: codeInvalidValue, Message: "Controller needs a positive targetRPM", Field: "targetRPM"})
+		}
+		controller := turbine.PIController{TargetRPM: targetRPM}
+		controller.ProportionalGain, _ = optionalFloat(args[1], "proportionalGain")
+		controller.IntegralGain, _ = optionalFloat(args[1], "integralGain")
+
+		ticks, ok := optionalInt(jsOptions, "ticks")
+		if !ok {
+			ticks = 1200
+		}
+		if ticks < 1 || ticks > maxSimulatedTicks {
+			return jsError(apiError{Code: codeInvalidValue, Message: fmt.Sprintf("ticks must be between 1 and %d", maxSimulatedTicks), Field: "ticks"})
+		}
+
+		return toJS(designTurbine.SimulateController(controller, ticks))
+	})
+}
@@ -0,0 +1,33 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func listSteamSourcesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return toJS(turbine.SteamSources())
+	})
+}
+
+// steamFlowFromOptions reads {steamSource, steamUnits} and returns the flow rate they provide
+func steamFlowFromOptions(options js.Value) (int64, bool, error) {
+	sourceName, ok := optionalString(options, "steamSource")
+	if !ok {
+		return 0, false, nil
+	}
+	source, err := turbine.SteamSourceByName(sourceName)
+	if err != nil {
+		return 0, false, err
+	}
+	units, ok := optionalFloat(options, "steamUnits")
+	if !ok || units <= 0 {
+		return 0, false, errors.New("steamSource needs a positive steamUnits")
+	}
+	return source.FlowRate(units), true, nil
+}
@@ -0,0 +1,75 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+const defaultSweepChunk = 256
+
+// streamFlowSweep(design, from, to, step, options, onChunk) settles the design at every flow rate in the range
+// and calls onChunk with arrays of up to "chunkSize" points (256 by default) as they are computed, fewer when
+// the "memoryBudgetMB" option can't hold that many.
+// With the "binary" option each chunk is a {columns, rows, buffer} table of float64s instead.
+func streamFlowSweepWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return streamFlowSweep(args)
+	})
+}
+
+func streamFlowSweep(args []js.Value) any {
+	if len(args) != 6 || args[5].Type() != js.TypeFunction {
+		return jsError(errArgumentCount)
+	}
+
+	jsOptions := optionsArg(args, 4)
+	config, err := configFromOptions(jsOptions)
+	if err != nil {
+		return jsError(fieldError("profile", err))
+	}
+	designTurbine, err := designFromJS(args[0], config)
+	if err != nil {
+		return jsError(fieldError("design", err))
+	}
+
+	from := int64(jsInt(args[1]))
+	to := int64(jsInt(args[2]))
+	step := int64(jsInt(args[3]))
+	if step <= 0 {
+		return jsError(apiError{Code: codeInvalidValue, Message: "step has to be positive", Field: "step"})
+	}
+	chunkSize := defaultSweepChunk
+	if value, ok := optionalInt(jsOptions, "chunkSize"); ok && value > 0 {
+		chunkSize = value
+	}
+	// a chunk the memory budget can't hold is sent in smaller ones
+	left, err := memoryLeft(jsOptions)
+	if err != nil {
+		return jsError(err)
+	}
+	chunkSize = max(1, min(chunkSize, int(left/bytesPerFlowPoint)))
+
+	convert := func(chunk []turbine.FlowPoint) js.Value {
+		return toJS(chunk)
+	}
+	if binary, _ := optionalBool(jsOptions, "binary"); binary {
+		convert = flowPointTable
+	}
+
+	onChunk := args[5]
+	chunk := make([]turbine.FlowPoint, 0, chunkSize)
+	designTurbine.SweepFlow(from, to, step, func(point turbine.FlowPoint) {
+		chunk = append(chunk, point)
+		if len(chunk) == chunkSize {
+			onChunk.Invoke(convert(chunk))
+			chunk = chunk[:0]
+		}
+	})
+	if len(chunk) > 0 {
+		onChunk.Invoke(convert(chunk))
+	}
+	return nil
+}
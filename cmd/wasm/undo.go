@@ -0,0 +1,109 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+)
+
+// how many configurations undo can go back through, the oldest is forgotten first
+const maxUndo = 100
+
+// undoStack is the editor's configurations, kept as json so later changes to the objects don't reach them
+type undoStack struct {
+	states []string
+	// index of the configuration in use, -1 before the first push
+	current int
+}
+
+var editorStates = &undoStack{current: -1}
+
+// undoStatus says what the editor can offer, to enable its buttons
+type undoStatus struct {
+	CanUndo bool `json:"canUndo"`
+	CanRedo bool `json:"canRedo"`
+	Depth   int  `json:"depth"`
+}
+
+func (stack *undoStack) status() undoStatus {
+	return undoStatus{CanUndo: stack.current > 0, CanRedo: stack.current < len(stack.states)-1, Depth: len(stack.states)}
+}
+
+// push makes state the current one and drops what could be redone, a state equal to the current one is no change
+func (stack *undoStack) push(state string) {
+	if stack.current >= 0 && stack.states[stack.current] == state {
+		return
+	}
+	stack.states = append(stack.states[:stack.current+1], state)
+	if len(stack.states) > maxUndo {
+		stack.states = stack.states[len(stack.states)-maxUndo:]
+	}
+	stack.current = len(stack.states) - 1
+}
+
+// step moves by offset and returns the state there, or false when there is nothing that way
+func (stack *undoStack) step(offset int) (string, bool) {
+	next := stack.current + offset
+	if stack.current < 0 || next < 0 || next >= len(stack.states) {
+		return "", false
+	}
+	stack.current = next
+	return stack.states[next], true
+}
+
+// pushState(state) records the editor's configuration after a change and returns {canUndo, canRedo, depth}
+func pushStateWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 {
+			return jsError(errArgumentCount)
+		}
+		if args[0].Type() != js.TypeObject {
+			return jsError(apiError{Code: codeInvalidArguments, Message: "state has to be an object", Field: "state"})
+		}
+		editorStates.push(stringify(args[0]))
+		return toJS(editorStates.status())
+	})
+}
+
+// undoState() returns the configuration before the current one, or null when there is none
+func undoStateWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return stepState(args, -1)
+	})
+}
+
+// redoState() returns the configuration undoState went back from, or null when there is none
+func redoStateWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		return stepState(args, 1)
+	})
+}
+
+func stepState(args []js.Value, offset int) any {
+	if len(args) != 0 {
+		return jsError(errArgumentCount)
+	}
+	state, ok := editorStates.step(offset)
+	if !ok {
+		return js.Null()
+	}
+	return js.Global().Get("JSON").Call("parse", state)
+}
+
+// undoStatus() returns {canUndo, canRedo, depth} without changing anything
+func undoStatusWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 0 {
+			return jsError(errArgumentCount)
+		}
+		return toJS(editorStates.status())
+	})
+}
+
+// clearStates() forgets every configuration, for a new editor document
+func clearStatesWrapper() js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		*editorStates = undoStack{current: -1}
+		return nil
+	})
+}
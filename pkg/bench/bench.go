@@ -0,0 +1,89 @@
+// Package bench holds the benchmarks for each part of the turbine model, shared by
+// go test -bench and cmd/bench so both measure the same thing
+package bench
+
+import (
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+type Benchmark struct {
+	Name string
+	Run  func(b *testing.B)
+}
+
+// a mid-sized design that is past its first peak, so every tick takes the slow branches
+const height, width, coilLayers = 16, 13, 3
+const flowRate = 40000
+
+func referenceTurbine(b *testing.B) turbine.Turbine {
+	reference, err := turbine.NewTurbine(&turbine.BiggerReactorsConfig, height, width, coilLayers, turbine.BiggerReactorsConfig.Coils["Enderium"])
+	if err != nil {
+		b.Fatal(err)
+	}
+	reference.SetNominalFlowRate(flowRate)
+	return reference
+}
+
+func searchOptions() turbine.Options {
+	energyFitness := func(turbine turbine.Turbine) float64 {
+		return turbine.Stats().EnergyGenerated
+	}
+	noConstraints := func(turbine.Turbine) bool {
+		return true
+	}
+	return turbine.NewOptions(energyFitness, noConstraints, turbine.BiggerReactorsConfig.Coils["Enderium"], turbine.FlowSetting{Variant: turbine.UseMaxFlow}, turbine.Size{X: 15, Y: 24, Z: 15})
+}
+
+var Benchmarks = []Benchmark{
+	{"NewTurbine", func(b *testing.B) {
+		coil := turbine.BiggerReactorsConfig.Coils["Enderium"]
+		for range b.N {
+			if _, err := turbine.NewTurbine(&turbine.BiggerReactorsConfig, height, width, coilLayers, coil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"Tick", func(b *testing.B) {
+		reference := referenceTurbine(b)
+		reference.Settle()
+		b.ResetTimer()
+		for range b.N {
+			reference.Tick()
+		}
+	}},
+	{"FinalRPM", func(b *testing.B) {
+		reference := referenceTurbine(b)
+		b.ResetTimer()
+		for range b.N {
+			reference.FinalRPM()
+		}
+	}},
+	{"Result", func(b *testing.B) {
+		reference := referenceTurbine(b)
+		reference.Converge()
+		b.ResetTimer()
+		for range b.N {
+			reference.Result()
+		}
+	}},
+	{"EvaluateBatch", func(b *testing.B) {
+		candidates := []turbine.Candidate{}
+		for flow := int64(1000); flow <= 100000; flow += 1000 {
+			candidates = append(candidates, turbine.Candidate{Height: height, Width: width, CoilLayers: coilLayers, FlowRate: flow})
+		}
+		coil := turbine.BiggerReactorsConfig.Coils["Enderium"]
+		for range b.N {
+			if _, err := turbine.EvaluateBatch(&turbine.BiggerReactorsConfig, coil, candidates, turbine.PrecisionFast); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}},
+	{"Search", func(b *testing.B) {
+		options := searchOptions()
+		for range b.N {
+			turbine.Search(options)
+		}
+	}},
+}
@@ -0,0 +1,26 @@
+package build
+
+// Item is one kind of block needed for a build
+type Item struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// Cost lists every block needed to build a machine, in the order a player would gather them
+type Cost []Item
+
+// Add appends an item, skipping it if none are needed
+func (cost Cost) Add(name string, count int64) Cost {
+	if count <= 0 {
+		return cost
+	}
+	return append(cost, Item{name, count})
+}
+
+func (cost Cost) Total() int64 {
+	total := int64(0)
+	for _, item := range cost {
+		total += item.Count
+	}
+	return total
+}
@@ -0,0 +1,14 @@
+package build
+
+import "testing"
+
+func TestCostAddSkipsEmpty(t *testing.T) {
+	cost := Cost{}.Add("Casing", 10).Add("Glass", 0).Add("Coil", 4)
+
+	if len(cost) != 2 {
+		t.Fatalf("got %d items, want 2", len(cost))
+	}
+	if cost.Total() != 14 {
+		t.Errorf("Total = %d, want 14", cost.Total())
+	}
+}
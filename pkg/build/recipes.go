@@ -0,0 +1,81 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Recipe makes Output items from one set of ingredients
+type Recipe struct {
+	Output      int64  `json:"output"`
+	Ingredients []Item `json:"ingredients"`
+}
+
+// Recipes maps an item name to how it's crafted, items without a recipe are raw materials
+type Recipes map[string]Recipe
+
+var ErrRecipeCycle = errors.New("Recipes form a cycle")
+
+// Expand crafts the cost down to raw materials. Every intermediate item is crafted once for everything
+// that needs it, so leftovers from one craft are not counted twice.
+func (cost Cost) Expand(recipes Recipes) (Cost, error) {
+	// order the items so everything comes before its ingredients
+	order := []string{}
+	// and remember the order they were first reached in
+	reached := []string{}
+	state := map[string]int{}
+	const visiting, done = 1, 2
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("%w through %q", ErrRecipeCycle, name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		reached = append(reached, name)
+		for _, ingredient := range recipes[name].Ingredients {
+			if err := visit(ingredient.Name); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		order = append(order, name)
+		return nil
+	}
+	for _, item := range cost {
+		if err := visit(item.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	needed := map[string]int64{}
+	for _, item := range cost {
+		needed[item.Name] += item.Count
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		recipe, ok := recipes[name]
+		if !ok {
+			continue
+		}
+		if recipe.Output <= 0 {
+			return nil, fmt.Errorf("Recipe for %q has to make at least one item", name)
+		}
+
+		crafts := (needed[name] + recipe.Output - 1) / recipe.Output
+		for _, ingredient := range recipe.Ingredients {
+			needed[ingredient.Name] += crafts * ingredient.Count
+		}
+	}
+
+	raw := Cost{}
+	for _, name := range reached {
+		if _, ok := recipes[name]; !ok {
+			raw = raw.Add(name, needed[name])
+		}
+	}
+	return raw, nil
+}
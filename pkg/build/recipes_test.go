@@ -0,0 +1,38 @@
+package build
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	recipes := Recipes{
+		"Casing": {Output: 4, Ingredients: []Item{{"Iron", 4}, {"Graphite", 4}, {"Gear", 1}}},
+		"Glass":  {Output: 2, Ingredients: []Item{{"Casing", 1}, {"Sand", 2}}},
+		"Gear":   {Output: 1, Ingredients: []Item{{"Iron", 4}}},
+	}
+	cost := Cost{}.Add("Glass", 3).Add("Casing", 2)
+
+	raw, err := cost.Expand(recipes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 glass crafts need 2 more casings, so the 4 casings come from a single craft
+	want := Cost{{"Iron", 8}, {"Graphite", 4}, {"Sand", 4}}
+	if !slices.Equal(raw, want) {
+		t.Errorf("got %v, want %v", raw, want)
+	}
+}
+
+func TestExpandCycle(t *testing.T) {
+	recipes := Recipes{
+		"Ingot": {Output: 1, Ingredients: []Item{{"Block", 1}}},
+		"Block": {Output: 9, Ingredients: []Item{{"Ingot", 9}}},
+	}
+
+	if _, err := (Cost{{"Ingot", 1}}).Expand(recipes); !errors.Is(err, ErrRecipeCycle) {
+		t.Errorf("got %v, want a cycle error", err)
+	}
+}
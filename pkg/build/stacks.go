@@ -0,0 +1,44 @@
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const StackSize = 64
+
+// Stacks splits the count into full stacks and what is left over
+func (item Item) Stacks() (stacks, remainder int64) {
+	return item.Count / StackSize, item.Count % StackSize
+}
+
+// StackText reads like "3 stacks + 12", or just the count under a stack
+func (item Item) StackText() string {
+	stacks, remainder := item.Stacks()
+	switch {
+	case stacks == 0:
+		return fmt.Sprintf("%d", remainder)
+	case remainder == 0 && stacks == 1:
+		return "1 stack"
+	case remainder == 0:
+		return fmt.Sprintf("%d stacks", stacks)
+	case stacks == 1:
+		return fmt.Sprintf("1 stack + %d", remainder)
+	default:
+		return fmt.Sprintf("%d stacks + %d", stacks, remainder)
+	}
+}
+
+type itemJSON struct {
+	Name      string `json:"name"`
+	Count     int64  `json:"count"`
+	Stacks    int64  `json:"stacks"`
+	Remainder int64  `json:"remainder"`
+	Text      string `json:"text"`
+}
+
+// MarshalJSON adds the stack math so a shopping list doesn't have to redo it
+func (item Item) MarshalJSON() ([]byte, error) {
+	stacks, remainder := item.Stacks()
+	return json.Marshal(itemJSON{item.Name, item.Count, stacks, remainder, item.StackText()})
+}
@@ -0,0 +1,36 @@
+package build
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStackText(t *testing.T) {
+	tests := []struct {
+		count int64
+		want  string
+	}{
+		{12, "12"},
+		{64, "1 stack"},
+		{70, "1 stack + 6"},
+		{128, "2 stacks"},
+		{204, "3 stacks + 12"},
+	}
+
+	for _, tc := range tests {
+		if got := (Item{"Casing", tc.count}).StackText(); got != tc.want {
+			t.Errorf("%d: got %q, want %q", tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestItemJSON(t *testing.T) {
+	data, err := json.Marshal(Item{"Glass", 204})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"Glass","count":204,"stacks":3,"remainder":12,"text":"3 stacks + 12"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
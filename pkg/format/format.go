@@ -0,0 +1,135 @@
+// Package format turns numbers into text for a locale, so the site can show results without reformatting them
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Locale struct {
+	Name      string `json:"name"`
+	Decimal   string `json:"decimal"`
+	Thousands string `json:"thousands"`
+}
+
+var locales = []Locale{
+	{"en", ".", ","},
+	{"de", ",", "."},
+	// narrow no-break space, like the browsers use
+	{"fr", ",", " "},
+	{"pl", ",", " "},
+}
+
+func Locales() []Locale {
+	return locales
+}
+
+// LocaleByName accepts tags like "de-AT" and falls back to the language
+func LocaleByName(name string) (Locale, error) {
+	language, _, _ := strings.Cut(strings.ToLower(name), "-")
+	for _, locale := range locales {
+		if locale.Name == language {
+			return locale, nil
+		}
+	}
+	return Locale{}, fmt.Errorf("Unknown locale %q", name)
+}
+
+// Number writes the value with thousands separators and the given number of decimals
+func (locale Locale) Number(value float64, decimals int) string {
+	text := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(text, ".")
+
+	var grouped strings.Builder
+	if value < 0 && strings.Trim(text, "0.") != "" {
+		grouped.WriteString("-")
+	}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(locale.Thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "" {
+		grouped.WriteString(locale.Decimal)
+		grouped.WriteString(fraction)
+	}
+	return grouped.String()
+}
+
+var siPrefixes = []string{"", "k", "M", "G", "T", "P"}
+
+// SI writes the value with three significant digits and a prefix, like "40.1 kRF/t"
+func (locale Locale) SI(value float64, unit string) string {
+	prefix := 0
+	magnitude := math.Abs(value)
+	for magnitude >= 999.5 && prefix < len(siPrefixes)-1 {
+		magnitude /= 1000
+		prefix++
+	}
+
+	decimals := 0
+	if magnitude < 9.995 {
+		decimals = 2
+	} else if magnitude < 99.95 {
+		decimals = 1
+	}
+	if prefix == 0 && magnitude == math.Trunc(magnitude) {
+		decimals = 0
+	}
+
+	return locale.Number(math.Copysign(magnitude, value), decimals) + " " + siPrefixes[prefix] + unit
+}
+
+// Unit is written after a number, SI prefixed ones shorten it to three significant digits
+type Unit struct {
+	Symbol   string
+	Prefixed bool
+}
+
+// Tree mirrors decoded json (maps, slices and float64s) with every number formatted, followed by the unit
+// of its field if it has one. Strings and booleans are left out.
+func (locale Locale) Tree(value any, units map[string]Unit) any {
+	return locale.tree(value, "", units)
+}
+
+func (locale Locale) tree(value any, field string, units map[string]Unit) any {
+	switch value := value.(type) {
+	case float64:
+		unit, ok := units[field]
+		if ok && unit.Prefixed {
+			return locale.SI(value, unit.Symbol)
+		}
+
+		// whole numbers as they are, the rest with two decimals
+		decimals := 2
+		if value == math.Trunc(value) {
+			decimals = 0
+		}
+		if ok {
+			return locale.Number(value, decimals) + " " + unit.Symbol
+		}
+		return locale.Number(value, decimals)
+	case map[string]any:
+		formatted := map[string]any{}
+		for key, child := range value {
+			if child := locale.tree(child, key, units); child != nil {
+				formatted[key] = child
+			}
+		}
+		return formatted
+	case []any:
+		formatted := []any{}
+		for _, element := range value {
+			// elements of an array take the unit of the array
+			if child := locale.tree(element, field, units); child != nil {
+				formatted = append(formatted, child)
+			}
+		}
+		return formatted
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	en, _ := LocaleByName("en-US")
+	de, _ := LocaleByName("de")
+
+	tests := []struct {
+		locale   Locale
+		value    float64
+		decimals int
+		want     string
+	}{
+		{en, 0, 0, "0"},
+		{en, 999, 0, "999"},
+		{en, 1234567.891, 2, "1,234,567.89"},
+		{en, -41000, 0, "-41,000"},
+		{en, -0.001, 2, "0.00"},
+		{de, 1234567.891, 1, "1.234.567,9"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.locale.Number(tc.value, tc.decimals); got != tc.want {
+			t.Errorf("%s Number(%v, %d) = %q, want %q", tc.locale.Name, tc.value, tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestSI(t *testing.T) {
+	en, _ := LocaleByName("en")
+	de, _ := LocaleByName("de")
+
+	tests := []struct {
+		locale Locale
+		value  float64
+		want   string
+	}{
+		{en, 40123, "40.1 kRF/t"},
+		{en, 999.7, "1.00 kRF/t"},
+		{en, 512, "512 RF/t"},
+		{en, 2.5, "2.50 RF/t"},
+		{en, 4126566.49, "4.13 MRF/t"},
+		{de, 40123, "40,1 kRF/t"},
+	}
+
+	for _, tc := range tests {
+		if got := tc.locale.SI(tc.value, "RF/t"); got != tc.want {
+			t.Errorf("%s SI(%v) = %q, want %q", tc.locale.Name, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestTree(t *testing.T) {
+	en, _ := LocaleByName("en")
+
+	var decoded any
+	if err := json.Unmarshal([]byte(`{"width":15,"rpm":1830.55,"energyGenerated":40123,"coil":"Gold","ok":true,"peaks":[{"flowRate":12000}]}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	got := en.Tree(decoded, map[string]Unit{"energyGenerated": {"RF/t", true}, "flowRate": {"mB/t", false}})
+	want := map[string]any{
+		"width":           "15",
+		"rpm":             "1,830.55",
+		"energyGenerated": "40.1 kRF/t",
+		"peaks":           []any{map[string]any{"flowRate": "12,000 mB/t"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnknownLocale(t *testing.T) {
+	if _, err := LocaleByName("xx"); err == nil {
+		t.Error("expected an error")
+	}
+}
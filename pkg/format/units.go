@@ -0,0 +1,119 @@
+package format
+
+import "fmt"
+
+// Quantity is what a result field measures, it decides how the field converts between unit systems
+type Quantity int64
+
+const (
+	Energy Quantity = iota
+	// per tick
+	EnergyRate
+	Fluid
+	FluidRate
+	// energy made from each unit of fluid
+	EnergyPerFluid
+)
+
+// UnitSystem picks the energy and fluid units results are shown in
+type UnitSystem struct {
+	Energy string `json:"energy"`
+	Fluid  string `json:"fluid"`
+}
+
+var DefaultUnits = UnitSystem{"RF", "mB"}
+
+// how many of each unit one RF or one mB is
+var energyUnits = map[string]float64{"RF": 1, "FE": 1, "J": 2.5}
+var fluidUnits = map[string]float64{"mB": 1, "B": 0.001}
+
+// ParseUnitSystem checks the names, empty ones keep the default unit
+func ParseUnitSystem(energy, fluid string) (UnitSystem, error) {
+	system := DefaultUnits
+	if energy != "" {
+		if _, ok := energyUnits[energy]; !ok {
+			return system, fmt.Errorf("Unknown energy unit %q", energy)
+		}
+		system.Energy = energy
+	}
+	if fluid != "" {
+		if _, ok := fluidUnits[fluid]; !ok {
+			return system, fmt.Errorf("Unknown fluid unit %q", fluid)
+		}
+		system.Fluid = fluid
+	}
+	return system, nil
+}
+
+// Scale is what a value in RF or mB is multiplied by to get it in this system
+func (system UnitSystem) Scale(quantity Quantity) float64 {
+	switch quantity {
+	case Energy, EnergyRate:
+		return energyUnits[system.Energy]
+	case Fluid, FluidRate:
+		return fluidUnits[system.Fluid]
+	case EnergyPerFluid:
+		return energyUnits[system.Energy] / fluidUnits[system.Fluid]
+	default:
+		panic("Invalid Quantity")
+	}
+}
+
+func (system UnitSystem) Unit(quantity Quantity) Unit {
+	switch quantity {
+	case Energy:
+		return Unit{system.Energy, true}
+	case EnergyRate:
+		return Unit{system.Energy + "/t", true}
+	case Fluid:
+		return Unit{system.Fluid, false}
+	case FluidRate:
+		return Unit{system.Fluid + "/t", false}
+	case EnergyPerFluid:
+		return Unit{system.Energy + "/" + system.Fluid, false}
+	default:
+		panic("Invalid Quantity")
+	}
+}
+
+// Units gives the unit of every field for formatting
+func (system UnitSystem) Units(fields map[string]Quantity) map[string]Unit {
+	units := map[string]Unit{}
+	for field, quantity := range fields {
+		units[field] = system.Unit(quantity)
+	}
+	return units
+}
+
+// Convert scales the fields of decoded json in place, from RF and mB to this system
+func (system UnitSystem) Convert(value any, fields map[string]Quantity) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if quantity, ok := fields[key]; ok {
+				value[key] = system.scaled(child, quantity)
+			} else {
+				system.Convert(child, fields)
+			}
+		}
+	case []any:
+		for _, element := range value {
+			system.Convert(element, fields)
+		}
+	}
+}
+
+// scaled converts a number, or every number in an array
+func (system UnitSystem) scaled(value any, quantity Quantity) any {
+	switch value := value.(type) {
+	case float64:
+		return value * system.Scale(quantity)
+	case []any:
+		for i := range value {
+			value[i] = system.scaled(value[i], quantity)
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,52 @@
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	system, err := ParseUnitSystem("J", "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(`{"energyGenerated":1000,"flowRate":2000,"rpm":1800,"storage":{"batteryCapacity":4},"series":[10,20]}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]Quantity{"energyGenerated": EnergyRate, "flowRate": FluidRate, "batteryCapacity": Energy, "series": EnergyRate}
+	system.Convert(decoded, fields)
+
+	want := map[string]any{
+		"energyGenerated": 2500.0,
+		"flowRate":        2.0,
+		"rpm":             1800.0,
+		"storage":         map[string]any{"batteryCapacity": 10.0},
+		"series":          []any{25.0, 50.0},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("got %v, want %v", decoded, want)
+	}
+
+	if unit := system.Unit(FluidRate); unit.Symbol != "B/t" || unit.Prefixed {
+		t.Errorf("fluid rate unit %+v", unit)
+	}
+	// 1 RF/mB is 2.5 J per 0.001 B
+	if scale := system.Scale(EnergyPerFluid); scale != 2500 {
+		t.Errorf("energy per fluid scale %v, want 2500", scale)
+	}
+}
+
+func TestParseUnitSystem(t *testing.T) {
+	if system, err := ParseUnitSystem("", ""); err != nil || system != DefaultUnits {
+		t.Errorf("empty names: %+v, %v", system, err)
+	}
+	if _, err := ParseUnitSystem("EU", ""); err == nil {
+		t.Error("expected an error for EU")
+	}
+	if _, err := ParseUnitSystem("", "L"); err == nil {
+		t.Error("expected an error for L")
+	}
+}
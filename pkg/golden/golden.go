@@ -0,0 +1,273 @@
+// Package golden compares the turbine model against tick dumps captured from the mods, to put a number
+// on how closely each profile follows the game
+package golden
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// Dump is one turbine recorded in game. The csv starts with "# key=value" lines describing the turbine
+// (profile, width, height, coilLayers, coil and optionally blade and fluid) followed by a header row
+// naming the rpm, flow and rf columns, and an optional tick column when not every tick was recorded.
+type Dump struct {
+	Profile    string
+	Width      int32
+	Height     int32
+	CoilLayers int32
+	Coil       string
+	// empty for the profile's default
+	Blade   string
+	Fluid   string
+	Samples []Sample
+}
+
+// Sample is the turbine at the end of one tick
+type Sample struct {
+	Tick     int64
+	RPM      float64
+	FlowRate float64
+	// RF/t made during the tick
+	Energy float64
+}
+
+// Load reads a dump
+func Load(reader io.Reader) (Dump, error) {
+	dump := Dump{Profile: turbine.DefaultProfileName}
+	buffered := bufio.NewReader(reader)
+
+	for {
+		peek, err := buffered.Peek(1)
+		if err != nil || peek[0] != '#' {
+			break
+		}
+		line, err := buffered.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return Dump{}, err
+		}
+		if err := dump.setField(strings.TrimSpace(strings.TrimPrefix(line, "#"))); err != nil {
+			return Dump{}, err
+		}
+	}
+	if dump.Width == 0 || dump.Height == 0 || dump.CoilLayers == 0 || dump.Coil == "" {
+		return Dump{}, errors.New("Dump needs width, height, coilLayers and coil")
+	}
+
+	rows, err := csv.NewReader(buffered).ReadAll()
+	if err != nil {
+		return Dump{}, err
+	}
+	if len(rows) < 2 {
+		return Dump{}, errors.New("Dump has no samples")
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"rpm", "flow", "rf"} {
+		if _, ok := columns[name]; !ok {
+			return Dump{}, fmt.Errorf("Dump has no %s column", name)
+		}
+	}
+
+	for i, row := range rows[1:] {
+		sample := Sample{Tick: int64(i)}
+		values := []struct {
+			column string
+			into   *float64
+		}{{"rpm", &sample.RPM}, {"flow", &sample.FlowRate}, {"rf", &sample.Energy}}
+		for _, value := range values {
+			*value.into, err = strconv.ParseFloat(strings.TrimSpace(row[columns[value.column]]), 64)
+			if err != nil {
+				return Dump{}, fmt.Errorf("Dump row %d %s: %w", i+2, value.column, err)
+			}
+		}
+		if column, ok := columns["tick"]; ok {
+			sample.Tick, err = strconv.ParseInt(strings.TrimSpace(row[column]), 10, 64)
+			if err != nil {
+				return Dump{}, fmt.Errorf("Dump row %d tick: %w", i+2, err)
+			}
+		}
+		if len(dump.Samples) > 0 && sample.Tick <= dump.Samples[len(dump.Samples)-1].Tick {
+			return Dump{}, fmt.Errorf("Dump row %d goes back to tick %d", i+2, sample.Tick)
+		}
+		dump.Samples = append(dump.Samples, sample)
+	}
+	return dump, nil
+}
+
+func (dump *Dump) setField(field string) error {
+	if field == "" {
+		return nil
+	}
+	key, value, ok := strings.Cut(field, "=")
+	if !ok {
+		return fmt.Errorf("Dump header %q is not key=value", field)
+	}
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	sizes := map[string]*int32{"width": &dump.Width, "height": &dump.Height, "coillayers": &dump.CoilLayers}
+	if size, ok := sizes[strings.ToLower(key)]; ok {
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Dump header %s: %w", key, err)
+		}
+		*size = int32(parsed)
+		return nil
+	}
+
+	switch strings.ToLower(key) {
+	case "profile":
+		dump.Profile = value
+	case "coil":
+		dump.Coil = value
+	case "blade":
+		dump.Blade = value
+	case "fluid":
+		dump.Fluid = value
+	default:
+		// dumps can carry notes like the mod version or who recorded them
+	}
+	return nil
+}
+
+// Turbine builds the recorded turbine
+func (dump Dump) Turbine() (turbine.Turbine, error) {
+	profile, err := turbine.ProfileByName(dump.Profile)
+	if err != nil {
+		return turbine.Turbine{}, err
+	}
+	config := profile.Config
+	coil, err := config.Coil(dump.Coil)
+	if err != nil {
+		return turbine.Turbine{}, err
+	}
+
+	built, err := turbine.NewTurbine(config, dump.Height, dump.Width, dump.CoilLayers, coil)
+	if err != nil {
+		return built, err
+	}
+	if dump.Blade != "" {
+		blade, err := config.Blade(dump.Blade)
+		if err != nil {
+			return built, err
+		}
+		built.SetBlade(blade)
+	}
+	if dump.Fluid != "" {
+		fluid, err := config.Fluid(dump.Fluid)
+		if err != nil {
+			return built, err
+		}
+		built.SetFluid(fluid)
+	}
+	built.SetPrecision(turbine.PrecisionExact)
+	return built, nil
+}
+
+// Comparison is how far the model strays from one or more dumps of a profile
+type Comparison struct {
+	Profile string
+	Dumps   int
+	RPM     Errors
+	Energy  Errors
+}
+
+// Compare starts the model at the first sample's rpm and runs it tick by tick on the recorded flow rates,
+// comparing every later sample. Between recorded ticks the flow rate of the next sample is held.
+func Compare(dump Dump) (Comparison, error) {
+	simulated, err := dump.Turbine()
+	if err != nil {
+		return Comparison{}, err
+	}
+	if len(dump.Samples) == 0 {
+		return Comparison{}, errors.New("Dump has no samples")
+	}
+
+	comparison := Comparison{Profile: dump.Profile, Dumps: 1}
+	simulated.SetEnergyForRPM(dump.Samples[0].RPM)
+	for i, sample := range dump.Samples[1:] {
+		simulated.SetNominalFlowRate(int64(math.Round(sample.FlowRate)))
+		for range sample.Tick - dump.Samples[i].Tick {
+			simulated.Tick()
+		}
+		stats := simulated.Stats()
+		comparison.RPM.Add(stats.RPM, sample.RPM)
+		comparison.Energy.Add(stats.EnergyGenerated, sample.Energy)
+	}
+	return comparison, nil
+}
+
+// Merge adds another comparison of the same profile
+func (comparison *Comparison) Merge(other Comparison) {
+	comparison.Dumps += other.Dumps
+	comparison.RPM.Merge(other.RPM)
+	comparison.Energy.Merge(other.Energy)
+}
+
+// Errors sums up the differences between simulated and recorded values
+type Errors struct {
+	samples   int
+	absolute  float64
+	squared   float64
+	maximum   float64
+	relative  float64
+	nonZeroes int
+}
+
+// ErrorStats summarizes Errors
+type ErrorStats struct {
+	Samples      int     `json:"samples"`
+	MeanAbsolute float64 `json:"meanAbsolute"`
+	RMS          float64 `json:"rms"`
+	MaxAbsolute  float64 `json:"maxAbsolute"`
+	// only over the samples where the game value isn't zero
+	MeanRelative float64 `json:"meanRelative"`
+}
+
+func (sums *Errors) Add(simulated, recorded float64) {
+	difference := math.Abs(simulated - recorded)
+	sums.samples++
+	sums.absolute += difference
+	sums.squared += difference * difference
+	sums.maximum = max(sums.maximum, difference)
+	if recorded != 0 {
+		sums.relative += difference / math.Abs(recorded)
+		sums.nonZeroes++
+	}
+}
+
+func (sums *Errors) Merge(other Errors) {
+	sums.samples += other.samples
+	sums.absolute += other.absolute
+	sums.squared += other.squared
+	sums.maximum = max(sums.maximum, other.maximum)
+	sums.relative += other.relative
+	sums.nonZeroes += other.nonZeroes
+}
+
+func (sums Errors) Stats() ErrorStats {
+	if sums.samples == 0 {
+		return ErrorStats{}
+	}
+	stats := ErrorStats{
+		Samples:      sums.samples,
+		MeanAbsolute: sums.absolute / float64(sums.samples),
+		RMS:          math.Sqrt(sums.squared / float64(sums.samples)),
+		MaxAbsolute:  sums.maximum,
+	}
+	if sums.nonZeroes > 0 {
+		stats.MeanRelative = sums.relative / float64(sums.nonZeroes)
+	}
+	return stats
+}
@@ -0,0 +1,120 @@
+package golden
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+const header = "# profile=BiggerReactors-0.6\n# width=9\n# height=10\n# coilLayers=2\n# coil=Gold\n# recordedBy=test\n"
+
+func TestLoad(t *testing.T) {
+	dump, err := Load(strings.NewReader(header + "Tick,RPM,Flow,RF\n0,900,2000,1200.5\n20,905.5,2000,1210\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dump.Width != 9 || dump.Height != 10 || dump.CoilLayers != 2 || dump.Coil != "Gold" {
+		t.Errorf("turbine %+v", dump)
+	}
+	want := []Sample{{0, 900, 2000, 1200.5}, {20, 905.5, 2000, 1210}}
+	if fmt.Sprint(dump.Samples) != fmt.Sprint(want) {
+		t.Errorf("samples %v, want %v", dump.Samples, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := map[string]string{
+		"no turbine":     "rpm,flow,rf\n1,2,3\n",
+		"bad size":       "# width=nine\n",
+		"no rf column":   header + "rpm,flow\n1,2\n",
+		"not a number":   header + "rpm,flow,rf\n1,two,3\n",
+		"ragged row":     header + "rpm,flow,rf\n1,2\n",
+		"ticks backward": header + "tick,rpm,flow,rf\n5,1,2,3\n4,1,2,3\n",
+		"no samples":     header + "rpm,flow,rf\n",
+	}
+	for name, data := range tests {
+		if _, err := Load(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// a dump recorded from the model itself has to compare without error
+func TestCompareAgainstModel(t *testing.T) {
+	dump, err := Load(strings.NewReader(header + "rpm,flow,rf\n0,0,0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := dump.Turbine()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dump.Samples = dump.Samples[:1]
+	for tick := int64(1); tick <= 400; tick++ {
+		flowRate := int64(2000)
+		if tick > 200 {
+			flowRate = 1000
+		}
+		recorded.SetNominalFlowRate(flowRate)
+		recorded.Tick()
+		// every fifth tick, like a dump taken with a slower logger
+		if tick%5 == 0 {
+			stats := recorded.Stats()
+			dump.Samples = append(dump.Samples, Sample{tick, stats.RPM, float64(flowRate), stats.EnergyGenerated})
+		}
+	}
+
+	comparison, err := Compare(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, stats := range map[string]ErrorStats{"rpm": comparison.RPM.Stats(), "energy": comparison.Energy.Stats()} {
+		if stats.Samples != 80 || stats.MaxAbsolute > 1e-9 {
+			t.Errorf("%s: %+v", name, stats)
+		}
+	}
+
+	// the same dump on a profile with other physics
+	dump.Profile = "ExtremeReactors-2.0"
+	other, err := Compare(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.RPM.Stats().MeanRelative == 0 {
+		t.Error("a different profile matched the dump exactly")
+	}
+}
+
+func TestErrorStats(t *testing.T) {
+	var first, second Errors
+	first.Add(11, 10)
+	first.Add(7, 10)
+	second.Add(5, 0)
+	first.Merge(second)
+
+	stats := first.Stats()
+	want := ErrorStats{Samples: 3, MeanAbsolute: 3, RMS: math.Sqrt(35.0 / 3), MaxAbsolute: 5, MeanRelative: 0.2}
+	if stats != want {
+		t.Errorf("stats %+v, want %+v", stats, want)
+	}
+	if (Errors{}).Stats() != (ErrorStats{}) {
+		t.Error("no samples should give empty stats")
+	}
+}
+
+func TestUnknownProfile(t *testing.T) {
+	dump := Dump{Profile: "nope", Width: 9, Height: 10, CoilLayers: 2, Coil: "Gold", Samples: []Sample{{}}}
+	if _, err := Compare(dump); err == nil {
+		t.Error("expected an error")
+	}
+	dump.Profile = turbine.DefaultProfileName
+	dump.Coil = "Gol"
+	if _, err := Compare(dump); err == nil {
+		t.Error("expected an error")
+	}
+}
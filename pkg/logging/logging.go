@@ -0,0 +1,112 @@
+// Package logging is the one place diagnostic output goes through, so it can be turned down in production.
+// Messages go to stderr unless a program routes them elsewhere with SetSink, the wasm build sends them to the
+// browser console.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Level int64
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+	// nothing is logged
+	Off
+)
+
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return Debug, nil
+	case "info":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error":
+		return Error, nil
+	case "off", "none":
+		return Off, nil
+	default:
+		return Info, fmt.Errorf("Unknown log level %q", name)
+	}
+}
+
+func (level Level) String() string {
+	switch level {
+	case Debug:
+		return "debug"
+	case Info:
+		return "info"
+	case Warn:
+		return "warn"
+	case Error:
+		return "error"
+	default:
+		return "off"
+	}
+}
+
+// Sink writes out one message that passed the level
+type Sink func(level Level, message string)
+
+// StderrSink prefixes each message with its level
+func StderrSink(level Level, message string) {
+	fmt.Fprintf(os.Stderr, "%-5s %s\n", strings.ToUpper(level.String()), message)
+}
+
+var (
+	mutex        sync.RWMutex
+	currentLevel = Info
+	currentSink  = Sink(StderrSink)
+)
+
+// SetLevel drops every message below level
+func SetLevel(level Level) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	currentLevel = level
+}
+
+func CurrentLevel() Level {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return currentLevel
+}
+
+// SetSink routes the messages to sink, nil goes back to stderr
+func SetSink(sink Sink) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if sink == nil {
+		sink = StderrSink
+	}
+	currentSink = sink
+}
+
+// Enabled tells whether a message at level would be written, to skip working out what to log
+func Enabled(level Level) bool {
+	return level != Off && level >= CurrentLevel()
+}
+
+// logf formats the message only when it is going to be written
+func logf(level Level, format string, args ...any) {
+	mutex.RLock()
+	sink := currentSink
+	enabled := level != Off && level >= currentLevel
+	mutex.RUnlock()
+	if enabled {
+		sink(level, fmt.Sprintf(format, args...))
+	}
+}
+
+func Debugf(format string, args ...any) { logf(Debug, format, args...) }
+func Infof(format string, args ...any)  { logf(Info, format, args...) }
+func Warnf(format string, args ...any)  { logf(Warn, format, args...) }
+func Errorf(format string, args ...any) { logf(Error, format, args...) }
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, level := range []Level{Debug, Info, Warn, Error, Off} {
+		parsed, err := ParseLevel(level.String())
+		if err != nil || parsed != level {
+			t.Errorf("ParseLevel(%q) = %v, %v", level.String(), parsed, err)
+		}
+	}
+	if level, err := ParseLevel("WARNING"); err != nil || level != Warn {
+		t.Errorf("ParseLevel(\"WARNING\") = %v, %v", level, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel accepted \"verbose\"")
+	}
+}
+
+func TestLevelFilters(t *testing.T) {
+	var logged []string
+	SetSink(func(level Level, message string) {
+		logged = append(logged, level.String()+" "+message)
+	})
+	defer SetSink(nil)
+	defer SetLevel(CurrentLevel())
+
+	SetLevel(Warn)
+	Debugf("tick %d", 1)
+	Infof("searching")
+	Warnf("slow search, %d ms", 300)
+	Errorf("out of memory")
+	if want := []string{"warn slow search, 300 ms", "error out of memory"}; !reflect.DeepEqual(logged, want) {
+		t.Errorf("logged %q, want %q", logged, want)
+	}
+
+	logged = nil
+	SetLevel(Off)
+	Errorf("out of memory")
+	if len(logged) != 0 || Enabled(Error) {
+		t.Errorf("level off logged %q", logged)
+	}
+}
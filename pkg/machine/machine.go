@@ -0,0 +1,77 @@
+package machine
+
+import (
+	"fmt"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// Machine is one calculated multiblock, whatever mod it comes from
+type Machine interface {
+	// Validate checks the machine against the structural rules of its mod
+	Validate() error
+	// Simulate brings the machine to its steady state
+	Simulate()
+	BuildCost() build.Cost
+	// Serialize returns a value with json tags describing the machine for the frontend
+	Serialize() any
+}
+
+// Calculator knows how to find the best machine of one kind
+type Calculator struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Optimize decodes a json request, whose fields are up to the calculator, and returns the best machine for it
+	Optimize func(request []byte) (Machine, error) `json:"-"`
+}
+
+var calculators = []Calculator{}
+
+func Register(calculator Calculator) {
+	for _, registered := range calculators {
+		if registered.Name == calculator.Name {
+			panic(fmt.Sprintf("Machine %q registered twice", calculator.Name))
+		}
+	}
+	calculators = append(calculators, calculator)
+}
+
+func Lookup(name string) (Calculator, error) {
+	for _, calculator := range calculators {
+		if calculator.Name == name {
+			return calculator, nil
+		}
+	}
+	return Calculator{}, fmt.Errorf("Unknown machine type %q", name)
+}
+
+func List() []Calculator {
+	return calculators
+}
+
+// Report is what the frontend gets back for any machine
+type Report struct {
+	Machine   string     `json:"machine"`
+	Result    any        `json:"result"`
+	BuildCost build.Cost `json:"buildCost"`
+}
+
+// Run optimizes, validates and simulates a machine of the named kind
+func Run(name string, request []byte) (Report, error) {
+	calculator, err := Lookup(name)
+	if err != nil {
+		return Report{}, err
+	}
+
+	machine, err := calculator.Optimize(request)
+	if err != nil {
+		return Report{}, err
+	}
+	if err := machine.Validate(); err != nil {
+		return Report{}, err
+	}
+	machine.Simulate()
+
+	return Report{name, machine.Serialize(), machine.BuildCost()}, nil
+}
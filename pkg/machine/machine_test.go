@@ -0,0 +1,79 @@
+package machine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+type fakeMachine struct {
+	valid     bool
+	simulated bool
+}
+
+func (fake *fakeMachine) Validate() error {
+	if !fake.valid {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+func (fake *fakeMachine) Simulate() {
+	fake.simulated = true
+}
+
+func (fake *fakeMachine) BuildCost() build.Cost {
+	return build.Cost{}.Add("Casing", 1)
+}
+
+func (fake *fakeMachine) Serialize() any {
+	return fake.simulated
+}
+
+func withCalculators(t *testing.T, registered ...Calculator) {
+	saved := calculators
+	calculators = nil
+	for _, calculator := range registered {
+		Register(calculator)
+	}
+	t.Cleanup(func() {
+		calculators = saved
+	})
+}
+
+func TestRun(t *testing.T) {
+	withCalculators(t,
+		Calculator{Name: "good", Optimize: func([]byte) (Machine, error) { return &fakeMachine{valid: true}, nil }},
+		Calculator{Name: "bad", Optimize: func([]byte) (Machine, error) { return &fakeMachine{}, nil }},
+	)
+
+	report, err := Run("good", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Result != true {
+		t.Error("machine was not simulated before serializing")
+	}
+	if len(report.BuildCost) != 1 {
+		t.Error("build cost missing")
+	}
+
+	if _, err := Run("bad", nil); err == nil {
+		t.Error("expected the validation error")
+	}
+	if _, err := Run("missing", nil); err == nil {
+		t.Error("expected an unknown machine error")
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	withCalculators(t, Calculator{Name: "twice"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	Register(Calculator{Name: "twice"})
+}
@@ -0,0 +1,13 @@
+// Package machines registers every calculator with the machine registry, importing it is enough to make them available
+package machines
+
+import (
+	"encoding/json"
+)
+
+func decodeRequest(request []byte, into any) error {
+	if len(request) == 0 {
+		return nil
+	}
+	return json.Unmarshal(request, into)
+}
@@ -0,0 +1,45 @@
+package machines
+
+import (
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/machine"
+)
+
+func TestRegisteredMachinesRun(t *testing.T) {
+	requests := map[string]string{
+		"turbine":         `{"maxWidth": 9, "maxHeight": 12, "coil": "Enderium", "targetRPM": 1800}`,
+		"mekanismTurbine": `{"maxWidth": 11, "maxHeight": 14, "steamFlow": 50000}`,
+		"mekanismBoiler":  `{"steamFlow": 50000}`,
+	}
+
+	if len(machine.List()) != len(requests) {
+		t.Errorf("%d machines registered, %d tested", len(machine.List()), len(requests))
+	}
+
+	for name, request := range requests {
+		report, err := machine.Run(name, []byte(request))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if report.BuildCost.Total() == 0 {
+			t.Errorf("%s: empty build cost", name)
+		}
+	}
+}
+
+func TestTurbineRequestErrors(t *testing.T) {
+	requests := []string{
+		`{"maxWidth": 9, "maxHeight": 12, "coil": "Cheese"}`,
+		`{"maxWidth": 9, "maxHeight": 12, "coil": "Iron", "profile": "nope"}`,
+		`{"maxWidth": "wide"}`,
+		`{"maxWidth": 9, "maxHeight": 12, "coil": "Iron", "walls": "wool"}`,
+		`{"maxWidth": 9, "maxHeight": 12, "coil": "Iron", "minCoilLayers": 20}`,
+	}
+	for _, request := range requests {
+		if _, err := machine.Run("turbine", []byte(request)); err == nil {
+			t.Errorf("%s: expected an error", request)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package machines
+
+import (
+	"errors"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/machine"
+	"github.com/drabart/turbine-calculator-website/pkg/mekanism"
+)
+
+type industrialTurbineRequest struct {
+	MaxWidth  int   `json:"maxWidth"`
+	MaxHeight int   `json:"maxHeight"`
+	SteamFlow int64 `json:"steamFlow"`
+}
+
+type industrialTurbine struct {
+	steamFlow int64
+	turbine   mekanism.IndustrialTurbine
+}
+
+func (machine *industrialTurbine) Validate() error {
+	_, err := mekanism.NewIndustrialTurbine(machine.turbine.Width, machine.turbine.Height, machine.turbine.Rotors, machine.steamFlow)
+	return err
+}
+
+// the industrial turbine has no spin up, its steady state is what NewIndustrialTurbine computes
+func (machine *industrialTurbine) Simulate() {}
+
+func (machine *industrialTurbine) BuildCost() build.Cost {
+	return machine.turbine.BuildCost()
+}
+
+func (machine *industrialTurbine) Serialize() any {
+	return machine.turbine
+}
+
+func optimizeIndustrialTurbine(data []byte) (machine.Machine, error) {
+	request := industrialTurbineRequest{}
+	if err := decodeRequest(data, &request); err != nil {
+		return nil, err
+	}
+
+	turbine, err := mekanism.OptimizeIndustrialTurbine(request.MaxWidth, request.MaxHeight, request.SteamFlow)
+	if err != nil {
+		return nil, err
+	}
+	return &industrialTurbine{request.SteamFlow, turbine}, nil
+}
+
+type boilerRequest struct {
+	SteamFlow int64 `json:"steamFlow"`
+}
+
+type boiler struct {
+	steamFlow int64
+	boiler    mekanism.BoilerRecommendation
+}
+
+func (machine *boiler) Validate() error {
+	if machine.boiler.BoilCapacity < machine.steamFlow {
+		return errors.New("Boiler cannot provide the requested steam")
+	}
+	return nil
+}
+
+func (machine *boiler) Simulate() {}
+
+func (machine *boiler) BuildCost() build.Cost {
+	return machine.boiler.BuildCost()
+}
+
+func (machine *boiler) Serialize() any {
+	return machine.boiler
+}
+
+func optimizeBoiler(data []byte) (machine.Machine, error) {
+	request := boilerRequest{}
+	if err := decodeRequest(data, &request); err != nil {
+		return nil, err
+	}
+
+	recommendation, err := mekanism.RecommendBoiler(request.SteamFlow)
+	if err != nil {
+		return nil, err
+	}
+	return &boiler{request.SteamFlow, recommendation}, nil
+}
+
+func init() {
+	machine.Register(machine.Calculator{
+		Name:        "mekanismTurbine",
+		Description: "Mekanism industrial turbine",
+		Optimize:    optimizeIndustrialTurbine,
+	})
+	machine.Register(machine.Calculator{
+		Name:        "mekanismBoiler",
+		Description: "Mekanism thermoelectric boiler",
+		Optimize:    optimizeBoiler,
+	})
+}
@@ -0,0 +1,114 @@
+package machines
+
+import (
+	"errors"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/machine"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+type turbineRequest struct {
+	MaxWidth  int32  `json:"maxWidth"`
+	MaxHeight int32  `json:"maxHeight"`
+	Coil      string `json:"coil"`
+	// zero runs every turbine at its max flow
+	FlowRate int64 `json:"flowRate"`
+	// mB/t the best flow rate under FlowRate is narrowed down to, zero for the default
+	FlowTolerance int64  `json:"flowTolerance"`
+	TargetRPM     int64  `json:"targetRPM"`
+	Profile       string `json:"profile"`
+
+	MinCoilLayers int32 `json:"minCoilLayers"`
+	MaxCoilLayers int32 `json:"maxCoilLayers"`
+
+	// "glass", "casing" or "band", glass when empty
+	Walls string `json:"walls"`
+}
+
+type reactorTurbine struct {
+	config  *turbine.Config
+	coil    string
+	walls   turbine.WallMaterial
+	turbine turbine.Turbine
+}
+
+type reactorTurbineResult struct {
+	turbine.Result
+	Coil string `json:"coil"`
+}
+
+func (machine *reactorTurbine) Validate() error {
+	stats := machine.turbine.Stats()
+	_, err := turbine.NewTurbine(machine.config, stats.Height, stats.Width, stats.CoilLayers, machine.config.Coils[machine.coil])
+	return err
+}
+
+func (machine *reactorTurbine) Simulate() {
+	machine.turbine.Converge()
+}
+
+func (machine *reactorTurbine) BuildCost() build.Cost {
+	return machine.turbine.BuildCostWith(machine.walls)
+}
+
+func (machine *reactorTurbine) Serialize() any {
+	return reactorTurbineResult{machine.turbine.Result(), machine.coil}
+}
+
+func optimizeReactorTurbine(data []byte) (machine.Machine, error) {
+	request := turbineRequest{Profile: turbine.DefaultProfileName}
+	if err := decodeRequest(data, &request); err != nil {
+		return nil, err
+	}
+
+	profile, err := turbine.ProfileByName(request.Profile)
+	if err != nil {
+		return nil, err
+	}
+	walls := turbine.GlassWalls
+	if request.Walls != "" {
+		walls, err = turbine.ParseWallMaterial(request.Walls)
+		if err != nil {
+			return nil, err
+		}
+	}
+	coilType, err := profile.Config.Coil(request.Coil)
+	if err != nil {
+		return nil, err
+	}
+
+	flowSetting := turbine.FlowSetting{Variant: turbine.UseMaxFlow}
+	if request.TargetRPM > 0 {
+		flowSetting = turbine.FlowSetting{Variant: turbine.UseTargetRPM, Value: request.TargetRPM}
+	} else if request.FlowRate > 0 {
+		flowSetting = turbine.FlowSetting{Variant: turbine.FindBestUnderFlow, Value: request.FlowRate, Tolerance: request.FlowTolerance}
+	}
+
+	energyFitness := func(turbine turbine.Turbine) float64 {
+		return turbine.Stats().EnergyGenerated
+	}
+	noConstraints := func(turbine.Turbine) bool {
+		return true
+	}
+	maxSize := turbine.Size{X: request.MaxWidth, Y: request.MaxHeight, Z: request.MaxWidth}
+
+	options := turbine.NewOptions(energyFitness, noConstraints, coilType, flowSetting, maxSize)
+	options.Config = profile.Config
+	options.MinCoilLayers = request.MinCoilLayers
+	options.MaxCoilLayers = request.MaxCoilLayers
+
+	result := turbine.Search(options)
+	if !result.Found {
+		return nil, errors.New("No turbine fits the given constraints")
+	}
+	return &reactorTurbine{profile.Config, request.Coil, walls, result.Turbine}, nil
+}
+
+func init() {
+	machine.Register(machine.Calculator{
+		Name:        "turbine",
+		Description: "Bigger Reactors / Extreme Reactors turbine",
+		Optimize:    optimizeReactorTurbine,
+	})
+}
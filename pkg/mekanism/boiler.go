@@ -0,0 +1,119 @@
+package mekanism
+
+import (
+	"errors"
+	"math"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// thermoelectric boiler rules as of Mekanism 10
+const BoilCapacityPerSuperheater int64 = 320
+const WaterPerBlock int64 = 16000
+const SteamPerBlock int64 = 160000
+
+// outer dimensions, including casing
+const boilerMinSize = 3
+const boilerMinHeight = 4
+const boilerMaxSize = 18
+
+// both cavities should hold at least this many ticks of flow so the turbine never runs dry
+const bufferTicks int64 = 20
+
+type BoilerRecommendation struct {
+	// outer dimensions, the footprint is always square
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	SuperheatingElements int `json:"superheatingElements"`
+	PressureDispersers   int `json:"pressureDispersers"`
+
+	// layers below and above the dispersers
+	WaterCavityLayers int `json:"waterCavityLayers"`
+	SteamCavityLayers int `json:"steamCavityLayers"`
+
+	BoilCapacity  int64 `json:"boilCapacity"`
+	WaterCapacity int64 `json:"waterCapacity"`
+	SteamCapacity int64 `json:"steamCapacity"`
+}
+
+func (boiler BoilerRecommendation) volume() int {
+	return boiler.Width * boiler.Width * boiler.Height
+}
+
+var ErrBoilerTooBig = errors.New("No boiler within the size limit can provide that much steam")
+
+// RecommendBoiler finds the smallest thermoelectric boiler able to boil steamFlow mB/t
+func RecommendBoiler(steamFlow int64) (BoilerRecommendation, error) {
+	if steamFlow <= 0 {
+		return BoilerRecommendation{}, errors.New("Steam flow must be positive")
+	}
+
+	superheaters := int(math.Ceil(float64(steamFlow) / float64(BoilCapacityPerSuperheater)))
+	buffer := steamFlow * bufferTicks
+
+	var best BoilerRecommendation
+	found := false
+
+	for width := boilerMinSize; width <= boilerMaxSize; width++ {
+		inner := width - 2
+		layerBlocks := inner * inner
+
+		for height := boilerMinHeight; height <= boilerMaxSize; height++ {
+			// one layer of the interior is taken by the dispersers
+			cavityLayers := height - 2 - 1
+
+			for waterLayers := 1; waterLayers < cavityLayers; waterLayers++ {
+				steamLayers := cavityLayers - waterLayers
+
+				waterBlocks := layerBlocks*waterLayers - superheaters
+				if waterBlocks <= 0 || int64(waterBlocks)*WaterPerBlock < buffer {
+					continue
+				}
+				if int64(layerBlocks*steamLayers)*SteamPerBlock < buffer {
+					continue
+				}
+
+				candidate := BoilerRecommendation{
+					Width:  width,
+					Height: height,
+
+					SuperheatingElements: superheaters,
+					PressureDispersers:   layerBlocks,
+
+					WaterCavityLayers: waterLayers,
+					SteamCavityLayers: steamLayers,
+
+					BoilCapacity:  int64(superheaters) * BoilCapacityPerSuperheater,
+					WaterCapacity: int64(waterBlocks) * WaterPerBlock,
+					SteamCapacity: int64(layerBlocks*steamLayers) * SteamPerBlock,
+				}
+				if !found || candidate.volume() < best.volume() {
+					best = candidate
+					found = true
+				}
+				// more water layers only grow the water cavity at the expense of steam
+				break
+			}
+		}
+	}
+
+	if !found {
+		return best, ErrBoilerTooBig
+	}
+	return best, nil
+}
+
+// water in and steam out
+const boilerValves = 2
+
+func (boiler BoilerRecommendation) BuildCost() build.Cost {
+	inner := int64(boiler.Width - 2)
+	shell := int64(boiler.volume()) - inner*inner*int64(boiler.Height-2)
+
+	return build.Cost{}.
+		Add("Boiler Casing", shell-boilerValves).
+		Add("Boiler Valve", boilerValves).
+		Add("Pressure Disperser", int64(boiler.PressureDispersers)).
+		Add("Superheating Element", int64(boiler.SuperheatingElements))
+}
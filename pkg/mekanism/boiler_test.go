@@ -0,0 +1,35 @@
+package mekanism
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecommendBoiler(t *testing.T) {
+	for _, steamFlow := range []int64{320, 10000, 48000, 200000} {
+		boiler, err := RecommendBoiler(steamFlow)
+		if err != nil {
+			t.Fatalf("%d mB/t: %v", steamFlow, err)
+		}
+
+		if boiler.BoilCapacity < steamFlow {
+			t.Errorf("%d mB/t: boil capacity only %d", steamFlow, boiler.BoilCapacity)
+		}
+		if boiler.WaterCapacity < steamFlow*bufferTicks || boiler.SteamCapacity < steamFlow*bufferTicks {
+			t.Errorf("%d mB/t: buffers too small, water %d steam %d", steamFlow, boiler.WaterCapacity, boiler.SteamCapacity)
+		}
+		inner := boiler.Width - 2
+		if boiler.SuperheatingElements >= inner*inner*boiler.WaterCavityLayers {
+			t.Errorf("%d mB/t: %d superheaters leave no room for water", steamFlow, boiler.SuperheatingElements)
+		}
+		if boiler.WaterCavityLayers+boiler.SteamCavityLayers+1 != boiler.Height-2 {
+			t.Errorf("%d mB/t: cavities don't add up to the interior height", steamFlow)
+		}
+	}
+}
+
+func TestRecommendBoilerTooBig(t *testing.T) {
+	if _, err := RecommendBoiler(10000000); !errors.Is(err, ErrBoilerTooBig) {
+		t.Errorf("err = %v, want ErrBoilerTooBig", err)
+	}
+}
@@ -0,0 +1,176 @@
+package mekanism
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// industrial turbine rules, Mekanism Generators 10 defaults
+const DisperserGasFlow int64 = 1280
+const VentGasFlow int64 = 32000
+const CondenserRate int64 = 64000
+const BladesPerCoil = 4
+const MaxBlades = 28
+const BladesPerRotor = 2
+
+// joules per mB of steam with all MaxBlades blades
+const MaxEnergyPerSteam float64 = 10
+const JoulesPerFE float64 = 2.5
+
+const GasPerBlock int64 = 64000
+const EnergyPerBlock int64 = 16000000
+
+// outer dimensions, including casing
+const turbineMinSize = 5
+const turbineMaxWidth = 17
+const turbineMaxHeight = 18
+
+// IndustrialTurbine is a square industrial turbine; from bottom to top the interior holds the rotor,
+// one layer of pressure dispersers around the rotational complex, then the coils and condensers
+type IndustrialTurbine struct {
+	// outer dimensions
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	Rotors     int `json:"rotors"`
+	Blades     int `json:"blades"`
+	Coils      int `json:"coils"`
+	Dispersers int `json:"dispersers"`
+	Vents      int `json:"vents"`
+	Condensers int `json:"condensers"`
+
+	// steam actually used, limited by the turbine's max flow
+	FlowRate    int64 `json:"flowRate"`
+	MaxFlowRate int64 `json:"maxFlowRate"`
+	// water returned by the condensers
+	WaterOutput int64 `json:"waterOutput"`
+
+	EnergyPerSteam  float64 `json:"energyPerSteam"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	// EnergyGenerated converted to FE
+	EnergyGeneratedFE float64 `json:"energyGeneratedFE"`
+
+	SteamCapacity  int64 `json:"steamCapacity"`
+	EnergyCapacity int64 `json:"energyCapacity"`
+}
+
+func (turbine IndustrialTurbine) volume() int {
+	return turbine.Width * turbine.Width * turbine.Height
+}
+
+func maxRotors(width int) int {
+	return min(2*width-5, MaxBlades/BladesPerRotor)
+}
+
+// NewIndustrialTurbine lays out a turbine with the given outer size and rotor height, sized for steamFlow mB/t.
+// Vents and condensers are only added as far as the flow needs them.
+func NewIndustrialTurbine(width, height, rotors int, steamFlow int64) (IndustrialTurbine, error) {
+	turbine := IndustrialTurbine{Width: width, Height: height, Rotors: rotors}
+
+	if width%2 == 0 {
+		return turbine, errors.New("Turbine width must be odd")
+	}
+	if width < turbineMinSize || height < turbineMinSize {
+		return turbine, errors.New("Turbine cannot be this small")
+	}
+	if width > turbineMaxWidth || height > turbineMaxHeight {
+		return turbine, errors.New("Turbine cannot be this big")
+	}
+	if rotors < 1 || rotors > maxRotors(width) {
+		return turbine, fmt.Errorf("Turbine %d wide can hold between 1 and %d rotors", width, maxRotors(width))
+	}
+
+	inner := width - 2
+	layerBlocks := inner * inner
+	// the disperser layer sits right above the rotor, what is left above it is the upper section
+	upperLayers := height - 2 - rotors - 1
+	if upperLayers < 1 {
+		return turbine, errors.New("Turbine needs room for coils above the rotor")
+	}
+
+	turbine.Blades = rotors * BladesPerRotor
+	turbine.Coils = (turbine.Blades + BladesPerCoil - 1) / BladesPerCoil
+	turbine.Dispersers = layerBlocks - 1
+
+	lowerVolume := int64(layerBlocks * rotors)
+	disperserFlow := lowerVolume * int64(turbine.Dispersers) * DisperserGasFlow
+
+	// vents go in the walls and ceiling around the upper section
+	maxVents := layerBlocks + 4*inner*upperLayers
+	neededVents := int((min(steamFlow, disperserFlow) + VentGasFlow - 1) / VentGasFlow)
+	turbine.Vents = max(1, min(maxVents, neededVents))
+
+	turbine.MaxFlowRate = min(disperserFlow, int64(turbine.Vents)*VentGasFlow)
+	turbine.FlowRate = min(steamFlow, turbine.MaxFlowRate)
+
+	// condensers share the upper section with the coils
+	freeUpper := layerBlocks*upperLayers - turbine.Coils
+	if freeUpper < 0 {
+		return turbine, errors.New("Turbine cannot hold that many coils")
+	}
+	neededCondensers := int((turbine.FlowRate + CondenserRate - 1) / CondenserRate)
+	turbine.Condensers = min(freeUpper, neededCondensers)
+	turbine.WaterOutput = min(turbine.FlowRate, int64(turbine.Condensers)*CondenserRate)
+
+	turbine.EnergyPerSteam = MaxEnergyPerSteam / MaxBlades * float64(min(turbine.Blades, turbine.Coils*BladesPerCoil))
+	turbine.EnergyGenerated = float64(turbine.FlowRate) * turbine.EnergyPerSteam
+	turbine.EnergyGeneratedFE = turbine.EnergyGenerated / JoulesPerFE
+
+	turbine.SteamCapacity = lowerVolume * GasPerBlock
+	turbine.EnergyCapacity = int64(turbine.volume()) * EnergyPerBlock
+
+	return turbine, nil
+}
+
+var ErrNoIndustrialTurbine = errors.New("No industrial turbine fits within max size")
+
+// OptimizeIndustrialTurbine finds the turbine within the max outer size making the most energy from steamFlow,
+// preferring the smaller one when two make the same
+func OptimizeIndustrialTurbine(maxWidth, maxHeight int, steamFlow int64) (IndustrialTurbine, error) {
+	var best IndustrialTurbine
+	found := false
+
+	for width := turbineMinSize; width <= min(maxWidth, turbineMaxWidth); width += 2 {
+		for height := turbineMinSize; height <= min(maxHeight, turbineMaxHeight); height++ {
+			for rotors := 1; rotors <= maxRotors(width); rotors++ {
+				turbine, err := NewIndustrialTurbine(width, height, rotors, steamFlow)
+				if err != nil {
+					continue
+				}
+
+				better := turbine.EnergyGenerated > best.EnergyGenerated ||
+					(turbine.EnergyGenerated == best.EnergyGenerated && turbine.volume() < best.volume())
+				if !found || better {
+					best = turbine
+					found = true
+				}
+			}
+		}
+	}
+
+	if !found {
+		return best, ErrNoIndustrialTurbine
+	}
+	return best, nil
+}
+
+// steam in and water out
+const turbineValves = 2
+
+func (turbine IndustrialTurbine) BuildCost() build.Cost {
+	inner := int64(turbine.Width - 2)
+	shell := int64(turbine.volume()) - inner*inner*int64(turbine.Height-2)
+
+	return build.Cost{}.
+		Add("Turbine Casing", shell-int64(turbine.Vents)-turbineValves).
+		Add("Turbine Valve", turbineValves).
+		Add("Turbine Vent", int64(turbine.Vents)).
+		Add("Turbine Rotor", int64(turbine.Rotors)).
+		Add("Turbine Blade", int64(turbine.Blades)).
+		Add("Rotational Complex", 1).
+		Add("Pressure Disperser", int64(turbine.Dispersers)).
+		Add("Electromagnetic Coil", int64(turbine.Coils)).
+		Add("Saturating Condenser", int64(turbine.Condensers))
+}
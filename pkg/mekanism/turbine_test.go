@@ -0,0 +1,66 @@
+package mekanism
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewIndustrialTurbine(t *testing.T) {
+	turbine, err := NewIndustrialTurbine(17, 18, 14, 200000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if turbine.Blades != MaxBlades || turbine.Coils != 7 {
+		t.Errorf("got %d blades and %d coils, want 28 and 7", turbine.Blades, turbine.Coils)
+	}
+	if turbine.EnergyPerSteam != MaxEnergyPerSteam {
+		t.Errorf("EnergyPerSteam = %.2f with all blades, want %.2f", turbine.EnergyPerSteam, MaxEnergyPerSteam)
+	}
+	if turbine.FlowRate != 200000 || turbine.Vents != 7 {
+		t.Errorf("flow %d with %d vents, want 200000 with 7", turbine.FlowRate, turbine.Vents)
+	}
+	if turbine.WaterOutput != turbine.FlowRate {
+		t.Errorf("condensers return %d of %d mB/t", turbine.WaterOutput, turbine.FlowRate)
+	}
+	if turbine.EnergyGeneratedFE != turbine.EnergyGenerated/JoulesPerFE {
+		t.Error("FE conversion is off")
+	}
+}
+
+func TestNewIndustrialTurbineValidation(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height, rotors int
+	}{
+		{"even width", 6, 10, 2},
+		{"too small", 3, 10, 1},
+		{"too wide", 19, 10, 2},
+		{"too tall", 9, 19, 2},
+		{"too many rotors for width", 5, 18, 6},
+		{"no room above rotor", 9, 8, 5},
+	}
+
+	for _, tc := range tests {
+		if _, err := NewIndustrialTurbine(tc.width, tc.height, tc.rotors, 10000); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestOptimizeIndustrialTurbine(t *testing.T) {
+	best, err := OptimizeIndustrialTurbine(11, 12, 50000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best.Width > 11 || best.Height > 12 {
+		t.Errorf("%dx%d exceeds the max size", best.Width, best.Height)
+	}
+	if best.FlowRate != 50000 {
+		t.Errorf("only uses %d of the 50000 mB/t", best.FlowRate)
+	}
+
+	if _, err := OptimizeIndustrialTurbine(3, 3, 50000); !errors.Is(err, ErrNoIndustrialTurbine) {
+		t.Errorf("err = %v, want ErrNoIndustrialTurbine", err)
+	}
+}
@@ -0,0 +1,173 @@
+// Package presets keeps named calculator inputs and pinned results, grouped by an owner key the user picks
+// so the same presets can be opened from another device. Anyone can read an owner's presets, changing them
+// takes the token the owner's first save was made with.
+package presets
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type Preset struct {
+	Name string `json:"name"`
+	// whatever the site sends, stored as is
+	Inputs json.RawMessage `json:"inputs"`
+	Pinned json.RawMessage `json:"pinned,omitempty"`
+
+	Updated time.Time `json:"updated"`
+}
+
+var ErrNotFound = errors.New("Preset not found")
+
+var ErrForbidden = errors.New("The owner token doesn't match")
+
+// ErrInvalid matches the errors for an owner, name, token or inputs the store won't take, as opposed to the
+// store failing
+var ErrInvalid = errors.New("Invalid preset")
+
+const maxNameLength = 100
+
+// each owner gets a bucket inside this one, keyed by preset name
+var presetsBucket = []byte("presets")
+
+// the sha256 of each owner's token, keyed by owner
+var ownersBucket = []byte("owners")
+
+type Store struct {
+	db *bolt.DB
+}
+
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{presetsBucket, ownersBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db}, nil
+}
+
+func (store *Store) Close() error {
+	return store.db.Close()
+}
+
+func validName(kind, name string) error {
+	if name == "" || len(name) > maxNameLength {
+		return fmt.Errorf("%w: %s has to be between 1 and %d characters", ErrInvalid, kind, maxNameLength)
+	}
+	return nil
+}
+
+// checkOwner lets the write through if token is the owner's, an owner without a token yet is claimed with it
+func checkOwner(tx *bolt.Tx, owner, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: changing presets needs the owner's token", ErrInvalid)
+	}
+	owners := tx.Bucket(ownersBucket)
+	sum := sha256.Sum256([]byte(token))
+	stored := owners.Get([]byte(owner))
+	if stored == nil {
+		return owners.Put([]byte(owner), sum[:])
+	}
+	if subtle.ConstantTimeCompare(stored, sum[:]) != 1 {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Save adds the preset or replaces the one with the same name, token has to be the owner's
+func (store *Store) Save(owner, token string, preset Preset) error {
+	if err := validName("the owner", owner); err != nil {
+		return err
+	}
+	if err := validName("the name", preset.Name); err != nil {
+		return err
+	}
+	if len(preset.Inputs) == 0 || !json.Valid(preset.Inputs) {
+		return fmt.Errorf("%w: the inputs have to be json", ErrInvalid)
+	}
+
+	preset.Updated = time.Now().UTC()
+	encoded, err := json.Marshal(preset)
+	if err != nil {
+		return err
+	}
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		if err := checkOwner(tx, owner, token); err != nil {
+			return err
+		}
+		bucket, err := tx.Bucket(presetsBucket).CreateBucketIfNotExists([]byte(owner))
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(preset.Name), encoded)
+	})
+}
+
+func (store *Store) Load(owner, name string) (Preset, error) {
+	var preset Preset
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(presetsBucket).Bucket([]byte(owner))
+		if bucket == nil {
+			return ErrNotFound
+		}
+		encoded := bucket.Get([]byte(name))
+		if encoded == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(encoded, &preset)
+	})
+	return preset, err
+}
+
+// List returns the owner's presets sorted by name
+func (store *Store) List(owner string) ([]Preset, error) {
+	presets := []Preset{}
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(presetsBucket).Bucket([]byte(owner))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, encoded []byte) error {
+			var preset Preset
+			if err := json.Unmarshal(encoded, &preset); err != nil {
+				return err
+			}
+			presets = append(presets, preset)
+			return nil
+		})
+	})
+	return presets, err
+}
+
+// Delete removes the preset, token has to be the owner's
+func (store *Store) Delete(owner, token, name string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		if err := checkOwner(tx, owner, token); err != nil {
+			return err
+		}
+		bucket := tx.Bucket(presetsBucket).Bucket([]byte(owner))
+		if bucket == nil || bucket.Get([]byte(name)) == nil {
+			return ErrNotFound
+		}
+		return bucket.Delete([]byte(name))
+	})
+}
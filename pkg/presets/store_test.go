@@ -0,0 +1,110 @@
+package presets
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "presets.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSaveLoadList(t *testing.T) {
+	store := openTestStore(t)
+
+	inputs := json.RawMessage(`{"maxWidth":9,"maxHeight":12,"coil":"Enderium"}`)
+	for _, name := range []string{"small", "big"} {
+		if err := store.Save("alice", "alice-token", Preset{Name: name, Inputs: inputs}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	preset, err := store.Load("alice", "small")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(preset.Inputs) != string(inputs) || preset.Updated.IsZero() {
+		t.Errorf("loaded %+v", preset)
+	}
+
+	presets, err := store.List("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(presets) != 2 || presets[0].Name != "big" || presets[1].Name != "small" {
+		t.Errorf("listed %+v", presets)
+	}
+
+	// owners don't see each other's presets
+	if _, err := store.Load("bob", "small"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("bob loaded alice's preset: %v", err)
+	}
+	if presets, _ := store.List("bob"); len(presets) != 0 {
+		t.Errorf("bob listed %+v", presets)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	store := openTestStore(t)
+
+	if err := store.Save("alice", "alice-token", Preset{Name: "small", Inputs: json.RawMessage(`{}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("alice", "alice-token", "small"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("alice", "alice-token", "small"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second delete: %v", err)
+	}
+}
+
+func TestSaveRejectsBadInput(t *testing.T) {
+	store := openTestStore(t)
+
+	presets := []struct {
+		owner  string
+		preset Preset
+	}{
+		{"", Preset{Name: "small", Inputs: json.RawMessage(`{}`)}},
+		{"alice", Preset{Name: "", Inputs: json.RawMessage(`{}`)}},
+		{"alice", Preset{Name: "small", Inputs: json.RawMessage(`{nope`)}},
+	}
+	for _, tc := range presets {
+		if err := store.Save(tc.owner, "token", tc.preset); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q %+v: expected an invalid preset, got %v", tc.owner, tc.preset, err)
+		}
+	}
+}
+
+func TestOwnerToken(t *testing.T) {
+	store := openTestStore(t)
+	preset := Preset{Name: "small", Inputs: json.RawMessage(`{}`)}
+
+	if err := store.Save("alice", "", preset); !errors.Is(err, ErrInvalid) {
+		t.Errorf("save without a token: %v", err)
+	}
+	// the first save claims the owner
+	if err := store.Save("alice", "alice-token", preset); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("alice", "mallory-token", preset); !errors.Is(err, ErrForbidden) {
+		t.Errorf("save with another token: %v", err)
+	}
+	if err := store.Delete("alice", "mallory-token", "small"); !errors.Is(err, ErrForbidden) {
+		t.Errorf("delete with another token: %v", err)
+	}
+	if _, err := store.Load("alice", "small"); err != nil {
+		t.Errorf("preset is gone after a forbidden delete: %v", err)
+	}
+	if err := store.Delete("alice", "alice-token", "small"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,76 @@
+package share
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// permalinkVersion leads every permalink so the layout can change without breaking old links
+const permalinkVersion = "1"
+
+// links have to fit in a chat message
+const maxNameLength = 64
+const maxNotesLength = 500
+
+// Permalink is a design with the name and notes its owner gave it
+type Permalink struct {
+	turbine.Design
+	// coils on the outer ring of the top coil layer, nil when the ring is full
+	OuterRingCoils *int64 `json:"outerRingCoils,omitempty"`
+	// profile the design was made with, empty for the default profile
+	Profile string `json:"profile,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// Validate checks the name and notes, the design itself is checked when it's built
+func (permalink Permalink) Validate() error {
+	if length := utf8.RuneCountInString(permalink.Name); length > maxNameLength {
+		return turbine.ValidationError{Field: "name", Message: fmt.Sprintf("Name is %d characters long, it can have at most %d", length, maxNameLength)}
+	}
+	if length := utf8.RuneCountInString(permalink.Notes); length > maxNotesLength {
+		return turbine.ValidationError{Field: "notes", Message: fmt.Sprintf("Notes are %d characters long, they can have at most %d", length, maxNotesLength)}
+	}
+	return nil
+}
+
+// Encode writes the permalink as url safe text, like "1.eyJ3aWR0aCI6..."
+func (permalink Permalink) Encode() (string, error) {
+	if err := permalink.Validate(); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(permalink)
+	if err != nil {
+		return "", err
+	}
+	return permalinkVersion + "." + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodePermalink reads what Encode wrote
+func DecodePermalink(text string) (Permalink, error) {
+	version, encoded, ok := strings.Cut(text, ".")
+	if !ok {
+		return Permalink{}, fmt.Errorf("%q is not a permalink", text)
+	}
+	if version != permalinkVersion {
+		return Permalink{}, fmt.Errorf("Permalink version %q is not supported", version)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Permalink{}, fmt.Errorf("Permalink is damaged: %w", err)
+	}
+
+	var permalink Permalink
+	if err := json.Unmarshal(data, &permalink); err != nil {
+		return Permalink{}, fmt.Errorf("Permalink is damaged: %w", err)
+	}
+	if err := permalink.Validate(); err != nil {
+		return Permalink{}, err
+	}
+	return permalink, nil
+}
@@ -0,0 +1,72 @@
+package share
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func TestPermalinkRoundTrip(t *testing.T) {
+	emptyRing := int64(0)
+	permalinks := []Permalink{
+		{Design: turbine.Design{Width: 9, Height: 14, CoilLayers: 3, Coil: "Enderium", FlowRate: 2000}},
+		{
+			Design:  turbine.Design{Width: 7, Height: 10, CoilLayers: 2, Coil: "Copper", Blade: "Basic", Fluid: "Steam", FlowRate: 900},
+			Profile: "bigger-reactors",
+			Name:    "Base turbine #2 ⚡",
+			Notes:   "Fed by the 5x5 reactor.\nKeep the coils engaged.",
+		},
+		{Design: turbine.Design{Width: 9, Height: 14, CoilLayers: 3, Coil: "Enderium", FlowRate: 2000}, OuterRingCoils: &emptyRing},
+	}
+
+	for _, permalink := range permalinks {
+		text, err := permalink.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(text, "1.") || strings.ContainsAny(text, "+/=?&# ") {
+			t.Errorf("%q is not url safe", text)
+		}
+		decoded, err := DecodePermalink(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(decoded, permalink) {
+			t.Errorf("DecodePermalink(Encode()) = %+v, want %+v", decoded, permalink)
+		}
+	}
+}
+
+func TestPermalinkLimits(t *testing.T) {
+	tests := []struct {
+		permalink Permalink
+		field     string
+	}{
+		{Permalink{Name: strings.Repeat("a", maxNameLength+1)}, "name"},
+		{Permalink{Notes: strings.Repeat("ü", maxNotesLength+1)}, "notes"},
+	}
+
+	for _, tc := range tests {
+		_, err := tc.permalink.Encode()
+		var validationErr turbine.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != tc.field {
+			t.Errorf("Encode() error = %v, want a ValidationError on %s", err, tc.field)
+		}
+	}
+
+	// at the limit in characters, not bytes
+	if _, err := (Permalink{Name: strings.Repeat("ü", maxNameLength)}).Encode(); err != nil {
+		t.Errorf("a %d character name failed: %v", maxNameLength, err)
+	}
+}
+
+func TestDecodePermalinkErrors(t *testing.T) {
+	for _, text := range []string{"", "eyJ3aWR0aCI6OX0", "2.eyJ3aWR0aCI6OX0", "1.not*base64", "1.bm90IGpzb24"} {
+		if _, err := DecodePermalink(text); err == nil {
+			t.Errorf("DecodePermalink(%q) should fail", text)
+		}
+	}
+}
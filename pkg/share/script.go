@@ -0,0 +1,131 @@
+package share
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+// Computer is the computer mod a control script is written for
+type Computer int64
+
+const (
+	ComputerCraft Computer = iota
+	OpenComputers
+)
+
+func ParseComputer(name string) (Computer, error) {
+	switch strings.ToLower(name) {
+	case "computercraft", "cc":
+		return ComputerCraft, nil
+	case "opencomputers", "oc":
+		return OpenComputers, nil
+	default:
+		return ComputerCraft, fmt.Errorf("Unknown computer mod %q", name)
+	}
+}
+
+// the script stops charging when the internal battery is this full and starts again once it drains to the low mark
+const (
+	scriptBatteryHigh = 0.95
+	scriptBatteryLow  = 0.5
+)
+
+// ControlScript holds the setpoints of a Lua program that runs a turbine from a computer next to it
+type ControlScript struct {
+	Title        string
+	FlowRate     int64
+	TargetRPM    float64
+	EngageRPM    float64
+	DisengageRPM float64
+	// the flow rate while the coils are off and the rotor spins back up
+	RecoveryFlowRate int64
+	BatteryCapacity  float64
+	// the steam is cut above this rpm, zero when the config has no max safe rpm
+	MaxRPM float64
+}
+
+// NewControlScript takes the setpoints from a result's coil thresholds, coil is the coil material name
+func NewControlScript(result turbine.Result, coil string) ControlScript {
+	script := ControlScript{
+		Title:            title(result.Stats, coil),
+		FlowRate:         result.FlowRate,
+		TargetRPM:        result.RPM,
+		EngageRPM:        result.Thresholds.EngageRPM,
+		DisengageRPM:     result.Thresholds.DisengageRPM,
+		RecoveryFlowRate: result.Thresholds.RecoveryFlowRate,
+		BatteryCapacity:  result.Storage.BatteryCapacity,
+	}
+	if result.Overspeed != nil {
+		script.MaxRPM = result.Overspeed.MaxSafeRPM
+	}
+	return script
+}
+
+// scriptBody runs the turbine once the setpoints and the turbine variable are defined. It uses the Extreme
+// Reactors method names, Bigger Reactors keeps them for old scripts.
+const scriptBody = `
+turbine.setActive(true)
+
+local coils = false
+local charging = true
+while true do
+  local rpm = turbine.getRotorSpeed()
+  local stored = turbine.getEnergyStored()
+
+  if stored >= BATTERY_CAPACITY * BATTERY_HIGH then
+    charging = false
+  elseif stored <= BATTERY_CAPACITY * BATTERY_LOW then
+    charging = true
+  end
+  if rpm >= ENGAGE_RPM then
+    coils = true
+  elseif rpm < DISENGAGE_RPM then
+    coils = false
+  end
+
+  turbine.setActive(charging and (MAX_RPM == nil or rpm < MAX_RPM))
+  turbine.setInductorEngaged(coils and charging)
+  if coils then
+    turbine.setFluidFlowRateMax(FLOW_RATE)
+  else
+    turbine.setFluidFlowRateMax(RECOVERY_FLOW_RATE)
+  end
+  os.sleep(0.5)
+end
+`
+
+// Lua writes the script for the computer mod
+func (script ControlScript) Lua(computer Computer) (string, error) {
+	var text strings.Builder
+	// a line break in the name would end the comment
+	fmt.Fprintf(&text, "-- %s\n", strings.Join(strings.Fields(script.Title), " "))
+	fmt.Fprintf(&text, "local FLOW_RATE = %d -- mB/t\n", script.FlowRate)
+	fmt.Fprintf(&text, "local TARGET_RPM = %.0f -- where the rotor settles at FLOW_RATE\n", script.TargetRPM)
+	fmt.Fprintf(&text, "local ENGAGE_RPM = %.0f -- coils on once the rotor is this fast\n", script.EngageRPM)
+	fmt.Fprintf(&text, "local DISENGAGE_RPM = %.0f -- coils off below this so the rotor can recover\n", script.DisengageRPM)
+	fmt.Fprintf(&text, "local RECOVERY_FLOW_RATE = %d -- mB/t while the coils are off\n", script.RecoveryFlowRate)
+	if script.MaxRPM > 0 {
+		fmt.Fprintf(&text, "local MAX_RPM = %.0f -- steam off above this\n", script.MaxRPM)
+	} else {
+		text.WriteString("local MAX_RPM = nil\n")
+	}
+	fmt.Fprintf(&text, "local BATTERY_CAPACITY = %.0f -- RF\n", script.BatteryCapacity)
+	fmt.Fprintf(&text, "local BATTERY_HIGH = %g\n", scriptBatteryHigh)
+	fmt.Fprintf(&text, "local BATTERY_LOW = %g\n\n", scriptBatteryLow)
+
+	switch computer {
+	case ComputerCraft:
+		text.WriteString(`local turbine = peripheral.find("BigReactors-Turbine")` + "\n")
+		text.WriteString(`if not turbine then error("No turbine attached") end` + "\n")
+	case OpenComputers:
+		text.WriteString(`local component = require("component")` + "\n")
+		text.WriteString(`if not component.isAvailable("br_turbine") then error("No turbine attached") end` + "\n")
+		text.WriteString("local turbine = component.br_turbine\n")
+	default:
+		return "", fmt.Errorf("Unknown computer mod %d", computer)
+	}
+	text.WriteString(scriptBody)
+	return text.String(), nil
+}
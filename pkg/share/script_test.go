@@ -0,0 +1,64 @@
+package share
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func TestParseComputer(t *testing.T) {
+	for name, want := range map[string]Computer{"computercraft": ComputerCraft, "CC": ComputerCraft, "OpenComputers": OpenComputers, "oc": OpenComputers} {
+		if got, err := ParseComputer(name); err != nil || got != want {
+			t.Errorf("ParseComputer(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseComputer("redstone"); err == nil {
+		t.Error("ParseComputer(\"redstone\") should fail")
+	}
+}
+
+func TestControlScript(t *testing.T) {
+	result := turbine.Result{
+		Stats:     turbine.Stats{Width: 9, Height: 14, RPM: 1800, FlowRate: 2000},
+		Storage:   turbine.StorageRecommendation{BatteryCapacity: 1000000},
+		Overspeed: &turbine.OverspeedReport{MaxSafeRPM: 2000},
+		Thresholds: turbine.CoilThresholds{
+			LoadedRPM: 1800, NoLoadRPM: 2400, EngageRPM: 1800, DisengageRPM: 1620, RecoveryFlowRate: 1500, Recoverable: true,
+		},
+	}
+	script := NewControlScript(result, "Enderium")
+
+	text, err := script.Lua(ComputerCraft)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"-- 9x9x14 Enderium turbine\n",
+		"local FLOW_RATE = 2000 -- mB/t\n",
+		"local ENGAGE_RPM = 1800 ",
+		"local DISENGAGE_RPM = 1620 ",
+		"local RECOVERY_FLOW_RATE = 1500 ",
+		"local MAX_RPM = 2000 ",
+		"local BATTERY_CAPACITY = 1000000 ",
+		`peripheral.find("BigReactors-Turbine")`,
+		"turbine.setFluidFlowRateMax(FLOW_RATE)\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("script is missing %q:\n%s", line, text)
+		}
+	}
+
+	result.Overspeed = nil
+	script = NewControlScript(result, "Enderium")
+	script.Title = "Main\nturbine"
+	text, err = script.Lua(OpenComputers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"-- Main turbine\n", "local MAX_RPM = nil\n", "component.br_turbine"} {
+		if !strings.Contains(text, line) {
+			t.Errorf("script is missing %q:\n%s", line, text)
+		}
+	}
+}
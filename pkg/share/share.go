@@ -0,0 +1,267 @@
+// Package share writes a turbine's stats and build cost as text to paste elsewhere, a CSV for spreadsheets,
+// Markdown or Reddit tables and BBCode for old forums
+package share
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/format"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+type Format int64
+
+const (
+	CSV Format = iota
+	Markdown
+	// Markdown with Reddit's heading and its extra characters escaped
+	Reddit
+	BBCode
+)
+
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return CSV, nil
+	case "markdown", "md":
+		return Markdown, nil
+	case "reddit":
+		return Reddit, nil
+	case "bbcode":
+		return BBCode, nil
+	default:
+		return CSV, fmt.Errorf("Unknown export format %q", name)
+	}
+}
+
+// Row is one stat, either text or a number with its unit
+type Row struct {
+	Name string
+	Text string
+	// the number, rounded to Decimals when written for people, spreadsheets get all of it
+	Value    float64
+	Decimals int
+	Unit     string
+}
+
+// Sheet is what gets exported, rows already in the units they are shown in
+type Sheet struct {
+	Title     string
+	Stats     []Row
+	BuildCost build.Cost
+	// what the owner called the design and wrote about it, the name replaces the title
+	Name  string
+	Notes string
+}
+
+func (sheet Sheet) heading() string {
+	if sheet.Name != "" {
+		return sheet.Name
+	}
+	return sheet.Title
+}
+
+// NewSheet picks the stats the site shows for a result, coil is the coil material name
+func NewSheet(result turbine.Result, coil string, cost build.Cost, system format.UnitSystem) Sheet {
+	stats := result.Stats
+	size := fmt.Sprintf("%dx%dx%d", stats.Width, stats.Width, stats.Height)
+	number := func(name string, value float64, decimals int, unit string) Row {
+		return Row{Name: name, Value: value, Decimals: decimals, Unit: unit}
+	}
+	quantity := func(name string, value float64, decimals int, quantity format.Quantity) Row {
+		scale := system.Scale(quantity)
+		// B keeps the mB digits
+		if scale < 1 {
+			decimals += int(math.Round(-math.Log10(scale)))
+		}
+		return number(name, value*scale, decimals, system.Unit(quantity).Symbol)
+	}
+
+	rows := []Row{
+		{Name: "Size", Text: size},
+		{Name: "Coil", Text: coil},
+		number("Coil layers", float64(stats.CoilLayers), 0, ""),
+		number("Coils", float64(stats.CoilSize), 0, ""),
+	}
+	if stats.Blade != "" {
+		rows = append(rows, Row{Name: "Blade", Text: stats.Blade})
+	}
+	rows = append(rows,
+		Row{Name: "Fluid", Text: stats.Fluid},
+		quantity("Flow rate", float64(stats.FlowRate), 0, format.FluidRate),
+		number("Rotor speed", stats.RPM, 0, "RPM"),
+		quantity("Output", stats.EnergyGenerated, 0, format.EnergyRate),
+		quantity("Energy per fluid", result.EnergyPerSteam, 2, format.EnergyPerFluid),
+		number("Rotor efficiency", stats.RotorEfficiency*100, 1, "%"),
+		number("Coil efficiency", stats.CoilEfficiency*100, 1, "%"),
+	)
+
+	return Sheet{Title: title(stats, coil), Stats: rows, BuildCost: cost}
+}
+
+// title names a turbine by its size and coil, like "9x9x14 Enderium turbine"
+func title(stats turbine.Stats, coil string) string {
+	size := fmt.Sprintf("%dx%dx%d", stats.Width, stats.Width, stats.Height)
+	if coil == "" {
+		return size + " turbine"
+	}
+	return fmt.Sprintf("%s %s turbine", size, coil)
+}
+
+// Text writes the sheet in the format, numbers for people follow the locale
+func (sheet Sheet) Text(exportFormat Format, locale format.Locale) (string, error) {
+	switch exportFormat {
+	case CSV:
+		return sheet.csv()
+	case Markdown:
+		return sheet.markdown(locale, fmt.Sprintf("**%s**", escapeMarkdown(sheet.heading())), escapeMarkdown), nil
+	case Reddit:
+		return sheet.markdown(locale, "### "+escapeReddit(sheet.heading()), escapeReddit), nil
+	case BBCode:
+		return sheet.bbcode(locale), nil
+	default:
+		return "", fmt.Errorf("Unknown export format %d", exportFormat)
+	}
+}
+
+// csv has a stat, value, unit table and a block, count table with an empty line between,
+// the values are plain numbers so spreadsheets read them whatever their locale
+func (sheet Sheet) csv() (string, error) {
+	var text strings.Builder
+	writer := csv.NewWriter(&text)
+	records := [][]string{{"Stat", "Value", "Unit"}}
+	if sheet.Name != "" {
+		records = append(records, []string{"Name", sheet.Name, ""})
+	}
+	if sheet.Notes != "" {
+		records = append(records, []string{"Notes", sheet.Notes, ""})
+	}
+	for _, row := range sheet.Stats {
+		value := row.Text
+		if value == "" {
+			value = strconv.FormatFloat(row.Value, 'f', -1, 64)
+		}
+		records = append(records, []string{row.Name, value, row.Unit})
+	}
+	records = append(records, []string{}, []string{"Block", "Count"})
+	for _, item := range sheet.BuildCost {
+		records = append(records, []string{item.Name, strconv.FormatInt(item.Count, 10)})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return "", err
+	}
+	return text.String(), nil
+}
+
+// table has a left aligned first column, the others hold numbers and are right aligned
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// tables are the stats and, if there is one, the build cost as people read them
+func (sheet Sheet) tables(locale format.Locale) []table {
+	stats := table{header: []string{"Stat", "Value"}}
+	for _, row := range sheet.Stats {
+		stats.rows = append(stats.rows, []string{row.Name, row.text(locale)})
+	}
+	tables := []table{stats}
+
+	if len(sheet.BuildCost) > 0 {
+		blocks := table{header: []string{"Block", "Count", "Stacks"}}
+		for _, item := range sheet.BuildCost {
+			blocks.rows = append(blocks.rows, []string{item.Name, locale.Number(float64(item.Count), 0), item.StackText()})
+		}
+		tables = append(tables, blocks)
+	}
+	return tables
+}
+
+func (sheet Sheet) markdown(locale format.Locale, heading string, escape func(string) string) string {
+	var text strings.Builder
+	text.WriteString(heading + "\n")
+	if sheet.Notes != "" {
+		// a line break inside a paragraph needs two trailing spaces
+		text.WriteString("\n" + strings.ReplaceAll(escape(sheet.Notes), "\n", "  \n") + "\n")
+	}
+	for _, table := range sheet.tables(locale) {
+		text.WriteString("\n")
+		writeMarkdownRow(&text, table.header, escape)
+		alignment := []string{":--"}
+		for range table.header[1:] {
+			alignment = append(alignment, "--:")
+		}
+		writeMarkdownRow(&text, alignment, func(cell string) string { return cell })
+		for _, row := range table.rows {
+			writeMarkdownRow(&text, row, escape)
+		}
+	}
+	return text.String()
+}
+
+func writeMarkdownRow(text *strings.Builder, cells []string, escape func(string) string) {
+	for _, cell := range cells {
+		text.WriteString("| " + escape(cell) + " ")
+	}
+	text.WriteString("|\n")
+}
+
+func (sheet Sheet) bbcode(locale format.Locale) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "[b]%s[/b]\n", sheet.heading())
+	if sheet.Notes != "" {
+		fmt.Fprintf(&text, "[i]%s[/i]\n", sheet.Notes)
+	}
+	for _, table := range sheet.tables(locale) {
+		text.WriteString("[table]\n[tr]")
+		for _, cell := range table.header {
+			fmt.Fprintf(&text, "[th]%s[/th]", cell)
+		}
+		text.WriteString("[/tr]\n")
+		for _, row := range table.rows {
+			text.WriteString("[tr]")
+			for i, cell := range row {
+				if i > 0 {
+					cell = "[right]" + cell + "[/right]"
+				}
+				fmt.Fprintf(&text, "[td]%s[/td]", cell)
+			}
+			text.WriteString("[/tr]\n")
+		}
+		text.WriteString("[/table]\n")
+	}
+	return text.String()
+}
+
+// text is the value as people read it, like "40,100 RF/t"
+func (row Row) text(locale format.Locale) string {
+	if row.Text != "" {
+		return row.Text
+	}
+	text := locale.Number(row.Value, row.Decimals)
+	switch row.Unit {
+	case "":
+	case "%":
+		text += "%"
+	default:
+		text += " " + row.Unit
+	}
+	return text
+}
+
+// a pipe would end the table cell
+func escapeMarkdown(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// Reddit also reads ^ as superscript and ~~ as strikethrough
+var redditEscaper = strings.NewReplacer("|", `\|`, "^", `\^`, "~", `\~`, "*", `\*`)
+
+func escapeReddit(text string) string {
+	return redditEscaper.Replace(text)
+}
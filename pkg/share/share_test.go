@@ -0,0 +1,153 @@
+package share
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+	"github.com/drabart/turbine-calculator-website/pkg/format"
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func testSheet(system format.UnitSystem) Sheet {
+	result := turbine.Result{
+		Stats: turbine.Stats{
+			Width: 9, Height: 14, RPM: 1799.6, CoilSize: 40, CoilLayers: 3,
+			FlowRate: 2000, Fluid: "Steam", EnergyGenerated: 40123.4, RotorEfficiency: 0.987, CoilEfficiency: 0.5,
+		},
+		EnergyPerSteam: 20.0617,
+	}
+	cost := build.Cost{}.Add("Turbine Casing", 300).Add("Enderium Block", 40)
+	return NewSheet(result, "Enderium", cost, system)
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"csv": CSV, "CSV": CSV, "markdown": Markdown, "md": Markdown, "Reddit": Reddit, "bbcode": BBCode} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xlsx"); err == nil {
+		t.Error("ParseFormat(\"xlsx\") should fail")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	en, _ := format.LocaleByName("en")
+	text, err := testSheet(format.DefaultUnits).Text(CSV, en)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"Stat,Value,Unit\n",
+		"Size,9x9x14,\n",
+		"Output,40123.4,RF/t\n",
+		"Flow rate,2000,mB/t\n",
+		"\nBlock,Count\n",
+		"Turbine Casing,300\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("CSV is missing %q:\n%s", line, text)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	de, _ := format.LocaleByName("de")
+	system, _ := format.ParseUnitSystem("FE", "B")
+	text, err := testSheet(system).Text(Markdown, de)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"**9x9x14 Enderium turbine**\n",
+		"| Output | 40.123 FE/t |\n",
+		"| Flow rate | 2,000 B/t |\n",
+		"| Rotor efficiency | 98,7% |\n",
+		"| Turbine Casing | 300 | 4 stacks + 44 |\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("Markdown is missing %q:\n%s", line, text)
+		}
+	}
+}
+
+func TestMarkdownEscapesPipes(t *testing.T) {
+	en, _ := format.LocaleByName("en")
+	sheet := Sheet{Title: "Test", Stats: []Row{{Name: "Coil", Text: "a|b"}}}
+	text, _ := sheet.Text(Markdown, en)
+	if !strings.Contains(text, `| Coil | a\|b |`) {
+		t.Errorf("pipe was not escaped:\n%s", text)
+	}
+}
+
+func TestReddit(t *testing.T) {
+	en, _ := format.LocaleByName("en")
+	sheet := testSheet(format.DefaultUnits)
+	sheet.Stats = append(sheet.Stats, Row{Name: "Note", Text: "2^10 ~~fast~~"})
+	text, err := sheet.Text(Reddit, en)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"### 9x9x14 Enderium turbine\n\n| Stat | Value |\n| :-- | --: |\n",
+		"| Output | 40,123 RF/t |\n",
+		`| Note | 2\^10 \~\~fast\~\~ |`,
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("Reddit table is missing %q:\n%s", line, text)
+		}
+	}
+}
+
+func TestBBCode(t *testing.T) {
+	en, _ := format.LocaleByName("en")
+	text, err := testSheet(format.DefaultUnits).Text(BBCode, en)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"[b]9x9x14 Enderium turbine[/b]\n[table]\n[tr][th]Stat[/th][th]Value[/th][/tr]\n",
+		"[tr][td]Output[/td][td][right]40,123 RF/t[/right][/td][/tr]\n",
+		"[tr][td]Turbine Casing[/td][td][right]300[/right][/td][td][right]4 stacks + 44[/right][/td][/tr]\n",
+	} {
+		if !strings.Contains(text, line) {
+			t.Errorf("BBCode is missing %q:\n%s", line, text)
+		}
+	}
+	if strings.Count(text, "[table]") != 2 || strings.Count(text, "[/table]") != 2 {
+		t.Errorf("want a stats and a build cost table:\n%s", text)
+	}
+}
+
+func TestNameAndNotes(t *testing.T) {
+	en, _ := format.LocaleByName("en")
+	sheet := testSheet(format.DefaultUnits)
+	sheet.Name = "Main | base"
+	sheet.Notes = "Line one\nline two"
+
+	tests := []struct {
+		format Format
+		want   []string
+	}{
+		{CSV, []string{"Stat,Value,Unit\nName,Main | base,\nNotes,\"Line one\nline two\",\n"}},
+		{Markdown, []string{"**Main \\| base**\n\nLine one  \nline two\n\n| Stat |", "| Size | 9x9x14 |"}},
+		{Reddit, []string{"### Main \\| base\n"}},
+		{BBCode, []string{"[b]Main | base[/b]\n[i]Line one\nline two[/i]\n[table]"}},
+	}
+	for _, tc := range tests {
+		text, err := sheet.Text(tc.format, en)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(text, want) {
+				t.Errorf("format %d is missing %q:\n%s", tc.format, want, text)
+			}
+		}
+	}
+}
@@ -0,0 +1,354 @@
+// Package toml reads the part of TOML mod config files use: tables, dotted keys, strings, numbers, booleans,
+// arrays and inline tables. Dates and arrays of tables are turned down, no reactor config has them.
+package toml
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Parse decodes a document into nested maps, integers come out as int64 and other numbers as float64
+func Parse(text string) (map[string]any, error) {
+	p := &parser{text: text, line: 1}
+	root := map[string]any{}
+	// headers seen so far, defining a table twice is an error
+	defined := map[string]bool{}
+	current := root
+
+	for {
+		p.skipBlank()
+		if p.done() {
+			return root, nil
+		}
+
+		if p.peek() == '[' {
+			if strings.HasPrefix(p.text[p.pos:], "[[") {
+				return nil, p.errorf("Arrays of tables aren't supported")
+			}
+			p.pos++
+			p.skipSpaces()
+			keys, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpaces()
+			if p.done() || p.peek() != ']' {
+				return nil, p.errorf("Expected ] after the table name")
+			}
+			p.pos++
+			table, err := descend(root, keys)
+			if err != nil {
+				return nil, p.errorf("%v", err)
+			}
+			name := strings.Join(keys, ".")
+			if defined[name] {
+				return nil, p.errorf("Table %s is defined twice", name)
+			}
+			defined[name] = true
+			current = table
+		} else if err := p.keyValue(current); err != nil {
+			return nil, err
+		}
+
+		if err := p.endOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type parser struct {
+	text string
+	pos  int
+	line int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("Line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) done() bool { return p.pos >= len(p.text) }
+func (p *parser) peek() byte { return p.text[p.pos] }
+
+func (p *parser) skipSpaces() {
+	for !p.done() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments between statements and array items
+func (p *parser) skipBlank() {
+	for !p.done() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+			p.pos++
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) skipComment() {
+	for !p.done() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+func (p *parser) endOfLine() error {
+	p.skipSpaces()
+	if !p.done() && p.peek() == '#' {
+		p.skipComment()
+	}
+	if !p.done() && p.peek() == '\r' {
+		p.pos++
+	}
+	if p.done() {
+		return nil
+	}
+	if p.peek() != '\n' {
+		return p.errorf("Unexpected %q after a value", p.peek())
+	}
+	return nil
+}
+
+// descend finds or makes the table keys lead to
+func descend(table map[string]any, keys []string) (map[string]any, error) {
+	for _, key := range keys {
+		next, ok := table[key]
+		if !ok {
+			child := map[string]any{}
+			table[key] = child
+			table = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("Key %s is already a value", key)
+		}
+		table = child
+	}
+	return table, nil
+}
+
+func (p *parser) keyValue(table map[string]any) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipSpaces()
+	if p.done() || p.peek() != '=' {
+		return p.errorf("Expected = after key %s", strings.Join(keys, "."))
+	}
+	p.pos++
+	p.skipSpaces()
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+
+	parent, err := descend(table, keys[:len(keys)-1])
+	if err != nil {
+		return p.errorf("%v", err)
+	}
+	last := keys[len(keys)-1]
+	if _, ok := parent[last]; ok {
+		return p.errorf("Key %s is set twice", strings.Join(keys, "."))
+	}
+	parent[last] = value
+	return nil
+}
+
+// key reads a dotted key of bare and quoted parts
+func (p *parser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpaces()
+		if p.done() {
+			return nil, p.errorf("Expected a key")
+		}
+		switch p.peek() {
+		case '"', '\'':
+			part, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, part)
+		default:
+			start := p.pos
+			for !p.done() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("Expected a key, found %q", p.peek())
+			}
+			keys = append(keys, p.text[start:p.pos])
+		}
+		p.skipSpaces()
+		if p.done() || p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (p *parser) value() (any, error) {
+	if p.done() {
+		return nil, p.errorf("Expected a value")
+	}
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.str()
+	case c == '[':
+		return p.array()
+	case c == '{':
+		return p.inlineTable()
+	case strings.HasPrefix(p.text[p.pos:], "true"):
+		p.pos += len("true")
+		return true, nil
+	case strings.HasPrefix(p.text[p.pos:], "false"):
+		p.pos += len("false")
+		return false, nil
+	default:
+		return p.number()
+	}
+}
+
+func (p *parser) str() (string, error) {
+	quote := p.peek()
+	if strings.HasPrefix(p.text[p.pos:], strings.Repeat(string(quote), 3)) {
+		return "", p.errorf("Multi-line strings aren't supported")
+	}
+	p.pos++
+	var text strings.Builder
+	for {
+		if p.done() || p.peek() == '\n' {
+			return "", p.errorf("Unterminated string")
+		}
+		c := p.peek()
+		p.pos++
+		switch {
+		case c == quote:
+			return text.String(), nil
+		case c == '\\' && quote == '"':
+			if p.done() {
+				return "", p.errorf("Unterminated string")
+			}
+			escaped := p.peek()
+			p.pos++
+			switch escaped {
+			case '"', '\\':
+				text.WriteByte(escaped)
+			case 'n':
+				text.WriteByte('\n')
+			case 't':
+				text.WriteByte('\t')
+			case 'r':
+				text.WriteByte('\r')
+			default:
+				return "", p.errorf("Unknown escape \\%c", escaped)
+			}
+		default:
+			text.WriteByte(c)
+		}
+	}
+}
+
+func (p *parser) array() ([]any, error) {
+	p.pos++
+	values := []any{}
+	for {
+		p.skipBlank()
+		if p.done() {
+			return nil, p.errorf("Unterminated array")
+		}
+		if p.peek() == ']' {
+			p.pos++
+			return values, nil
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		p.skipBlank()
+		if !p.done() && p.peek() == ',' {
+			p.pos++
+		} else if p.done() || p.peek() != ']' {
+			return nil, p.errorf("Expected , or ] in an array")
+		}
+	}
+}
+
+func (p *parser) inlineTable() (map[string]any, error) {
+	p.pos++
+	table := map[string]any{}
+	p.skipSpaces()
+	if !p.done() && p.peek() == '}' {
+		p.pos++
+		return table, nil
+	}
+	for {
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if p.done() {
+			return nil, p.errorf("Unterminated inline table")
+		}
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return table, nil
+		default:
+			return nil, p.errorf("Expected , or } in an inline table")
+		}
+	}
+}
+
+func (p *parser) number() (any, error) {
+	start := p.pos
+	for !p.done() && strings.IndexByte("+-0123456789._eEinfatxobcdABCDF", p.peek()) >= 0 {
+		p.pos++
+	}
+	word := strings.ReplaceAll(p.text[start:p.pos], "_", "")
+	if word == "" {
+		return nil, p.errorf("Expected a value, found %q", p.peek())
+	}
+
+	switch strings.TrimLeft(word, "+-") {
+	case "inf":
+		if strings.HasPrefix(word, "-") {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+	// base 0 would read a leading zero as octal, only the 0x, 0o and 0b prefixes pick a base
+	digits := strings.TrimLeft(word, "+-")
+	base := 10
+	if len(digits) > 1 && digits[0] == '0' && strings.IndexByte("xob", digits[1]) >= 0 {
+		base = 0
+	} else if len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		return nil, p.errorf("%q has a leading zero", p.text[start:p.pos])
+	}
+	if integer, err := strconv.ParseInt(word, base, 64); err == nil {
+		return integer, nil
+	}
+	if float, err := strconv.ParseFloat(word, 64); err == nil && base == 10 {
+		return float, nil
+	}
+	return nil, p.errorf("%q is not a value", p.text[start:p.pos])
+}
@@ -0,0 +1,90 @@
+package toml
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	document, err := Parse(`# Extreme Reactors common config
+title = "Turbines" # trailing comment
+
+[turbine]
+maxTurbineSize = 32
+maxTurbineHeight = 0x30
+fluidPerBlade = 2.5e1
+enabled = true
+"quoted key" = 'C:\literal'
+
+[turbine.coils.Gold]
+efficiency = 2.0
+extraction = { rate = 1_750, bonus = +1.0 }
+
+[general]
+mods = [
+	"biggerreactors", # inline comment
+	"extremereactors",
+]
+limits = [-inf, nan]
+path.to.key = "a\"b"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"title": "Turbines",
+		"turbine": map[string]any{
+			"maxTurbineSize":   int64(32),
+			"maxTurbineHeight": int64(48),
+			"fluidPerBlade":    25.0,
+			"enabled":          true,
+			"quoted key":       `C:\literal`,
+			"coils": map[string]any{
+				"Gold": map[string]any{
+					"efficiency": 2.0,
+					"extraction": map[string]any{"rate": int64(1750), "bonus": 1.0},
+				},
+			},
+		},
+		"general": map[string]any{
+			"mods":   []any{"biggerreactors", "extremereactors"},
+			"limits": []any{math.Inf(-1), math.NaN()},
+			"path":   map[string]any{"to": map[string]any{"key": `a"b`}},
+		},
+	}
+	general := document["general"].(map[string]any)
+	limits := general["limits"].([]any)
+	if limits[0] != math.Inf(-1) || !math.IsNaN(limits[1].(float64)) {
+		t.Errorf("limits = %v", limits)
+	}
+	// NaN never equals itself
+	general["limits"] = nil
+	want["general"].(map[string]any)["limits"] = nil
+	if !reflect.DeepEqual(document, want) {
+		t.Errorf("Parse = %#v\nwant %#v", document, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"no value":        "a =",
+		"no equals":       "a 1",
+		"set twice":       "a = 1\na = 2",
+		"table twice":     "[a]\n[a]",
+		"table on value":  "a = 1\n[a.b]",
+		"array of tables": "[[a]]",
+		"unterminated":    `a = "b`,
+		"bad escape":      `a = "\q"`,
+		"two values":      "a = 1 2",
+		"open array":      "a = [1, 2",
+		"leading zero":    "a = 012",
+		"date":            "a = 1979-05-27",
+	}
+	for name, text := range tests {
+		if _, err := Parse(text); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package turbine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Version is the semantic version of the public API
+const Version = "1.2.0"
+
+// Design describes a turbine to build with New. Coil, Blade and Fluid are names from the config's tables,
+// Blade and Fluid fall back to the config's defaults when empty.
+type Design struct {
+	// outer dimensions, including casing
+	Width      int32  `json:"width"`
+	Height     int32  `json:"height"`
+	CoilLayers int32  `json:"coilLayers"`
+	Coil       string `json:"coil"`
+	Blade      string `json:"blade,omitempty"`
+	Fluid      string `json:"fluid,omitempty"`
+	// mB/t, capped at what the rotor takes in
+	FlowRate int64 `json:"flowRate"`
+}
+
+// ErrNoTurbine is returned by Optimize when no design passes the options
+var ErrNoTurbine = errors.New("No turbine fits the options")
+
+// InfeasibleError is why a search found no turbine, it is ErrNoTurbine to errors.Is. Constraint names the option
+// that ruled out the last candidates: "maxSize", "chunks", "coilLayers", "constraints", "flow", "limitNoLoadRPM",
+// "targetPeak" or "fitness", or "budget" and "cancelled" for a search that stopped before finding one.
+type InfeasibleError struct {
+	Constraint string
+	Message    string
+}
+
+func (err InfeasibleError) Error() string {
+	return err.Message
+}
+
+func (err InfeasibleError) Is(target error) bool {
+	return target == ErrNoTurbine
+}
+
+// New builds the design and runs it to its steady state, a nil config is Bigger Reactors.
+// Invalid sizes and unknown names are ValidationErrors with a suggestion where there is one.
+func New(config *Config, design Design) (Turbine, error) {
+	if config == nil {
+		config = &BiggerReactorsConfig
+	}
+	if design.FlowRate <= 0 {
+		return Turbine{}, ValidationError{"flowRate", fmt.Sprintf("Flow rate %d mB/t has to be positive", design.FlowRate), ""}
+	}
+	if err := config.ValidateDesignSize(design.Width, design.Height); err != nil {
+		return Turbine{}, err
+	}
+	coil, err := config.Coil(design.Coil)
+	if err != nil {
+		return Turbine{}, err
+	}
+
+	turbine, err := NewTurbine(config, design.Height, design.Width, design.CoilLayers, coil)
+	if err != nil {
+		return Turbine{}, err
+	}
+	if design.Blade != "" {
+		blade, err := config.Blade(design.Blade)
+		if err != nil {
+			return Turbine{}, err
+		}
+		turbine.SetBlade(blade)
+	}
+	if design.Fluid != "" {
+		fluid, err := config.Fluid(design.Fluid)
+		if err != nil {
+			return Turbine{}, err
+		}
+		turbine.SetFluid(fluid)
+	}
+
+	turbine.SetNominalFlowRate(design.FlowRate)
+	turbine.Converge()
+	return turbine, nil
+}
+
+// Optimize searches the options for the fittest turbine, converged to its steady state.
+// A search cut short by its budget returns the best turbine found so far.
+func Optimize(options Options) (Turbine, error) {
+	result := Search(options)
+	if err := result.Err(); err != nil {
+		return Turbine{}, err
+	}
+	return result.Turbine, nil
+}
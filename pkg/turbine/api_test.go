@@ -0,0 +1,42 @@
+package turbine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	built, err := New(nil, Design{Width: 9, Height: 12, CoilLayers: 2, Coil: "Gold", FlowRate: 500000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := built.Stats(); stats.FlowRate != stats.MaxFlowRate {
+		t.Errorf("flow rate %d is over the max of %d", stats.FlowRate, stats.MaxFlowRate)
+	}
+
+	tests := []struct {
+		design    Design
+		wantField string
+	}{
+		{Design{Width: 9, Height: 12, CoilLayers: 2, Coil: "Gold"}, "flowRate"},
+		{Design{Width: 9, Height: 300, CoilLayers: 2, Coil: "Gold", FlowRate: 2000}, "height"},
+		{Design{Width: 9, Height: 12, CoilLayers: 2, Coil: "Gld", FlowRate: 2000}, "coil"},
+		{Design{Width: 9, Height: 12, CoilLayers: 2, Coil: "Gold", Fluid: "Lava", FlowRate: 2000}, "fluid"},
+	}
+	for _, tc := range tests {
+		var validationErr ValidationError
+		if _, err := New(&BiggerReactorsConfig, tc.design); !errors.As(err, &validationErr) || validationErr.Field != tc.wantField {
+			t.Errorf("New(%+v) error = %v, want a %s error", tc.design, err, tc.wantField)
+		}
+	}
+	if _, err := New(nil, Design{Width: 9, Height: 12, CoilLayers: 10, Coil: "Gold", FlowRate: 2000}); err == nil {
+		t.Error("New built more coil layers than fit")
+	}
+}
+
+func TestOptimizeNoTurbine(t *testing.T) {
+	options := NewOptions(energyFitness, func(Turbine) bool { return false }, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 7, Y: 8, Z: 7})
+	if _, err := Optimize(options); !errors.Is(err, ErrNoTurbine) {
+		t.Errorf("Optimize error = %v, want ErrNoTurbine", err)
+	}
+}
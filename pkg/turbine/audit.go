@@ -0,0 +1,78 @@
+package turbine
+
+import "math"
+
+// how far a tick's energy may be off before the audit calls it unbalanced, relative to the energy that moved
+const auditTolerance = 1e-9
+
+// EnergyAudit sums where the energy went over the audited ticks. Everything is in rotor energy, the steam's
+// heat times the turbine multiplier, except Generated which is the RF the coils made from InductorDrag.
+type EnergyAudit struct {
+	Ticks int64 `json:"ticks"`
+	// heat of the steam that flowed in
+	SteamEnergy float64 `json:"steamEnergy"`
+	// what the blades took from it
+	RotorInput float64 `json:"rotorInput"`
+	// steam beyond what the blades could take at their rpm
+	BladeLoss float64 `json:"bladeLoss"`
+
+	InductorDrag float64 `json:"inductorDrag"`
+	FrictionDrag float64 `json:"frictionDrag"`
+	AeroDrag     float64 `json:"aeroDrag"`
+	Generated    float64 `json:"generated"`
+
+	// drag beyond the energy the rotor had, it stops at zero instead of going negative
+	Clamped float64 `json:"clamped"`
+	// rotor energy at the end less at the start, positive while it spins up
+	Stored float64 `json:"stored"`
+
+	// largest imbalance of a tick relative to the energy it moved, zero when energy is conserved exactly
+	MaxImbalance float64 `json:"maxImbalance"`
+	Balanced     bool    `json:"balanced"`
+}
+
+// StartAudit starts a fresh audit of the following ticks
+func (turbine *Turbine) StartAudit() {
+	turbine.audit = &EnergyAudit{Balanced: true}
+}
+
+// StopAudit ends the audit and returns it, an empty one if none was running
+func (turbine *Turbine) StopAudit() EnergyAudit {
+	if turbine.audit == nil {
+		return EnergyAudit{Balanced: true}
+	}
+	audit := *turbine.audit
+	turbine.audit = nil
+	return audit
+}
+
+// Audit runs ticks on a copy of the turbine as it stands and returns where the energy went,
+// the turbine it's called on is not modified
+func (turbine Turbine) Audit(ticks int) EnergyAudit {
+	turbine.StartAudit()
+	for range max(0, ticks) {
+		turbine.Tick()
+	}
+	return turbine.StopAudit()
+}
+
+// record adds one tick, start is the rotor energy before it
+func (audit *EnergyAudit) record(turbine *Turbine, start, steamEnergy, rotorInput, clamped float64) {
+	audit.Ticks++
+	audit.SteamEnergy += steamEnergy
+	audit.RotorInput += rotorInput
+	audit.BladeLoss += steamEnergy - rotorInput
+	audit.InductorDrag += turbine.inductorDragLastTick
+	audit.FrictionDrag += turbine.frictionDragLastTick
+	audit.AeroDrag += turbine.aeroDragLastTick
+	audit.Generated += turbine.energyGeneratedLastTick
+	audit.Clamped += clamped
+	audit.Stored += turbine.rotorEnergy - start
+
+	drag := turbine.inductorDragLastTick + turbine.frictionDragLastTick + turbine.aeroDragLastTick
+	residual := start + rotorInput - drag + clamped - turbine.rotorEnergy
+	moved := max(1, start+rotorInput, drag)
+	imbalance := math.Abs(residual) / moved
+	audit.MaxImbalance = max(audit.MaxImbalance, imbalance)
+	audit.Balanced = audit.Balanced && imbalance <= auditTolerance
+}
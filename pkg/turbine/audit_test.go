@@ -0,0 +1,78 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func auditedTurbine(t *testing.T, flowRate int64) Turbine {
+	t.Helper()
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 14, 9, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetNominalFlowRate(flowRate)
+	return turbine
+}
+
+func TestAuditSteadyState(t *testing.T) {
+	turbine := auditedTurbine(t, 2000)
+	turbine.Converge()
+	before := turbine.RPM()
+
+	audit := turbine.Audit(200)
+	if audit.Ticks != 200 || !audit.Balanced {
+		t.Fatalf("audit = %+v, want 200 balanced ticks", audit)
+	}
+	if turbine.RPM() != before || turbine.audit != nil {
+		t.Error("Audit changed the turbine it was called on")
+	}
+
+	// at the steady state the blades' input goes into the drag and nothing is stored
+	drag := audit.InductorDrag + audit.FrictionDrag + audit.AeroDrag
+	if math.Abs(audit.RotorInput-drag) > 1e-3*audit.RotorInput {
+		t.Errorf("rotor input %g but drag %g at the steady state", audit.RotorInput, drag)
+	}
+	if math.Abs(audit.Stored) > 1e-3*audit.RotorInput {
+		t.Errorf("stored %g at the steady state", audit.Stored)
+	}
+	if math.Abs(audit.SteamEnergy-audit.RotorInput-audit.BladeLoss) > 1e-6*audit.SteamEnergy || audit.BladeLoss < 0 {
+		t.Errorf("steam %g does not split into input %g and blade loss %g", audit.SteamEnergy, audit.RotorInput, audit.BladeLoss)
+	}
+	if audit.Generated <= 0 {
+		t.Errorf("generated %g RF", audit.Generated)
+	}
+}
+
+func TestAuditSpinUpAndCoast(t *testing.T) {
+	turbine := auditedTurbine(t, 2000)
+	turbine.SetActive(true)
+	turbine.SetCoilEngaged(true)
+
+	turbine.StartAudit()
+	for range 100 {
+		turbine.Tick()
+	}
+	spinUp := turbine.StopAudit()
+	if !spinUp.Balanced || spinUp.Stored <= 0 || math.Abs(spinUp.Stored-turbine.rotorEnergy) > 1e-6*turbine.rotorEnergy {
+		t.Errorf("spin up from rest = %+v, want the rotor energy %g stored", spinUp, turbine.rotorEnergy)
+	}
+
+	// without steam the stored energy pays for the drag until the rotor stops
+	turbine.SetActive(false)
+	coast := turbine.Audit(100000)
+	if !coast.Balanced || coast.SteamEnergy != 0 || coast.Stored >= 0 {
+		t.Errorf("coast = %+v, want balanced with the stored energy drained", coast)
+	}
+	if math.Abs(-coast.Stored+coast.Clamped-(coast.InductorDrag+coast.FrictionDrag+coast.AeroDrag)) > 1e-6*turbine.rotorEnergy {
+		t.Errorf("coast drag does not add up to the stored energy and the clamp: %+v", coast)
+	}
+}
+
+func TestStopAuditWithoutStart(t *testing.T) {
+	turbine := auditedTurbine(t, 2000)
+	if audit := turbine.StopAudit(); audit.Ticks != 0 || !audit.Balanced {
+		t.Errorf("StopAudit() without StartAudit = %+v", audit)
+	}
+}
@@ -0,0 +1,101 @@
+package turbine
+
+import (
+	"slices"
+	"time"
+)
+
+// balanceTiers are the reference rooms of a balance report, outer width and height. Tiers a config doesn't
+// allow are left out and the last one is always the largest turbine the config allows.
+var balanceTiers = []referenceRoom{
+	{"small", 7, 10},
+	{"medium", 11, 16},
+	{"large", 15, 24},
+	{"huge", 21, 32},
+}
+
+type referenceRoom struct {
+	name          string
+	width, height int32
+}
+
+// every coil is compared in the medium room, big enough for the coils to matter and quick to search
+const balanceCoilTier = 1
+
+// the largest tier is only searched this many times as tall as it is wide, a 32x192 room takes minutes
+const maxTierAspect = 2
+
+// BalanceReport shows pack makers what a config allows, the best turbine for each room size and every coil
+type BalanceReport struct {
+	Variant ModVariant    `json:"variant"`
+	Tiers   []BalanceTier `json:"tiers"`
+	// every coil in the medium room, worst first
+	Coils []CoilBalance `json:"coils"`
+	// a search ran out of its time budget, the numbers may be a little low
+	Truncated bool `json:"truncated"`
+	// cancelled stopped the report, the rooms and coils after the last search are missing
+	Cancelled bool `json:"cancelled"`
+}
+
+// BalanceTier is the most energy a room of a size makes with the best coil, Found is false when no turbine fits
+type BalanceTier struct {
+	Name      string `json:"name"`
+	MaxWidth  int32  `json:"maxWidth"`
+	MaxHeight int32  `json:"maxHeight"`
+	Coil      string `json:"coil"`
+	Found     bool   `json:"found"`
+	Stats     Stats  `json:"stats"`
+}
+
+type CoilBalance struct {
+	Coil  string `json:"coil"`
+	Found bool   `json:"found"`
+	Stats Stats  `json:"stats"`
+}
+
+// Balance searches the reference rooms for the turbines making the most energy at full flow, each search stops
+// after timeBudget if it is positive. The report stops early once cancelled returns true, it may be nil.
+func Balance(config *Config, timeBudget time.Duration, cancelled func() bool) BalanceReport {
+	report := BalanceReport{Variant: config.Variant}
+	materials := config.CoilMaterials()
+	if len(materials) == 0 {
+		return report
+	}
+
+	search := func(width, height int32, coil CoilData) (bool, Stats) {
+		options := NewOptions(MetricFitness(MaximizeEnergy, 0), func(Turbine) bool { return true }, coil, FlowSetting{Variant: UseMaxFlow}, Size{X: width, Y: height, Z: width})
+		options.Config = config
+		options.TimeBudget = timeBudget
+		options.Cancelled = cancelled
+		result := Search(options)
+		report.Truncated = report.Truncated || result.Truncated
+		report.Cancelled = report.Cancelled || result.Cancelled
+		return result.Found, result.Turbine.Stats()
+	}
+
+	best := materials[len(materials)-1]
+	largest := referenceRoom{"max", config.MaxWidth, min(config.MaxHeight, config.MaxWidth*maxTierAspect)}
+	for _, tier := range append(slices.Clone(balanceTiers), largest) {
+		if tier.width > config.MaxWidth || tier.height > config.MaxHeight || tier.width < config.MinWidth || tier.height < config.MinHeight {
+			continue
+		}
+		balanceTier := BalanceTier{Name: tier.name, MaxWidth: tier.width, MaxHeight: tier.height, Coil: best.Name}
+		balanceTier.Found, balanceTier.Stats = search(tier.width, tier.height, best.CoilData)
+		if report.Cancelled {
+			return report
+		}
+		report.Tiers = append(report.Tiers, balanceTier)
+	}
+
+	coilTier := balanceTiers[balanceCoilTier]
+	width, height := min(coilTier.width, config.MaxWidth), min(coilTier.height, config.MaxHeight)
+	for _, material := range materials {
+		coil := CoilBalance{Coil: material.Name}
+		coil.Found, coil.Stats = search(width, height, material.CoilData)
+		if report.Cancelled {
+			return report
+		}
+		report.Coils = append(report.Coils, coil)
+	}
+	return report
+}
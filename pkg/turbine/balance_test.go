@@ -0,0 +1,44 @@
+package turbine
+
+import "testing"
+
+func TestBalance(t *testing.T) {
+	report := Balance(&ExtremeReactorsConfig, 0, nil)
+
+	var names []string
+	for i, tier := range report.Tiers {
+		names = append(names, tier.Name)
+		if !tier.Found || tier.Coil != "Ludicrite" {
+			t.Errorf("%s tier found %v with %s", tier.Name, tier.Found, tier.Coil)
+		}
+		if i > 0 && tier.Stats.EnergyGenerated < report.Tiers[i-1].Stats.EnergyGenerated {
+			t.Errorf("%s tier makes %v RF/t, less than the smaller %s", tier.Name, tier.Stats.EnergyGenerated, report.Tiers[i-1].Name)
+		}
+	}
+	// Extreme Reactors stops at 32 blocks high, so the max tier is 32x32
+	if len(names) != 5 || report.Tiers[4].MaxHeight != 32 {
+		t.Errorf("tiers %v", report.Tiers)
+	}
+
+	if len(report.Coils) != len(ExtremeReactorsConfig.Coils) {
+		t.Fatalf("%d coils, want %d", len(report.Coils), len(ExtremeReactorsConfig.Coils))
+	}
+	first, last := report.Coils[0], report.Coils[len(report.Coils)-1]
+	if first.Coil != "Iron" || last.Coil != "Ludicrite" || last.Stats.EnergyGenerated <= first.Stats.EnergyGenerated {
+		t.Errorf("coils from %s making %v to %s making %v", first.Coil, first.Stats.EnergyGenerated, last.Coil, last.Stats.EnergyGenerated)
+	}
+	if report.Truncated {
+		t.Error("search without a budget was truncated")
+	}
+}
+
+func TestBalanceCancelled(t *testing.T) {
+	calls := 0
+	report := Balance(&ExtremeReactorsConfig, 0, func() bool {
+		calls++
+		return calls > 1
+	})
+	if !report.Cancelled || len(report.Tiers)+len(report.Coils) > 1 {
+		t.Errorf("cancelled report has %d tiers and %d coils, cancelled %v", len(report.Tiers), len(report.Coils), report.Cancelled)
+	}
+}
@@ -0,0 +1,129 @@
+package turbine
+
+import "fmt"
+
+// Candidate is one geometry and flow rate for EvaluateBatch
+type Candidate struct {
+	Height, Width, CoilLayers int32
+	FlowRate                  int64
+}
+
+// BatchResult holds what Settle would leave in Stats for each candidate, in the order they were given
+type BatchResult struct {
+	RPM             []float64
+	EnergyGenerated []float64
+	RotorEfficiency []float64
+	CoilEfficiency  []float64
+}
+
+// batchColumns is everything the evaluation loop reads, one flat slice per value
+type batchColumns struct {
+	flowRate            []float64
+	rotorCapacityPerRPM []float64
+	rotorDrag           []float64
+	inductorDrag        []float64
+	coilSize            []float64
+	rotorMass           []float64
+	bladeLength         []float64
+	rotorAxialMass      []float64
+	exponentBonus       []float64
+	inductionEfficiency []float64
+}
+
+func newBatchColumns(size int) batchColumns {
+	return batchColumns{
+		flowRate:            make([]float64, size),
+		rotorCapacityPerRPM: make([]float64, size),
+		rotorDrag:           make([]float64, size),
+		inductorDrag:        make([]float64, size),
+		coilSize:            make([]float64, size),
+		rotorMass:           make([]float64, size),
+		bladeLength:         make([]float64, size),
+		rotorAxialMass:      make([]float64, size),
+		exponentBonus:       make([]float64, size),
+		inductionEfficiency: make([]float64, size),
+	}
+}
+
+// EvaluateBatch settles every candidate with the given coil and returns the same values Settle does.
+// Each geometry is only built once, after that the evaluation is a single loop over flat float64 columns.
+func EvaluateBatch(config *Config, coil CoilData, candidates []Candidate, precision Precision) (BatchResult, error) {
+	type geometry struct{ height, width, coilLayers int32 }
+	built := map[geometry]Turbine{}
+
+	columns := newBatchColumns(len(candidates))
+	for i, candidate := range candidates {
+		key := geometry{candidate.Height, candidate.Width, candidate.CoilLayers}
+		turbine, ok := built[key]
+		if !ok {
+			var err error
+			turbine, err = NewTurbine(config, candidate.Height, candidate.Width, candidate.CoilLayers, coil)
+			if err != nil {
+				return BatchResult{}, fmt.Errorf("Candidate %d: %w", i, err)
+			}
+			built[key] = turbine
+		}
+
+		turbine.SetNominalFlowRate(candidate.FlowRate)
+		columns.flowRate[i] = float64(turbine.maxFlowRate)
+		columns.rotorCapacityPerRPM[i] = turbine.rotorCapacityPerRPM
+		columns.rotorDrag[i] = turbine.rotorDragPerRPM2()
+		columns.inductorDrag[i] = turbine.inductorDragCoefficient
+		columns.coilSize[i] = float64(turbine.coilSize)
+		columns.rotorMass[i] = turbine.rotorMass
+		columns.bladeLength[i] = turbine.linearBladeMetersPerRevolution
+		columns.rotorAxialMass[i] = turbine.rotorAxialMass
+		columns.exponentBonus[i] = turbine.inductionEnergyExponentBonus
+		columns.inductionEfficiency[i] = turbine.inductionEfficiency
+	}
+
+	return columns.evaluate(config, precision), nil
+}
+
+// evaluate is Settle for every row: jump to the closed form rpm and tick once.
+// The arithmetic follows steadyRPM and Tick step for step so the results match bit for bit.
+func (columns batchColumns) evaluate(config *Config, precision Precision) BatchResult {
+	size := len(columns.flowRate)
+	result := BatchResult{
+		RPM:             make([]float64, size),
+		EnergyGenerated: make([]float64, size),
+		RotorEfficiency: make([]float64, size),
+		CoilEfficiency:  make([]float64, size),
+	}
+	fluid := config.DefaultFluid()
+	RFPerHeat := fluid.LatentHeat * config.TurbineMultiplier
+	curve := config.efficiencyCurve()
+
+	for i := range size {
+		flowRate := columns.flowRate[i]
+		finalRPM := steadyRPM(flowRate, columns.rotorCapacityPerRPM[i], columns.rotorDrag[i], columns.inductorDrag[i]*columns.coilSize[i], RFPerHeat)
+		rotorEnergy := columns.rotorAxialMass[i] * finalRPM
+		rpm := rotorEnergy / columns.rotorAxialMass[i]
+
+		rotorCapacity := columns.rotorCapacityPerRPM[i] * max(100, rpm)
+		effectiveFlowRate := flowRate
+		if flowRate > rotorCapacity {
+			excessFlow := flowRate - rotorCapacity
+			excessEfficiency := rotorCapacity / flowRate
+			effectiveFlowRate = rotorCapacity + excessFlow*excessEfficiency
+		}
+		if flowRate != 0 {
+			result.RotorEfficiency[i] = effectiveFlowRate / flowRate
+		}
+		if effectiveFlowRate > 0 {
+			rotorEnergy += effectiveFlowRate * fluid.LatentHeat * config.TurbineMultiplier
+		}
+
+		inductionTorque := rpm * columns.inductorDrag[i] * columns.coilSize[i]
+		efficiency := curve.efficiency(rpm)
+		result.CoilEfficiency[i] = efficiency
+		result.EnergyGenerated[i] = inductionEnergy(inductionTorque, columns.exponentBonus[i], columns.inductionEfficiency[i], efficiency, precision)
+		rotorEnergy -= inductionTorque
+
+		rotorEnergy -= columns.rotorMass[i] * (rpm * config.FrictionDragMultiplier) * (rpm * config.FrictionDragMultiplier)
+		rotorEnergy -= columns.bladeLength[i] * (rpm * config.AerodynamicDragMultiplier) * (rpm * config.AerodynamicDragMultiplier)
+		result.RPM[i] = max(0, rotorEnergy) / columns.rotorAxialMass[i]
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package turbine
+
+import "testing"
+
+func TestEvaluateBatchMatchesSettle(t *testing.T) {
+	for _, precision := range []Precision{PrecisionFast, PrecisionExact} {
+		for _, tc := range snapshotTurbines {
+			coil := biggerReactorsCoils[tc.coil]
+			candidates := []Candidate{
+				{tc.height, tc.width, tc.coilLayers, tc.flowRate},
+				{tc.height, tc.width, tc.coilLayers, tc.flowRate / 2},
+				{tc.height, tc.width, tc.coilLayers, 0},
+			}
+			batch, err := EvaluateBatch(&BiggerReactorsConfig, coil, candidates, precision)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, candidate := range candidates {
+				turbine, err := NewTurbine(&BiggerReactorsConfig, candidate.Height, candidate.Width, candidate.CoilLayers, coil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				turbine.SetPrecision(precision)
+				turbine.SetNominalFlowRate(candidate.FlowRate)
+				turbine.Settle()
+				stats := turbine.Stats()
+
+				if batch.RPM[i] != stats.RPM || batch.EnergyGenerated[i] != stats.EnergyGenerated ||
+					batch.RotorEfficiency[i] != stats.RotorEfficiency || batch.CoilEfficiency[i] != stats.CoilEfficiency {
+					t.Errorf("%s at %d mB/t: batch %v rpm %v RF/t, settled %v rpm %v RF/t", tc.name, candidate.FlowRate,
+						batch.RPM[i], batch.EnergyGenerated[i], stats.RPM, stats.EnergyGenerated)
+				}
+			}
+		}
+	}
+}
+
+func TestEvaluateBatchRejectsInvalidGeometry(t *testing.T) {
+	candidates := []Candidate{{10, 9, 2, 1000}, {10, 8, 2, 1000}}
+	if _, err := EvaluateBatch(&BiggerReactorsConfig, biggerReactorsCoils["Gold"], candidates, PrecisionFast); err == nil {
+		t.Error("even width accepted")
+	}
+}
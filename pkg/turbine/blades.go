@@ -0,0 +1,80 @@
+package turbine
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BladeData scales a rotor blade against the config's RotorAxialMassPerBlade and FluidPerBladeLinerKilometre
+type BladeData struct {
+	// steam each blade can use
+	Capacity float64 `json:"capacity"`
+	// weight each blade adds to the rotor, heavier rotors spin up slower and lose more to friction
+	Mass float64 `json:"mass"`
+}
+
+// StandardBlade is the plain turbine rotor blade every mod has
+var StandardBlade = BladeData{Capacity: 1, Mass: 1}
+
+var biggerReactorsBlades = map[string]BladeData{
+	"Basic": StandardBlade,
+}
+
+var extremeReactorsBlades = map[string]BladeData{
+	"Basic": StandardBlade,
+}
+
+type BladeMaterial struct {
+	Name string `json:"name"`
+	BladeData
+}
+
+// BladeMaterials lists the blade table from the lowest to the highest capacity
+func (config Config) BladeMaterials() []BladeMaterial {
+	materials := make([]BladeMaterial, 0, len(config.Blades))
+	for name, blade := range config.Blades {
+		materials = append(materials, BladeMaterial{name, blade})
+	}
+
+	slices.SortFunc(materials, func(a, b BladeMaterial) int {
+		return cmp.Or(
+			cmp.Compare(a.Capacity, b.Capacity),
+			cmp.Compare(a.Mass, b.Mass),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return materials
+}
+
+// DefaultBlade is the blade turbines get unless another is picked, the standard one for configs without a blade table
+func (config Config) DefaultBlade() BladeMaterial {
+	if blade, ok := config.Blades[config.DefaultBladeName]; ok {
+		return BladeMaterial{config.DefaultBladeName, blade}
+	}
+	return BladeMaterial{BladeData: StandardBlade}
+}
+
+// Blade looks up a rotor blade by name, suggesting the closest one if there is no such blade
+func (config Config) Blade(name string) (BladeMaterial, error) {
+	if blade, ok := config.Blades[name]; ok {
+		return BladeMaterial{name, blade}, nil
+	}
+
+	names := make([]string, 0, len(config.Blades))
+	for _, material := range config.BladeMaterials() {
+		names = append(names, material.Name)
+	}
+	return BladeMaterial{}, ValidationError{"blade", fmt.Sprintf("Unknown rotor blade %q", name), closestName(name, names)}
+}
+
+// SetBlade swaps every rotor blade for another type
+func (turbine *Turbine) SetBlade(blade BladeMaterial) {
+	turbine.SetRotorConfiguration(turbine.rotors, blade)
+}
+
+// Blade is the rotor blade type the turbine is built with
+func (turbine Turbine) Blade() BladeMaterial {
+	return turbine.blade
+}
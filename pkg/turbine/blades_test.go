@@ -0,0 +1,93 @@
+package turbine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultBlade(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 12, 9, 3, biggerReactorsCoils["Gold"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := turbine.Blade(); got.Name != "Basic" || got.BladeData != StandardBlade {
+		t.Errorf("default blade is %+v", got)
+	}
+
+	// a config without a blade table still gets standard blades
+	config := BiggerReactorsConfig.Clone()
+	config.Blades = nil
+	bare, err := NewTurbine(config, 12, 9, 3, biggerReactorsCoils["Gold"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bare.rotorCapacityPerRPM != turbine.rotorCapacityPerRPM || bare.rotorMass != turbine.rotorMass {
+		t.Error("a config without blades builds a different rotor")
+	}
+	if got := bare.Stats().Blade; got != "" {
+		t.Errorf("blade %q without a blade table", got)
+	}
+}
+
+func TestSetBlade(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.Blades["Reinforced"] = BladeData{Capacity: 1.5, Mass: 2}
+	reinforced, err := config.Blade("Reinforced")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	basic, err := NewTurbine(config, 12, 9, 3, biggerReactorsCoils["Gold"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine := basic
+	turbine.SetBlade(reinforced)
+
+	assertClose(t, "rotorCapacityPerRPM", turbine.rotorCapacityPerRPM, basic.rotorCapacityPerRPM*1.5)
+	shaftMass := float64(basic.rotorShafts) * config.RotorAxialMassPerShaft
+	assertClose(t, "blade mass", turbine.rotorMass-shaftMass, (basic.rotorMass-shaftMass)*2)
+	if turbine.RotorBlades() != basic.RotorBlades() {
+		t.Errorf("got %d blades, want %d", turbine.RotorBlades(), basic.RotorBlades())
+	}
+
+	found := false
+	for _, item := range turbine.BuildCost() {
+		if item.Name == "Reinforced Rotor Blades" {
+			found = item.Count == basic.RotorBlades()
+		}
+	}
+	if !found {
+		t.Errorf("build cost %v doesn't list the reinforced blades", turbine.BuildCost())
+	}
+
+	// switching back gives the same rotor again
+	turbine.SetBlade(config.DefaultBlade())
+	if turbine.rotorCapacityPerRPM != basic.rotorCapacityPerRPM || turbine.rotorMass != basic.rotorMass {
+		t.Error("switching back to the basic blade changed the rotor")
+	}
+}
+
+func TestSearchWithBlade(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.Blades["Reinforced"] = BladeData{Capacity: 1.5, Mass: 2}
+
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 12, Z: 9})
+	options.Config = config
+	options.Blade, _ = config.Blade("Reinforced")
+	result := Search(options)
+	if !result.Found {
+		t.Fatal("no turbine found")
+	}
+	if got := result.Turbine.Stats().Blade; got != "Reinforced" {
+		t.Errorf("search built %q blades", got)
+	}
+}
+
+func TestBladeSuggestion(t *testing.T) {
+	_, err := BiggerReactorsConfig.Blade("basic ")
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Suggestion != "Basic" {
+		t.Errorf("got %v, want a suggestion of Basic", err)
+	}
+}
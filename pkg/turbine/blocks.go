@@ -0,0 +1,126 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// blocks in the walls that aren't casing, glass or bearings: controller, power tap and two fluid ports
+const wallComponents = 4
+
+func (turbine Turbine) RotorBlades() int64 {
+	return int64(math.Round((turbine.rotorMass - (float64(turbine.rotorShafts) * turbine.config.RotorAxialMassPerShaft)) / (turbine.config.RotorAxialMassPerBlade * turbine.blade.Mass)))
+}
+
+// BlockCount is every block placed in the build: the outer shell plus shafts, blades and coils
+func (turbine Turbine) BlockCount() int64 {
+	inner := int64(turbine.size.X) * int64(turbine.size.Y) * int64(turbine.size.Z)
+	return turbine.ExteriorVolume() - inner + int64(turbine.rotorShafts) + turbine.RotorBlades() + turbine.coilSize
+}
+
+// Footprint is the floor area the turbine takes up
+func (turbine Turbine) Footprint() int64 {
+	return int64(turbine.size.X+2) * int64(turbine.size.Z+2)
+}
+
+// WallMaterial is what fills the faces of the turbine inside the casing frame
+type WallMaterial int64
+
+const (
+	GlassWalls WallMaterial = iota
+	CasingWalls
+	// glass only in the side walls next to the blades, so the rotor can still be seen
+	GlassBand
+)
+
+func ParseWallMaterial(name string) (WallMaterial, error) {
+	switch strings.ToLower(name) {
+	case "glass":
+		return GlassWalls, nil
+	case "casing":
+		return CasingWalls, nil
+	case "band":
+		return GlassBand, nil
+	default:
+		return 0, fmt.Errorf("Unknown wall material %q", name)
+	}
+}
+
+func (turbine Turbine) BuildCost() build.Cost {
+	return turbine.BuildCostWith(GlassWalls)
+}
+
+func (turbine Turbine) BuildCostWith(walls WallMaterial) build.Cost {
+	casing, glass := turbine.wallBlocks(walls)
+	return build.Cost{}.
+		Add("Turbine Controller", 1).
+		Add("Turbine Power Tap", 1).
+		Add("Turbine IO Ports", 2).
+		Add("Turbine Bearings", int64(turbine.config.Bearings)).
+		Add("Turbine Casings", casing).
+		Add("Turbine Glass", glass).
+		Add("Coil Blocks", turbine.coilSize).
+		Add("Shafts", int64(turbine.rotorShafts)).
+		Add(turbine.bladeItemName(), turbine.RotorBlades())
+}
+
+// wallBlocks splits the shell, less the controller, ports and bearings, into casing and glass
+func (turbine Turbine) wallBlocks(walls WallMaterial) (casing, glass int64) {
+	width := int64(turbine.size.X + 2)
+	height := int64(turbine.size.Y + 2)
+	depth := int64(turbine.size.Z + 2)
+
+	// the frame has to be casing, the faces inside it can be glass
+	frame := 4*(width+height+depth) - 16
+	faces := 2 * ((width-2)*(height-2) + (width-2)*(depth-2) + (height-2)*(depth-2))
+	components := wallComponents + int64(turbine.config.Bearings)
+
+	switch walls {
+	case GlassWalls:
+		glass = faces - components
+	case CasingWalls:
+		glass = 0
+	case GlassBand:
+		// the components go in the casing above and below the band
+		bladeRows := int64(turbine.size.Y - turbine.coilLayers)
+		glass = bladeRows * 2 * ((width - 2) + (depth - 2))
+	default:
+		panic("Invalid WallMaterial")
+	}
+	return frame + faces - components - glass, glass
+}
+
+// BlockSummary counts a build at a glance, for comparing how dense designs are
+type BlockSummary struct {
+	TotalBlocks    int64 `json:"totalBlocks"`
+	InteriorVolume int64 `json:"interiorVolume"`
+	CasingBlocks   int64 `json:"casingBlocks"`
+	GlassBlocks    int64 `json:"glassBlocks"`
+	// blocks placed for every RF/t made, zero when the turbine makes nothing
+	BlocksPerRFt float64 `json:"blocksPerRFt"`
+}
+
+func (turbine Turbine) BlockSummary(walls WallMaterial) BlockSummary {
+	casing, glass := turbine.wallBlocks(walls)
+	summary := BlockSummary{
+		TotalBlocks:    turbine.BlockCount(),
+		InteriorVolume: int64(turbine.size.X) * int64(turbine.size.Y) * int64(turbine.size.Z),
+		CasingBlocks:   casing,
+		GlassBlocks:    glass,
+	}
+	if turbine.energyGeneratedLastTick > 0 {
+		summary.BlocksPerRFt = float64(summary.TotalBlocks) / turbine.energyGeneratedLastTick
+	}
+	return summary
+}
+
+// bladeItemName names the blades in the build cost, plain "Rotor Blades" for the default type
+func (turbine Turbine) bladeItemName() string {
+	if turbine.blade.Name == "" || turbine.blade.Name == turbine.config.DefaultBladeName {
+		return "Rotor Blades"
+	}
+	return turbine.blade.Name + " Rotor Blades"
+}
@@ -0,0 +1,105 @@
+package turbine
+
+import "testing"
+
+func TestBlockCount(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 4, 5, 1, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 5x4x5 shell around a 3x2x3 interior, two shafts, one layer of 4 blades and 8 coils
+	if got, want := turbine.BlockCount(), int64(5*4*5-3*2*3+2+4+8); got != want {
+		t.Errorf("BlockCount = %d, want %d", got, want)
+	}
+	if got := turbine.Footprint(); got != 25 {
+		t.Errorf("Footprint = %d, want 25", got)
+	}
+}
+
+func TestBuildCost(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 4, 5, 1, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every block of the build is in the cost exactly once
+	if got, want := turbine.BuildCost().Total(), turbine.BlockCount(); got != want {
+		t.Errorf("build cost has %d blocks, BlockCount is %d", got, want)
+	}
+
+	// counted by hand on the smallest build, the 5x4x5 shell: 8 corners, 8 blocks along the 4 upright edges and
+	// 24 along the 8 flat ones are casing, the 2*(3*2+3*2+3*3) face blocks less the controller, power tap, two
+	// ports and two bearings are glass. The counts used to come from the 3x2x3 interior, 16 casings and -4 glass.
+	cost := map[string]int64{}
+	for _, item := range turbine.BuildCost() {
+		cost[item.Name] = item.Count
+	}
+	if cost["Turbine Casings"] != 40 || cost["Turbine Glass"] != 36 {
+		t.Errorf("%d casings and %d glass, want 40 and 36", cost["Turbine Casings"], cost["Turbine Glass"])
+	}
+}
+
+func TestBuildCostWalls(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 10, 7, 2, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		walls     WallMaterial
+		wantGlass int64
+	}{
+		// 5x8 and 5x5 faces minus controller, power tap, ports and bearings
+		{GlassWalls, 2*(5*8+5*8+5*5) - 6},
+		{CasingWalls, 0},
+		// six rows of blades under two coil layers, all four sides
+		{GlassBand, 6 * 4 * 5},
+	}
+
+	for _, tc := range tests {
+		cost := turbine.BuildCostWith(tc.walls)
+		if got := cost.Total(); got != turbine.BlockCount() {
+			t.Errorf("walls %d: build cost has %d blocks, BlockCount is %d", tc.walls, got, turbine.BlockCount())
+		}
+
+		var glass int64
+		for _, item := range cost {
+			if item.Name == "Turbine Glass" {
+				glass = item.Count
+			}
+		}
+		if glass != tc.wantGlass {
+			t.Errorf("walls %d: %d glass, want %d", tc.walls, glass, tc.wantGlass)
+		}
+	}
+}
+
+func TestBlockSummary(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 10, 7, 2, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary := turbine.BlockSummary(GlassWalls); summary.BlocksPerRFt != 0 {
+		t.Errorf("idle turbine has %g blocks per RF/t", summary.BlocksPerRFt)
+	}
+
+	turbine.SetNominalFlowRate(10000)
+	turbine.Converge()
+	for _, walls := range []WallMaterial{GlassWalls, CasingWalls, GlassBand} {
+		summary := turbine.BlockSummary(walls)
+		if summary.TotalBlocks != turbine.BlockCount() || summary.InteriorVolume != 5*8*5 {
+			t.Errorf("walls %d: %d blocks around %d inside", walls, summary.TotalBlocks, summary.InteriorVolume)
+		}
+
+		cost := map[string]int64{}
+		for _, item := range turbine.BuildCostWith(walls) {
+			cost[item.Name] = item.Count
+		}
+		if summary.CasingBlocks != cost["Turbine Casings"] || summary.GlassBlocks != cost["Turbine Glass"] {
+			t.Errorf("walls %d: %d casings and %d glass, the build cost has %d and %d", walls, summary.CasingBlocks, summary.GlassBlocks, cost["Turbine Casings"], cost["Turbine Glass"])
+		}
+		assertClose(t, "BlocksPerRFt", summary.BlocksPerRFt, float64(summary.TotalBlocks)/turbine.Stats().EnergyGenerated)
+	}
+}
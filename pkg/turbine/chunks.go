@@ -0,0 +1,64 @@
+package turbine
+
+import (
+	"fmt"
+	"strings"
+)
+
+const ChunkSize = 16
+
+type ChunkMode int64
+
+const (
+	IgnoreChunks ChunkMode = iota
+	// pick the best turbine within one chunk if there is one, else the best overall
+	PreferOneChunk
+	// only turbines within one chunk
+	RequireOneChunk
+)
+
+func ParseChunkMode(name string) (ChunkMode, error) {
+	switch strings.ToLower(name) {
+	case "ignore":
+		return IgnoreChunks, nil
+	case "prefer":
+		return PreferOneChunk, nil
+	case "require":
+		return RequireOneChunk, nil
+	default:
+		return 0, fmt.Errorf("Unknown chunk mode %q", name)
+	}
+}
+
+type ChunkSetting struct {
+	Mode ChunkMode
+	// x and z of the turbine's lowest corner, either within the chunk or as world coordinates
+	OffsetX, OffsetZ int32
+}
+
+// ChunkSpan is how many chunks a turbine's footprint crosses
+type ChunkSpan struct {
+	X      int32 `json:"x"`
+	Z      int32 `json:"z"`
+	Chunks int32 `json:"chunks"`
+}
+
+// chunkOffset is where a world coordinate falls within its chunk, negative coordinates included
+func chunkOffset(coordinate int32) int32 {
+	return (coordinate%ChunkSize + ChunkSize) % ChunkSize
+}
+
+// Span is the chunks a turbine of the given outer width covers from the setting's offset
+func (setting ChunkSetting) Span(width int32) ChunkSpan {
+	span := ChunkSpan{
+		X: (chunkOffset(setting.OffsetX) + width + ChunkSize - 1) / ChunkSize,
+		Z: (chunkOffset(setting.OffsetZ) + width + ChunkSize - 1) / ChunkSize,
+	}
+	span.Chunks = span.X * span.Z
+	return span
+}
+
+// allows says if a turbine of the given outer width can be considered
+func (setting ChunkSetting) allows(width int32) bool {
+	return setting.Mode != RequireOneChunk || setting.Span(width).Chunks == 1
+}
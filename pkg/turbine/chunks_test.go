@@ -0,0 +1,59 @@
+package turbine
+
+import "testing"
+
+func TestChunkSpan(t *testing.T) {
+	tests := []struct {
+		setting ChunkSetting
+		width   int32
+		want    ChunkSpan
+	}{
+		{ChunkSetting{}, 15, ChunkSpan{1, 1, 1}},
+		{ChunkSetting{}, 17, ChunkSpan{2, 2, 4}},
+		{ChunkSetting{OffsetX: 4}, 13, ChunkSpan{2, 1, 2}},
+		// world coordinates, -12 is 4 blocks into its chunk
+		{ChunkSetting{OffsetX: 35, OffsetZ: -12}, 11, ChunkSpan{1, 1, 1}},
+		{ChunkSetting{OffsetZ: -12}, 13, ChunkSpan{1, 2, 2}},
+	}
+
+	for _, tc := range tests {
+		if got := tc.setting.Span(tc.width); got != tc.want {
+			t.Errorf("%+v width %d spans %+v, want %+v", tc.setting, tc.width, got, tc.want)
+		}
+	}
+}
+
+func TestSearchChunks(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 25, Y: 8, Z: 25})
+	options.Chunks = ChunkSetting{Mode: RequireOneChunk, OffsetX: 6}
+
+	result := Search(options)
+	if !result.Found || result.ChunkSpan.Chunks != 1 {
+		t.Fatalf("required one chunk, got %+v", result.ChunkSpan)
+	}
+	if width := result.Turbine.Stats().Width; width > ChunkSize-6 {
+		t.Errorf("width %d doesn't fit 6 blocks into the chunk", width)
+	}
+
+	// nothing fits 15 blocks into the chunk, preferring falls back with a note
+	options.Chunks = ChunkSetting{Mode: RequireOneChunk, OffsetX: 15}
+	if Search(options).Found {
+		t.Error("found a turbine within one block of the chunk edge")
+	}
+	options.Chunks.Mode = PreferOneChunk
+	result = Search(options)
+	if !result.Found || result.ChunkSpan.Chunks < 2 || len(result.Notes) != 1 {
+		t.Errorf("preferred one chunk: found %v spanning %+v with notes %q", result.Found, result.ChunkSpan, result.Notes)
+	}
+}
+
+func TestParseChunkMode(t *testing.T) {
+	for name, want := range map[string]ChunkMode{"ignore": IgnoreChunks, "Prefer": PreferOneChunk, "require": RequireOneChunk} {
+		if got, err := ParseChunkMode(name); err != nil || got != want {
+			t.Errorf("ParseChunkMode(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseChunkMode("region"); err == nil {
+		t.Error("unknown chunk mode accepted")
+	}
+}
@@ -0,0 +1,118 @@
+package turbine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CoilPlacement is one coil block of a layer, X and Z are offsets from the rotor shaft
+type CoilPlacement struct {
+	X, Z int32
+	Coil CoilData
+}
+
+// ringPositions walks the square ring at distance from the shaft, starting at a corner
+func ringPositions(distance int32) []CoilPlacement {
+	ring := make([]CoilPlacement, 0, 8*distance)
+	for i := -distance; i < distance; i++ {
+		ring = append(ring, CoilPlacement{X: i, Z: -distance})
+	}
+	for i := -distance; i < distance; i++ {
+		ring = append(ring, CoilPlacement{X: distance, Z: i})
+	}
+	for i := distance; i > -distance; i-- {
+		ring = append(ring, CoilPlacement{X: i, Z: distance})
+	}
+	for i := distance; i > -distance; i-- {
+		ring = append(ring, CoilPlacement{X: -distance, Z: i})
+	}
+	return ring
+}
+
+// FullCoilLayer is every block around the shaft of one layer filled with coilData, ring by ring outwards
+func (turbine Turbine) FullCoilLayer(coilData CoilData) []CoilPlacement {
+	layout := []CoilPlacement{}
+	for distance := int32(1); distance <= turbine.size.X/2; distance++ {
+		for _, placement := range ringPositions(distance) {
+			placement.Coil = coilData
+			layout = append(layout, placement)
+		}
+	}
+	return layout
+}
+
+// SetCoilLayer stacks the same layout on layers coil layers, the layout may mix materials and leave gaps
+func (turbine *Turbine) SetCoilLayer(layout []CoilPlacement, layers int32) error {
+	if layers < 0 {
+		return errors.New("Coil layer count cannot be negative")
+	}
+
+	type position struct{ x, z int32 }
+	used := map[position]bool{}
+	for _, placement := range layout {
+		if placement.X == 0 && placement.Z == 0 {
+			return errors.New("Coil cannot replace the rotor shaft")
+		}
+		if abs(placement.X) > turbine.size.X/2 || abs(placement.Z) > turbine.size.Z/2 {
+			return fmt.Errorf("Coil at %d,%d is outside the turbine", placement.X, placement.Z)
+		}
+		if used[position{placement.X, placement.Z}] {
+			return fmt.Errorf("Two coils placed at %d,%d", placement.X, placement.Z)
+		}
+		used[position{placement.X, placement.Z}] = true
+	}
+
+	turbine.addCoilLayers(layout, layers)
+	return nil
+}
+
+// addCoilLayers sums the exact per-block values of one layer and scales them by the number of layers
+func (turbine *Turbine) addCoilLayers(layout []CoilPlacement, layers int32) {
+	var layer Turbine
+	for _, placement := range layout {
+		layer.SetCoilData(placement.X, placement.Z, placement.Coil)
+	}
+
+	count := float64(layers)
+	turbine.coilSize += layer.coilSize * int64(layers)
+	turbine.inductionEfficiency += layer.inductionEfficiency * count
+	turbine.inductionEnergyExponentBonus += layer.inductionEnergyExponentBonus * count
+	turbine.inductorDragCoefficient += layer.inductorDragCoefficient * count
+}
+
+func abs(x int32) int32 {
+	return max(x, -x)
+}
+
+// EngageCoilLayers leaves only the bottom layers coil layers making power, like a controller that
+// switches the upper layers off. Idle coils still count as blocks and battery, they just don't drag.
+func (turbine *Turbine) EngageCoilLayers(layers int32) error {
+	if layers < 0 || layers > turbine.coilLayers {
+		return fmt.Errorf("Turbine can engage between 0 and %d coil layers", turbine.coilLayers)
+	}
+
+	var engaged Turbine
+	if layers == turbine.coilLayers && layers > 0 {
+		engaged.addCoilLayers(turbine.coilLayout, layers-1)
+		engaged.addCoilLayers(turbine.topCoilLayout, 1)
+	} else {
+		engaged.addCoilLayers(turbine.coilLayout, layers)
+	}
+
+	// the tick multiplies the drag by every coil, so spread the engaged drag over all of them
+	turbine.inductionEfficiency = 0
+	turbine.inductorDragCoefficient = 0
+	turbine.inductionEnergyExponentBonus = 0
+	if engaged.coilSize > 0 {
+		turbine.inductionEfficiency = engaged.inductionEfficiency / float64(engaged.coilSize)
+		turbine.inductionEnergyExponentBonus = engaged.inductionEnergyExponentBonus / float64(engaged.coilSize)
+		turbine.inductorDragCoefficient = engaged.inductorDragCoefficient * turbine.config.CoilDragMultiplier / float64(turbine.coilSize)
+	}
+	turbine.engagedCoilLayers = layers
+	return nil
+}
+
+// EngagedCoilLayers is how many coil layers make power
+func (turbine Turbine) EngagedCoilLayers() int32 {
+	return turbine.engagedCoilLayers
+}
@@ -0,0 +1,161 @@
+package turbine
+
+import "testing"
+
+func TestRingPositions(t *testing.T) {
+	for distance := int32(1); distance <= 4; distance++ {
+		ring := ringPositions(distance)
+		if len(ring) != int(8*distance) {
+			t.Errorf("ring %d has %d positions, want %d", distance, len(ring), 8*distance)
+		}
+
+		seen := map[[2]int32]bool{}
+		for _, placement := range ring {
+			if max(abs(placement.X), abs(placement.Z)) != distance {
+				t.Errorf("ring %d holds %d,%d", distance, placement.X, placement.Z)
+			}
+			seen[[2]int32{placement.X, placement.Z}] = true
+		}
+		if len(seen) != len(ring) {
+			t.Errorf("ring %d visits %d of its %d positions", distance, len(seen), len(ring))
+		}
+	}
+}
+
+// stacking one layer must match placing every block of every layer on its own
+func TestFullCoilMatchesEveryBlock(t *testing.T) {
+	for _, tc := range snapshotTurbines {
+		t.Run(tc.name, func(t *testing.T) {
+			coil := biggerReactorsCoils[tc.coil]
+			turbine := Turbine{config: &BiggerReactorsConfig}
+			turbine.Resize(Size{tc.width - 2, tc.height - 2, tc.width - 2})
+			turbine.SetFullCoil(tc.coilLayers, coil)
+
+			blocks := Turbine{config: &BiggerReactorsConfig}
+			radius := (tc.width - 2) / 2
+			for range tc.coilLayers {
+				for x := -radius; x <= radius; x++ {
+					for z := -radius; z <= radius; z++ {
+						if x != 0 || z != 0 {
+							blocks.SetCoilData(x, z, coil)
+						}
+					}
+				}
+			}
+
+			if turbine.coilSize != blocks.coilSize {
+				t.Errorf("coilSize = %d, want %d", turbine.coilSize, blocks.coilSize)
+			}
+			assertClose(t, "inductionEfficiency", turbine.inductionEfficiency, blocks.inductionEfficiency)
+			assertClose(t, "inductionEnergyExponentBonus", turbine.inductionEnergyExponentBonus, blocks.inductionEnergyExponentBonus)
+			assertClose(t, "inductorDragCoefficient", turbine.inductorDragCoefficient, blocks.inductorDragCoefficient)
+		})
+	}
+}
+
+func TestCoilLayout(t *testing.T) {
+	gold := biggerReactorsCoils["Gold"]
+	iron := biggerReactorsCoils["Iron"]
+
+	full, err := NewTurbine(&BiggerReactorsConfig, 8, 7, 2, gold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	same, err := NewTurbineWithCoilLayout(&BiggerReactorsConfig, 8, 7, 2, full.FullCoilLayer(gold))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full.SetNominalFlowRate(4000)
+	same.SetNominalFlowRate(4000)
+	assertClose(t, "FinalRPM", same.FinalRPM(), full.FinalRPM())
+
+	// gold on the inner ring, iron on the outer one
+	layout := []CoilPlacement{}
+	for _, placement := range full.FullCoilLayer(gold) {
+		if max(abs(placement.X), abs(placement.Z)) == 2 {
+			placement.Coil = iron
+		}
+		layout = append(layout, placement)
+	}
+	mixed, err := NewTurbineWithCoilLayout(&BiggerReactorsConfig, 8, 7, 2, layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDrag := 2 * (8*gold.ExtractionRate + 16*iron.ExtractionRate*2.0/3) / 48 * BiggerReactorsConfig.CoilDragMultiplier
+	assertClose(t, "inductorDragCoefficient", mixed.inductorDragCoefficient, wantDrag)
+	if got := mixed.Stats().OuterRingCoils; got != 16 {
+		t.Errorf("OuterRingCoils = %d, want 16", got)
+	}
+
+	invalid := map[string][]CoilPlacement{
+		"empty":     {},
+		"shaft":     {{X: 0, Z: 0, Coil: gold}},
+		"outside":   {{X: 3, Z: 0, Coil: gold}},
+		"duplicate": {{X: 1, Z: 1, Coil: gold}, {X: 1, Z: 1, Coil: iron}},
+	}
+	for name, layout := range invalid {
+		if _, err := NewTurbineWithCoilLayout(&BiggerReactorsConfig, 8, 7, 2, layout); err == nil {
+			t.Errorf("%s layout accepted", name)
+		}
+	}
+}
+
+func TestEngageCoilLayers(t *testing.T) {
+	for _, tc := range snapshotTurbines {
+		t.Run(tc.name, func(t *testing.T) {
+			turbine, err := NewTurbine(&BiggerReactorsConfig, tc.height, tc.width, tc.coilLayers, biggerReactorsCoils[tc.coil])
+			if err != nil {
+				t.Fatal(err)
+			}
+			turbine.SetNominalFlowRate(tc.flowRate)
+
+			// engaging every layer is the turbine as built
+			if err := turbine.EngageCoilLayers(tc.coilLayers); err != nil {
+				t.Fatal(err)
+			}
+			assertClose(t, "FinalRPM", turbine.FinalRPM(), tc.finalRPM)
+
+			// every idle layer takes drag off the rotor
+			lastRPM := tc.finalRPM
+			for layers := tc.coilLayers - 1; layers >= 0; layers-- {
+				idle := turbine
+				if err := idle.EngageCoilLayers(layers); err != nil {
+					t.Fatal(err)
+				}
+				if rpm := idle.FinalRPM(); rpm <= lastRPM {
+					t.Errorf("%d engaged layers spin at %.2f rpm, %d at %.2f", layers, rpm, layers+1, lastRPM)
+				}
+				lastRPM = idle.FinalRPM()
+				if got := idle.Stats().CoilSize; got != turbine.Stats().CoilSize {
+					t.Errorf("idling layers changed CoilSize to %d", got)
+				}
+			}
+			assertClose(t, "no engaged layers", lastRPM, turbine.FinalRPMNoLoad())
+
+			for _, layers := range []int32{-1, tc.coilLayers + 1} {
+				if err := turbine.EngageCoilLayers(layers); err == nil {
+					t.Errorf("engaged %d of %d coil layers", layers, tc.coilLayers)
+				}
+			}
+		})
+	}
+}
+
+func TestEngageCoilLayersLayout(t *testing.T) {
+	gold := biggerReactorsCoils["Gold"]
+	layout := []CoilPlacement{{X: 1, Z: 0, Coil: gold}, {X: -1, Z: 0, Coil: gold}, {X: 0, Z: 2, Coil: gold}}
+	turbine, err := NewTurbineWithCoilLayout(&BiggerReactorsConfig, 10, 7, 4, layout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fullDrag := turbine.inductorDragCoefficient
+
+	// every layer holds the same layout, so half the layers drag half as much
+	if err := turbine.EngageCoilLayers(2); err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "inductorDragCoefficient", turbine.inductorDragCoefficient, fullDrag/2)
+	if got := turbine.Stats().EngagedCoilLayers; got != 2 {
+		t.Errorf("EngagedCoilLayers = %d, want 2", got)
+	}
+}
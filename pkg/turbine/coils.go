@@ -0,0 +1,69 @@
+package turbine
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+var biggerReactorsCoils = map[string]CoilData{
+	// {efficiency, bonus, extractionRate}
+	"Iron":         {0.33, 1, 0.1},
+	"Copper":       {0.396, 1, 0.12},
+	"Osmium":       {0.462, 1, 0.12},
+	"Steel":        {0.495, 1, 0.13},
+	"Invar":        {0.495, 1, 0.14},
+	"Silver":       {0.561, 1, 0.15},
+	"Gold":         {0.66, 1, 0.175},
+	"Electrum":     {0.825, 1, 0.2},
+	"Platinum":     {0.99, 1, 0.25},
+	"Enderium":     {0.99, 1.02, 0.3},
+	"Ludicrite":    {1.15, 1.02, 0.35},
+	"AllTheModium": {1.2, 1.02, 0.4},
+	"Vibranium":    {1.35, 1.04, 0.5},
+	"Unobtanium":   {1.5, 1.06, 0.7},
+}
+
+var extremeReactorsCoils = map[string]CoilData{
+	// {efficiency, bonus, extractionRate}
+	"Iron":      {1, 1, 1},
+	"Copper":    {1.2, 1, 1.2},
+	"Osmium":    {1.2, 1, 1.2},
+	"Lead":      {1.35, 1.01, 1.35},
+	"Bronze":    {1.4, 1, 1.2},
+	"Steel":     {1.5, 1, 1.3},
+	"Invar":     {1.5, 1, 1.4},
+	"Silver":    {1.7, 1, 1.5},
+	"Gold":      {2, 1, 1.75},
+	"Electrum":  {2.5, 1, 2},
+	"Platinum":  {3, 1, 2.5},
+	"Enderium":  {3, 1.02, 3},
+	"Ludicrite": {3.5, 1.02, 3.5},
+}
+
+type CoilMaterial struct {
+	Name string `json:"name"`
+	CoilData
+}
+
+// CoilMaterials lists the coil table from the worst to the best material
+func (config Config) CoilMaterials() []CoilMaterial {
+	materials := make([]CoilMaterial, 0, len(config.Coils))
+	for name, coil := range config.Coils {
+		materials = append(materials, CoilMaterial{name, coil})
+	}
+
+	slices.SortFunc(materials, func(a, b CoilMaterial) int {
+		return cmp.Or(compareCoilData(a.CoilData, b.CoilData), strings.Compare(a.Name, b.Name))
+	})
+	return materials
+}
+
+// compareCoilData orders coils by efficiency, then bonus, then extraction rate
+func compareCoilData(a, b CoilData) int {
+	return cmp.Or(
+		cmp.Compare(a.Efficiency, b.Efficiency),
+		cmp.Compare(a.Bonus, b.Bonus),
+		cmp.Compare(a.ExtractionRate, b.ExtractionRate),
+	)
+}
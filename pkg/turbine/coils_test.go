@@ -0,0 +1,18 @@
+package turbine
+
+import "testing"
+
+func TestCoilMaterialsOrder(t *testing.T) {
+	materials := BiggerReactorsConfig.CoilMaterials()
+	if len(materials) != len(biggerReactorsCoils) {
+		t.Fatalf("got %d materials, want %d", len(materials), len(biggerReactorsCoils))
+	}
+
+	// Steel and Invar share an efficiency, so extraction rate breaks the tie
+	want := []string{"Iron", "Copper", "Osmium", "Steel", "Invar", "Silver", "Gold", "Electrum", "Platinum", "Enderium", "Ludicrite", "AllTheModium", "Vibranium", "Unobtanium"}
+	for i, name := range want {
+		if materials[i].Name != name {
+			t.Errorf("materials[%d] = %s, want %s", i, materials[i].Name, name)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package turbine
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// CoilComparison is one row of a coil material table for a turbine of fixed size
+type CoilComparison struct {
+	Material        string     `json:"material"`
+	RPM             float64    `json:"rpm"`
+	EnergyGenerated float64    `json:"energyGenerated"`
+	EnergyPerSteam  float64    `json:"energyPerSteam"`
+	CoilCost        build.Item `json:"coilCost"`
+}
+
+// CompareCoils converges the same turbine with every coil material of the config, from the worst to the best.
+// A flow rate of zero or less runs the turbine at its max flow rate.
+func CompareCoils(config *Config, height, width, coilLayers int32, flowRate int64) ([]CoilComparison, error) {
+	materials := config.CoilMaterials()
+	rows := make([]CoilComparison, 0, len(materials))
+	for _, material := range materials {
+		turbine, err := NewTurbine(config, height, width, coilLayers, material.CoilData)
+		if err != nil {
+			return nil, err
+		}
+		if flowRate > 0 {
+			turbine.SetNominalFlowRate(flowRate)
+		} else {
+			turbine.SetNominalFlowRate(turbine.maxMaxFlowRate)
+		}
+		turbine.Converge()
+
+		rows = append(rows, CoilComparison{
+			Material:        material.Name,
+			RPM:             turbine.RPM(),
+			EnergyGenerated: turbine.energyGeneratedLastTick,
+			EnergyPerSteam:  turbine.EnergyPerSteam(),
+			CoilCost:        build.Item{Name: material.Name, Count: turbine.coilSize},
+		})
+	}
+	return rows, nil
+}
+
+// every coil block is crafted from nine ingots
+const IngotsPerCoilBlock = 9
+
+// CoilUpgrade is what swapping every coil block of a turbine for another material gets
+type CoilUpgrade struct {
+	Material        string  `json:"material"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	EnergyGain      float64 `json:"energyGain"`
+	Ingots          int64   `json:"ingots"`
+	GainPerIngot    float64 `json:"gainPerIngot"`
+}
+
+// CoilUpgrades rebuilds the turbine with every material better than its coil, at the same size, coil
+// layout and flow rate, with the best RF/t gained per ingot spent first
+func (turbine Turbine) CoilUpgrades() ([]CoilUpgrade, error) {
+	current := turbine
+	current.Converge()
+	stats := current.Stats()
+
+	var upgrades []CoilUpgrade
+	for _, material := range turbine.config.CoilMaterials() {
+		if compareCoilData(material.CoilData, turbine.coil) <= 0 {
+			continue
+		}
+
+		upgraded, err := NewTurbineWithOuterRing(turbine.config, stats.Height, stats.Width, stats.CoilLayers, stats.OuterRingCoils, material.CoilData)
+		if err != nil {
+			return nil, err
+		}
+		upgraded.SetBlade(turbine.blade)
+		upgraded.SetFluid(turbine.fluid)
+		upgraded.SetNominalFlowRate(stats.FlowRate)
+		upgraded.Converge()
+
+		upgrade := CoilUpgrade{
+			Material:        material.Name,
+			EnergyGenerated: upgraded.energyGeneratedLastTick,
+			EnergyGain:      upgraded.energyGeneratedLastTick - stats.EnergyGenerated,
+			Ingots:          upgraded.coilSize * IngotsPerCoilBlock,
+		}
+		if upgrade.Ingots > 0 {
+			upgrade.GainPerIngot = upgrade.EnergyGain / float64(upgrade.Ingots)
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+
+	// stable, so materials with the same gain stay from the cheapest to the best
+	slices.SortStableFunc(upgrades, func(a, b CoilUpgrade) int {
+		return cmp.Compare(b.GainPerIngot, a.GainPerIngot)
+	})
+	return upgrades, nil
+}
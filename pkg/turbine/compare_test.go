@@ -0,0 +1,82 @@
+package turbine
+
+import "testing"
+
+func TestCompareCoils(t *testing.T) {
+	rows, err := CompareCoils(&BiggerReactorsConfig, 12, 9, 3, 1500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	materials := BiggerReactorsConfig.CoilMaterials()
+	if len(rows) != len(materials) {
+		t.Fatalf("got %d rows, want one per material (%d)", len(rows), len(materials))
+	}
+
+	for i, row := range rows {
+		if row.Material != materials[i].Name {
+			t.Errorf("rows[%d] is %s, want %s", i, row.Material, materials[i].Name)
+		}
+
+		turbine, err := NewTurbine(&BiggerReactorsConfig, 12, 9, 3, materials[i].CoilData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		turbine.SetNominalFlowRate(1500)
+		turbine.Converge()
+		stats := turbine.Stats()
+		if row.EnergyGenerated != stats.EnergyGenerated || row.RPM != stats.RPM {
+			t.Errorf("%s: got %.2f RF/t at %.2f RPM, building it alone gives %.2f at %.2f", row.Material, row.EnergyGenerated, row.RPM, stats.EnergyGenerated, stats.RPM)
+		}
+		if row.CoilCost.Count != stats.CoilSize {
+			t.Errorf("%s: costs %d coil blocks, the turbine has %d", row.Material, row.CoilCost.Count, stats.CoilSize)
+		}
+	}
+
+	if first, last := rows[0], rows[len(rows)-1]; last.EnergyGenerated <= first.EnergyGenerated {
+		t.Errorf("%s makes %.0f RF/t, no more than %s with %.0f", last.Material, last.EnergyGenerated, first.Material, first.EnergyGenerated)
+	}
+
+	if _, err := CompareCoils(&BiggerReactorsConfig, 12, 8, 3, 0); err == nil {
+		t.Error("even width accepted")
+	}
+}
+
+func TestCoilUpgrades(t *testing.T) {
+	turbine, err := NewTurbineWithOuterRing(&BiggerReactorsConfig, 12, 9, 3, 12, biggerReactorsCoils["Gold"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(1500)
+
+	upgrades, err := turbine.CoilUpgrades()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Electrum and everything after it in the coil table
+	if len(upgrades) != 7 {
+		t.Fatalf("got %d upgrades from gold, want 7", len(upgrades))
+	}
+
+	current := turbine
+	current.Converge()
+	for i, upgrade := range upgrades {
+		if upgrade.Material == "Gold" || upgrade.Material == "Iron" {
+			t.Errorf("%s offered as an upgrade from gold", upgrade.Material)
+		}
+		if upgrade.Ingots != current.coilSize*IngotsPerCoilBlock {
+			t.Errorf("%s needs %d ingots, want %d", upgrade.Material, upgrade.Ingots, current.coilSize*IngotsPerCoilBlock)
+		}
+		assertClose(t, upgrade.Material+" gain", upgrade.EnergyGain, upgrade.EnergyGenerated-current.Stats().EnergyGenerated)
+		if i > 0 && upgrade.GainPerIngot > upgrades[i-1].GainPerIngot {
+			t.Errorf("%s gains more per ingot than %s before it", upgrade.Material, upgrades[i-1].Material)
+		}
+	}
+
+	top, err := NewTurbine(&BiggerReactorsConfig, 12, 9, 3, biggerReactorsCoils["Unobtanium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgrades, _ := top.CoilUpgrades(); len(upgrades) != 0 {
+		t.Errorf("got %d upgrades from the best coil", len(upgrades))
+	}
+}
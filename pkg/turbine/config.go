@@ -0,0 +1,195 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type ModVariant int64
+
+const (
+	BiggerReactors ModVariant = iota
+	ExtremeReactors
+)
+
+var modVariantNames = map[ModVariant]string{
+	BiggerReactors:  "BiggerReactors",
+	ExtremeReactors: "ExtremeReactors",
+}
+
+func (variant ModVariant) String() string {
+	if name, ok := modVariantNames[variant]; ok {
+		return name
+	}
+	return fmt.Sprintf("ModVariant(%d)", int64(variant))
+}
+
+func (variant ModVariant) MarshalText() ([]byte, error) {
+	return []byte(variant.String()), nil
+}
+
+func (variant *ModVariant) UnmarshalText(text []byte) error {
+	parsed, err := ParseModVariant(string(text))
+	if err != nil {
+		return err
+	}
+	*variant = parsed
+	return nil
+}
+
+func ParseModVariant(name string) (ModVariant, error) {
+	for variant, variantName := range modVariantNames {
+		if strings.EqualFold(name, variantName) {
+			return variant, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown mod variant %q", name)
+}
+
+// Config holds everything that differs between mods: the physics constants, the coil table and the structural rules
+type Config struct {
+	Variant ModVariant `json:"variant"`
+
+	FlowRatePerBlock            int64   `json:"flowRatePerBlock"`
+	LatentHeat                  float64 `json:"latentHeat"`
+	TurbineMultiplier           float64 `json:"turbineMultiplier"`
+	FluidPerBladeLinerKilometre float64 `json:"fluidPerBladeLinerKilometre"`
+	RotorAxialMassPerShaft      float64 `json:"rotorAxialMassPerShaft"`
+	RotorAxialMassPerBlade      float64 `json:"rotorAxialMassPerBlade"`
+	CoilDragMultiplier          float64 `json:"coilDragMultiplier"`
+	BatterySizePerCoilBlock     float64 `json:"batterySizePerCoilBlock"`
+	TankVolumePerBlock          float64 `json:"tankVolumePerBlock"`
+	FrictionDragMultiplier      float64 `json:"frictionDragMultiplier"`
+	AerodynamicDragMultiplier   float64 `json:"aerodynamicDragMultiplier"`
+
+	Coils map[string]CoilData `json:"coils"`
+	// rotor blade types, turbines use DefaultBladeName unless another one is picked
+	Blades           map[string]BladeData `json:"blades"`
+	DefaultBladeName string               `json:"defaultBlade"`
+	// fluids other than steam the turbine can run on, steam is always there with LatentHeat
+	Fluids map[string]FluidData `json:"fluids"`
+
+	// outer dimensions, including casing
+	MinWidth  int32 `json:"minWidth"`
+	MinHeight int32 `json:"minHeight"`
+	MaxWidth  int32 `json:"maxWidth"`
+	MaxHeight int32 `json:"maxHeight"`
+
+	// the rotor shaft runs the whole inner height, zero means no limit
+	MaxShaftLength int32 `json:"maxShaftLength"`
+	// bearings at the ends of the shaft, each carrying up to ShaftLengthPerBearing shaft blocks (zero for any length)
+	Bearings              int32 `json:"bearings"`
+	ShaftLengthPerBearing int32 `json:"shaftLengthPerBearing"`
+
+	// rotors spinning faster than this break, zero means no limit
+	MaxSafeRPM float64 `json:"maxSafeRPM"`
+
+	// the coil efficiency peaks at the grid frequency times 60 rpm and at EfficiencyPeaks-1 halvings below it,
+	// zero uses EffectiveGridFrequency and EfficiencyPeaks
+	GridFrequency   float64 `json:"gridFrequency,omitempty"`
+	EfficiencyPeaks int32   `json:"efficiencyPeaks,omitempty"`
+
+	// coils a game stage filter took out, to tell a locked material from a typo
+	lockedCoils map[string]CoilData
+}
+
+var BiggerReactorsConfig = Config{
+	Variant: BiggerReactors,
+
+	FlowRatePerBlock:            5000,
+	LatentHeat:                  4.0,
+	TurbineMultiplier:           2.5,
+	FluidPerBladeLinerKilometre: 20.0,
+	RotorAxialMassPerShaft:      100.0,
+	RotorAxialMassPerBlade:      100.0,
+	CoilDragMultiplier:          10.0,
+	BatterySizePerCoilBlock:     300000,
+	TankVolumePerBlock:          10000,
+	FrictionDragMultiplier:      5.0e-4,
+	AerodynamicDragMultiplier:   5.0e-4,
+
+	Coils:            biggerReactorsCoils,
+	Blades:           biggerReactorsBlades,
+	DefaultBladeName: "Basic",
+
+	MinWidth:  5,
+	MinHeight: 4,
+	MaxWidth:  32,
+	MaxHeight: 192,
+
+	Bearings: 2,
+}
+
+// Extreme Reactors kept the original Big Reactors coil values, whose extraction rates
+// are ten times larger, so the drag multiplier is scaled down to match
+var ExtremeReactorsConfig = Config{
+	Variant: ExtremeReactors,
+
+	FlowRatePerBlock:            5000,
+	LatentHeat:                  4.0,
+	TurbineMultiplier:           2.5,
+	FluidPerBladeLinerKilometre: 20.0,
+	RotorAxialMassPerShaft:      100.0,
+	RotorAxialMassPerBlade:      100.0,
+	CoilDragMultiplier:          1.0,
+	BatterySizePerCoilBlock:     300000,
+	TankVolumePerBlock:          10000,
+	FrictionDragMultiplier:      5.0e-4,
+	AerodynamicDragMultiplier:   5.0e-4,
+
+	Coils:            extremeReactorsCoils,
+	Blades:           extremeReactorsBlades,
+	DefaultBladeName: "Basic",
+
+	MinWidth:  5,
+	MinHeight: 4,
+	MaxWidth:  32,
+	MaxHeight: 32,
+
+	Bearings: 2,
+}
+
+// ShaftLimit is the longest legal rotor shaft under both shaft rules, zero if there is no limit
+func (config Config) ShaftLimit() int32 {
+	limit := config.MaxShaftLength
+	if config.ShaftLengthPerBearing > 0 {
+		// in int64, a loaded profile could have bearings carrying more than an int32 of shaft in total
+		bearingLimit := min(int64(config.Bearings)*int64(config.ShaftLengthPerBearing), math.MaxInt32)
+		if limit == 0 || bearingLimit < int64(limit) {
+			limit = int32(bearingLimit)
+		}
+	}
+	return limit
+}
+
+// Clone copies the config so it can be modified without touching the bundled tables
+func (config Config) Clone() *Config {
+	coils := make(map[string]CoilData, len(config.Coils))
+	for name, coil := range config.Coils {
+		coils[name] = coil
+	}
+	config.Coils = coils
+	blades := make(map[string]BladeData, len(config.Blades))
+	for name, blade := range config.Blades {
+		blades[name] = blade
+	}
+	config.Blades = blades
+	fluids := make(map[string]FluidData, len(config.Fluids))
+	for name, fluid := range config.Fluids {
+		fluids[name] = fluid
+	}
+	config.Fluids = fluids
+	return &config
+}
+
+func ConfigFor(variant ModVariant) (*Config, error) {
+	switch variant {
+	case BiggerReactors:
+		return &BiggerReactorsConfig, nil
+	case ExtremeReactors:
+		return &ExtremeReactorsConfig, nil
+	default:
+		return nil, fmt.Errorf("No config for %s", variant)
+	}
+}
@@ -0,0 +1,104 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseModVariant(t *testing.T) {
+	for _, variant := range []ModVariant{BiggerReactors, ExtremeReactors} {
+		parsed, err := ParseModVariant(variant.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != variant {
+			t.Errorf("ParseModVariant(%q) = %s", variant.String(), parsed)
+		}
+	}
+
+	if _, err := ParseModVariant("BigReactors"); err == nil {
+		t.Error("expected an error for an unknown variant")
+	}
+}
+
+func TestVariantSizeRules(t *testing.T) {
+	tests := []struct {
+		config  *Config
+		height  int32
+		wantErr bool
+	}{
+		{&BiggerReactorsConfig, 32, false},
+		{&BiggerReactorsConfig, 48, false},
+		{&ExtremeReactorsConfig, 32, false},
+		{&ExtremeReactorsConfig, 48, true},
+	}
+
+	for _, tc := range tests {
+		_, err := NewTurbine(tc.config, tc.height, 9, 2, tc.config.Coils["Iron"])
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s height %d: error = %v, wantErr %v", tc.config.Variant, tc.height, err, tc.wantErr)
+		}
+	}
+}
+
+func TestVariantCoilDragMatches(t *testing.T) {
+	// both tables describe the same coils, only scaled differently
+	for name, coil := range extremeReactorsCoils {
+		biggerCoil, ok := biggerReactorsCoils[name]
+		if !ok {
+			continue
+		}
+		extreme := coil.ExtractionRate * ExtremeReactorsConfig.CoilDragMultiplier
+		bigger := biggerCoil.ExtractionRate * BiggerReactorsConfig.CoilDragMultiplier
+		if math.Abs(extreme-bigger) > 1e-9 {
+			t.Errorf("%s drag %.3f in Extreme Reactors, %.3f in Bigger Reactors", name, extreme, bigger)
+		}
+	}
+}
+
+func TestShaftRules(t *testing.T) {
+	shortShaft := BiggerReactorsConfig.Clone()
+	shortShaft.MaxShaftLength = 20
+
+	weakBearings := BiggerReactorsConfig.Clone()
+	weakBearings.ShaftLengthPerBearing = 12
+
+	tests := []struct {
+		name      string
+		config    *Config
+		height    int32
+		wantErr   bool
+		wantLimit int32
+	}{
+		{"no limit", &BiggerReactorsConfig, 100, false, 0},
+		{"shaft at the limit", shortShaft, 22, false, 20},
+		{"shaft too long", shortShaft, 23, true, 20},
+		{"bearings carry it", weakBearings, 26, false, 24},
+		{"bearings can't carry it", weakBearings, 27, true, 24},
+	}
+
+	for _, tc := range tests {
+		_, err := NewTurbine(tc.config, tc.height, 9, 2, tc.config.Coils["Iron"])
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+		if limit := tc.config.ShaftLimit(); limit != tc.wantLimit {
+			t.Errorf("%s: ShaftLimit() = %d, want %d", tc.name, limit, tc.wantLimit)
+		}
+	}
+}
+
+func TestSearchRespectsShaftLimit(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.MaxShaftLength = 10
+
+	options := NewOptions(energyFitness, noConstraints, config.Coils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 40, Z: 9})
+	options.Config = config
+	best, err := FindOptimalTurbine(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height := best.Stats().Height; height > 12 {
+		t.Errorf("height %d needs a shaft longer than 10", height)
+	}
+}
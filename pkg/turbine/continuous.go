@@ -0,0 +1,92 @@
+package turbine
+
+// geometryKey is one candidate the search builds, before the flow rate is picked
+type geometryKey struct {
+	height, width, coilLayers int32
+	outerRingCoils            int64
+	engagedCoilLayers         int32
+}
+
+// cachedGeometry is the fittest flow rate found for a geometry, and how many flow rates got through each
+// filter so a search made of cached geometries can still tell which option ruled them out
+type cachedGeometry struct {
+	fitness                   float64
+	flowRate                  int64
+	flowRates, safe, nearPeak int64
+}
+
+// a cache this full is dropped and refilled, that's around 20 MB
+const maxCachedGeometries = 200000
+
+// evaluationCache remembers every geometry a search finished, a nil cache holds nothing
+type evaluationCache struct {
+	geometries map[geometryKey]cachedGeometry
+}
+
+func (cache *evaluationCache) lookup(key geometryKey) (cachedGeometry, bool) {
+	if cache == nil {
+		return cachedGeometry{}, false
+	}
+	cached, ok := cache.geometries[key]
+	return cached, ok
+}
+
+func (cache *evaluationCache) store(key geometryKey, geometry cachedGeometry) {
+	if cache == nil {
+		return
+	}
+	if len(cache.geometries) >= maxCachedGeometries {
+		clear(cache.geometries)
+	}
+	cache.geometries[key] = geometry
+}
+
+// evaluationKey is every option that changes how a geometry scores, the room and coil layer bounds only change
+// which geometries are tried
+type evaluationKey struct {
+	config          *Config
+	coil            CoilData
+	blade           BladeMaterial
+	fluid           FluidMaterial
+	flow            FlowSetting
+	limitNoLoadRPM  bool
+	targetPeak      int32
+	searchPrecision Precision
+}
+
+func (options Options) evaluationKey() evaluationKey {
+	return evaluationKey{options.Config, options.Coil, options.Blade, options.Fluid, options.Flow, options.LimitNoLoadRPM, options.TargetPeak, options.SearchPrecision}
+}
+
+// Continuous searches again and again as the options change, like while a size slider is dragged. Each geometry's
+// fittest flow rate is kept, so a search only evaluates the geometries the earlier ones didn't finish. The cache is
+// dropped when an option that changes the scores does, but the fitness and constraints can't be compared, call
+// Reset when they change.
+type Continuous struct {
+	cache evaluationCache
+	key   evaluationKey
+}
+
+func NewContinuous() *Continuous {
+	return &Continuous{cache: evaluationCache{geometries: map[geometryKey]cachedGeometry{}}}
+}
+
+// Search is Search with the cached geometries filled in, a cancelled search keeps the geometries it finished
+func (continuous *Continuous) Search(options Options) SearchResult {
+	if key := options.evaluationKey(); key != continuous.key {
+		continuous.Reset()
+		continuous.key = key
+	}
+	options.cache = &continuous.cache
+	return Search(options)
+}
+
+// Reset forgets every cached geometry
+func (continuous *Continuous) Reset() {
+	clear(continuous.cache.geometries)
+}
+
+// CachedGeometries is how many geometries the next search can skip at most
+func (continuous *Continuous) CachedGeometries() int {
+	return len(continuous.cache.geometries)
+}
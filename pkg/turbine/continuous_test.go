@@ -0,0 +1,54 @@
+package turbine
+
+import "testing"
+
+func TestContinuousMatchesSearch(t *testing.T) {
+	continuous := NewContinuous()
+	for _, room := range []Size{{X: 9, Y: 10, Z: 9}, {X: 11, Y: 14, Z: 11}, {X: 7, Y: 12, Z: 7}, {X: 11, Y: 14, Z: 11}} {
+		options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1000}, room)
+		fresh := Search(options)
+		cached := continuous.Search(options)
+
+		if cached.Turbine.Stats() != fresh.Turbine.Stats() {
+			t.Errorf("%v: continuous search found %+v, a fresh one %+v", room, cached.Turbine.Stats(), fresh.Turbine.Stats())
+		}
+		if cached.Evaluations > fresh.Evaluations {
+			t.Errorf("%v: continuous search ran %d evaluations, a fresh one %d", room, cached.Evaluations, fresh.Evaluations)
+		}
+	}
+
+	// the last room was searched already
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 11, Y: 14, Z: 11})
+	if result := continuous.Search(options); result.Evaluations != 0 || result.Reused == 0 {
+		t.Errorf("repeated search ran %d evaluations and reused %d geometries", result.Evaluations, result.Reused)
+	}
+
+	// another coil scores every geometry differently
+	options.Coil = biggerReactorsCoils["Enderium"]
+	if result := continuous.Search(options); result.Reused != 0 {
+		t.Errorf("search with another coil reused %d geometries", result.Reused)
+	}
+}
+
+func TestContinuousKeepsCutShortSearch(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Iron"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 9, Y: 12, Z: 9})
+	fresh := Search(options)
+
+	continuous := NewContinuous()
+	options.MaxEvaluations = fresh.Evaluations / 2
+	if result := continuous.Search(options); !result.Truncated {
+		t.Fatal("search with half the evaluations wasn't cut short")
+	}
+	if continuous.CachedGeometries() == 0 {
+		t.Fatal("cut short search cached nothing")
+	}
+
+	options.MaxEvaluations = 0
+	resumed := continuous.Search(options)
+	if resumed.Turbine.Stats() != fresh.Turbine.Stats() {
+		t.Errorf("resumed search found %+v, a fresh one %+v", resumed.Turbine.Stats(), fresh.Turbine.Stats())
+	}
+	if resumed.Evaluations >= fresh.Evaluations {
+		t.Errorf("resumed search ran %d evaluations, a fresh one %d", resumed.Evaluations, fresh.Evaluations)
+	}
+}
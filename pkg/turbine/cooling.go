@@ -0,0 +1,54 @@
+package turbine
+
+// flow rate step for the turbine search when there is more steam than one turbine can take
+const coolingFlowStep = 1000
+
+// CoolingComparison answers whether a reactor makes more power cooled passively or making steam for turbines
+type CoolingComparison struct {
+	PassiveEnergy float64 `json:"passiveEnergy"`
+	SteamFlow     int64   `json:"steamFlow"`
+	// copies of the best turbine it takes to use all the steam, the last one may run on what is left over
+	Turbines     int64   `json:"turbines"`
+	ActiveEnergy float64 `json:"activeEnergy"`
+	// active over passive RF/t, above 1 the turbines pay off
+	Gain    float64 `json:"gain"`
+	WorthIt bool    `json:"worthIt"`
+}
+
+// CompareCooling searches for the best turbine under the steam flow of the actively cooled reactor and builds
+// as many as it takes to use all of it, against passiveEnergy RF/t from the same reactor cooled passively
+func CompareCooling(options Options, passiveEnergy float64, steamFlow int64) (CoolingComparison, SearchResult) {
+	comparison := CoolingComparison{PassiveEnergy: passiveEnergy, SteamFlow: steamFlow}
+	options.Flow = FlowSetting{Variant: FindBestUnderFlow, Value: steamFlow}
+	result := Search(options)
+	if !result.Found {
+		// no turbine in the room gets close to the steam flow, find the best one at any flow and build copies
+		options.Flow = FlowSetting{Variant: FindBestFlow, Value: coolingFlowStep}
+		result = Search(options)
+	}
+	if !result.Found {
+		return comparison, result
+	}
+
+	best := result.Turbine
+	stats := best.Stats()
+	if stats.FlowRate > 0 {
+		comparison.Turbines = steamFlow / stats.FlowRate
+		comparison.ActiveEnergy = float64(comparison.Turbines) * stats.EnergyGenerated
+
+		// one more copy takes the rest at a lower flow rate
+		if leftover := steamFlow % stats.FlowRate; leftover > 0 {
+			extra := best
+			extra.SetNominalFlowRate(leftover)
+			extra.Converge()
+			comparison.Turbines++
+			comparison.ActiveEnergy += extra.Stats().EnergyGenerated
+		}
+	}
+
+	if passiveEnergy > 0 {
+		comparison.Gain = comparison.ActiveEnergy / passiveEnergy
+	}
+	comparison.WorthIt = comparison.ActiveEnergy > passiveEnergy
+	return comparison, result
+}
@@ -0,0 +1,34 @@
+package turbine
+
+import "testing"
+
+func TestCompareCooling(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{}, Size{X: 9, Y: 12, Z: 9})
+
+	comparison, result := CompareCooling(options, 10000, 4000)
+	if !result.Found {
+		t.Fatal("no turbine found")
+	}
+	stats := result.Turbine.Stats()
+	if comparison.Turbines != 1 || stats.FlowRate > 4000 {
+		t.Errorf("got %d turbines at %d mB/t for 4000 mB/t of steam, want one", comparison.Turbines, stats.FlowRate)
+	}
+	assertClose(t, "ActiveEnergy", comparison.ActiveEnergy, stats.EnergyGenerated)
+	assertClose(t, "Gain", comparison.Gain, comparison.ActiveEnergy/10000)
+	if comparison.WorthIt != (comparison.ActiveEnergy > 10000) {
+		t.Errorf("WorthIt = %v with %.0f RF/t active against 10000 passive", comparison.WorthIt, comparison.ActiveEnergy)
+	}
+
+	// more steam than one turbine of the room takes needs copies of it
+	big, result := CompareCooling(options, 10000, 1000000)
+	if !result.Found {
+		t.Fatal("no turbine found")
+	}
+	perTurbine := result.Turbine.Stats().FlowRate
+	if want := (int64(1000000) + perTurbine - 1) / perTurbine; big.Turbines != want {
+		t.Errorf("got %d turbines at %d mB/t each for 1000000 mB/t, want %d", big.Turbines, perTurbine, want)
+	}
+	if !big.WorthIt || big.ActiveEnergy <= comparison.ActiveEnergy {
+		t.Errorf("a million mB/t only makes %.0f RF/t", big.ActiveEnergy)
+	}
+}
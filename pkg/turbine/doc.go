@@ -0,0 +1,8 @@
+// Package turbine models Bigger Reactors and Extreme Reactors turbines: building a design, running it
+// to its steady state and searching for the best design that fits a room.
+//
+// New, Optimize, Config and the types they take and return are the public API. It follows semantic
+// versioning from Version, releases are tagged v<Version> on the module: anything exported may gain
+// fields and functions in a minor release, and only a major release removes or changes them.
+// Other exported names are used by the site and may change with it.
+package turbine
@@ -0,0 +1,73 @@
+package turbine
+
+import "math"
+
+// efficiencyCurve is the coil efficiency over rpm for a grid frequency and number of peaks
+type efficiencyCurve struct {
+	frequency  float64
+	peakRPM    float64
+	logPeakRPM float64
+	// below it the efficiency stays at its floor
+	minRPM float64
+	peaks  int32
+}
+
+func (config Config) efficiencyCurve() efficiencyCurve {
+	frequency := config.GridFrequency
+	if frequency == 0 {
+		frequency = EffectiveGridFrequency
+	}
+	peaks := config.EfficiencyPeaks
+	if peaks == 0 {
+		peaks = int32(EfficiencyPeaks)
+	}
+	peakRPM := frequency * 60
+	return efficiencyCurve{
+		frequency:  frequency,
+		peakRPM:    peakRPM,
+		logPeakRPM: math.Log(peakRPM),
+		minRPM:     peakRPM / math.Pow(2, float64(peaks)-0.5),
+		peaks:      peaks,
+	}
+}
+
+// EfficiencyPeakRPMs lists the rpms where the coil efficiency reaches 100%, highest first
+func (config Config) EfficiencyPeakRPMs() []float64 {
+	return config.efficiencyCurve().peakRPMs()
+}
+
+func (curve efficiencyCurve) peakRPMs() []float64 {
+	peaks := []float64{}
+	for i := range curve.peaks {
+		peaks = append(peaks, curve.peakRPM/math.Pow(2, float64(i)))
+	}
+	return peaks
+}
+
+// efficiency is how well the coils turn torque into energy at rpm, it peaks at the grid frequency and every
+// halving of it down to the last peak
+func (curve efficiencyCurve) efficiency(rpm float64) float64 {
+	if rpm < curve.minRPM {
+		return 0.5
+	} else if rpm > curve.peakRPM {
+		numerator := -(rpm - curve.peakRPM) * (rpm - curve.peakRPM)
+		denominator := 8 * curve.frequency * curve.peakRPM
+		possibleEfficiency := numerator / denominator
+		return max(0, possibleEfficiency+1)
+	}
+	logValue := -2*((math.Log(rpm)-curve.logPeakRPM)/log2) + 1
+	return -0.25*math.Cos(logValue*math.Pi) + 0.75
+}
+
+// nearPeak tells if rpm is closer to the peak-th highest peak than to the others on a log scale, so within half
+// an octave of it. Peak zero takes any rpm.
+func (curve efficiencyCurve) nearPeak(rpm float64, peak int32) bool {
+	if peak == 0 {
+		return true
+	}
+	if peak < 0 || peak > curve.peaks {
+		return false
+	}
+	center := curve.peakRPM / math.Pow(2, float64(peak-1))
+	return rpm >= center/math.Sqrt2 && rpm < center*math.Sqrt2
+}
@@ -0,0 +1,99 @@
+package turbine
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEfficiencyCurvePeaks(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency float64
+		peaks     int32
+		want      []float64
+	}{
+		{"mod default", 0, 0, []float64{1800, 900}},
+		{"same as default", 30, 2, []float64{1800, 900}},
+		{"50 Hz grid", 50, 2, []float64{3000, 1500}},
+		{"three peaks", 25, 3, []float64{1500, 750, 375}},
+	}
+
+	for _, tc := range tests {
+		config := BiggerReactorsConfig
+		config.GridFrequency = tc.frequency
+		config.EfficiencyPeaks = tc.peaks
+
+		peaks := config.EfficiencyPeakRPMs()
+		if !reflect.DeepEqual(peaks, tc.want) {
+			t.Errorf("%s: peaks = %v, want %v", tc.name, peaks, tc.want)
+		}
+		curve := config.efficiencyCurve()
+		for _, peak := range peaks {
+			if efficiency := curve.efficiency(peak); math.Abs(efficiency-1) > 1e-9 {
+				t.Errorf("%s: efficiency at the %g rpm peak is %g", tc.name, peak, efficiency)
+			}
+		}
+		// half way between the last two peaks on a log scale is the low point
+		if len(peaks) >= 2 {
+			valley := math.Sqrt(peaks[0] * peaks[1])
+			if efficiency := curve.efficiency(valley); math.Abs(efficiency-0.5) > 1e-9 {
+				t.Errorf("%s: efficiency between the peaks at %g rpm is %g", tc.name, valley, efficiency)
+			}
+		}
+		// below the last peak's valley it bottoms out
+		if efficiency := curve.efficiency(peaks[len(peaks)-1] / 2); efficiency != 0.5 {
+			t.Errorf("%s: efficiency far below the peaks is %g", tc.name, efficiency)
+		}
+	}
+}
+
+func TestGridFrequencyMovesRecommendations(t *testing.T) {
+	fast := BiggerReactorsConfig.Clone()
+	fast.GridFrequency = 50
+
+	turbine, err := New(fast, Design{Width: 9, Height: 14, CoilLayers: 3, Coil: "Enderium", FlowRate: 40000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := turbine.Result()
+	if len(result.PeakFlows) != 2 || result.PeakFlows[0].RPM != 3000 {
+		t.Errorf("peak flows = %+v, want the 3000 rpm peak first", result.PeakFlows)
+	}
+
+	stock, err := New(nil, Design{Width: 9, Height: 14, CoilLayers: 3, Coil: "Enderium", FlowRate: 40000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// about 1400 rpm sits between the stock peaks but close under the 1500 rpm one of a 50 Hz grid
+	if stock.Stats().CoilEfficiency > 0.7 || turbine.Stats().CoilEfficiency < 0.9 {
+		t.Errorf("coil efficiency %g on the stock curve and %g on a 50 Hz grid", stock.Stats().CoilEfficiency, turbine.Stats().CoilEfficiency)
+	}
+}
+
+func TestNearPeak(t *testing.T) {
+	curve := BiggerReactorsConfig.efficiencyCurve()
+	tests := []struct {
+		rpm  float64
+		peak int32
+		want bool
+	}{
+		{100, 0, true},
+		{1800, 1, true},
+		{1300, 1, true},
+		{1250, 1, false},
+		{2500, 1, true},
+		{2600, 1, false},
+		{900, 2, true},
+		{1250, 2, true},
+		{1300, 2, false},
+		{300, 2, false},
+		{900, 3, false},
+		{900, -1, false},
+	}
+	for _, tc := range tests {
+		if got := curve.nearPeak(tc.rpm, tc.peak); got != tc.want {
+			t.Errorf("nearPeak(%g, %d) = %v, want %v", tc.rpm, tc.peak, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package turbine
+
+import (
+	"time"
+)
+
+// SearchEstimate is how much work a search would do, counted without running it
+type SearchEstimate struct {
+	// sizes, coil layer counts and ring fills tried
+	Geometries int64 `json:"geometries"`
+	// flow rates settled over all geometries, an upper bound when constraints rule some geometries out
+	Evaluations int64 `json:"evaluations"`
+	// the widths of all geometries added up, building a turbine takes about as long as it is wide
+	widths int64
+}
+
+// SearchCost is what searching takes on this machine, from CalibrateSearch
+type SearchCost struct {
+	// building a turbine, per block of width
+	PerWidth      time.Duration
+	PerEvaluation time.Duration
+}
+
+// EstimateSearch counts what Search(options) would evaluate. A chunk preference counts both passes
+// and a target rpm counts one flow rate per geometry, so it is an upper bound in those cases.
+func EstimateSearch(options Options) SearchEstimate {
+	if options.Chunks.Mode != PreferOneChunk {
+		return estimate(options)
+	}
+
+	options.Chunks.Mode = RequireOneChunk
+	first := estimate(options)
+	options.Chunks.Mode = IgnoreChunks
+	second := estimate(options)
+	return SearchEstimate{first.Geometries + second.Geometries, first.Evaluations + second.Evaluations, first.widths + second.widths}
+}
+
+func estimate(options Options) SearchEstimate {
+	var estimate SearchEstimate
+	bounds, _ := options.bounds()
+	fluid := options.Config.DefaultFluid()
+	if options.Fluid != (FluidMaterial{}) {
+		fluid = options.Fluid
+	}
+
+	// int like in search, so the last width of a config allowing the widest int32 doesn't wrap around
+	for wideWidth := int(bounds.minWidth); wideWidth <= int(bounds.maxWidth); wideWidth += 2 {
+		width := int32(wideWidth)
+		if !options.Chunks.allows(width) {
+			continue
+		}
+		// only the intake matters to the flow rates, and it only depends on the width
+		shell := Turbine{config: options.Config, size: Size{width - 2, 0, width - 2}, fluid: fluid, curve: options.Config.efficiencyCurve()}
+		var err error
+		shell.maxMaxFlowRate, err = shell.intakeFlowRate()
+		if err != nil {
+			// NewTurbine turns these down too
+			continue
+		}
+		flowRates := int64(1)
+		if options.Flow.Variant != UseTargetRPM {
+			// only FindBestUnderFlow picks rates by fitness and it tries as many whatever the fitness
+			flowRates = 0
+			options.Flow.tryFlowRates(shell, func(int64) (float64, bool) {
+				flowRates++
+				return 0, true
+			})
+		}
+		rings := int64(len(options.outerRingChoices(width)))
+
+		for height := bounds.minHeight; height <= bounds.maxHeight; height++ {
+			minCoilLayers, maxCoilLayers := options.coilLayerRange(height)
+			if maxCoilLayers < minCoilLayers {
+				continue
+			}
+			geometries := int64(maxCoilLayers-minCoilLayers+1) * rings
+			estimate.Geometries += geometries
+			estimate.widths += geometries * int64(width)
+			estimate.Evaluations += geometries * flowRates
+		}
+	}
+
+	if options.MaxEvaluations > 0 && estimate.Evaluations > options.MaxEvaluations {
+		// the search stops partway, about as far through the geometries as through the evaluations
+		estimate.widths = estimate.widths * options.MaxEvaluations / estimate.Evaluations
+		estimate.Evaluations = options.MaxEvaluations
+	}
+	return estimate
+}
+
+// Duration is how long the search takes at cost, capped by the options' time budget
+func (estimate SearchEstimate) Duration(cost SearchCost, options Options) time.Duration {
+	duration := time.Duration(estimate.widths)*cost.PerWidth + time.Duration(estimate.Evaluations)*cost.PerEvaluation
+	if options.TimeBudget > 0 {
+		duration = min(duration, options.TimeBudget)
+	}
+	return duration
+}
+
+// CalibrateSearch times building turbines and settling them the way the search does
+// to find what each costs on this machine
+func CalibrateSearch() SearchCost {
+	config := BiggerReactorsConfig
+	coil := config.Coils["Enderium"]
+	var cost SearchCost
+
+	// the first round pays for warming up, in a browser that is most of it
+	for range 2 {
+		start := time.Now()
+		var widths int64
+		var built Turbine
+		for width := int32(5); width <= 15; width += 2 {
+			turbine, err := NewTurbine(&config, 20, width, 4, coil)
+			if err != nil {
+				continue
+			}
+			widths += int64(width)
+			built = turbine
+		}
+		if widths == 0 {
+			return SearchCost{}
+		}
+		cost.PerWidth = time.Since(start) / time.Duration(widths)
+
+		const settles = 1000
+		start = time.Now()
+		for flowRate := range settles {
+			built.SetNominalFlowRate(int64(flowRate) * 20)
+			built.Settle()
+		}
+		cost.PerEvaluation = time.Since(start) / settles
+	}
+	return cost
+}
@@ -0,0 +1,35 @@
+package turbine
+
+import "testing"
+
+func TestEstimateSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Options)
+	}{
+		{"max flow", func(options *Options) {}},
+		{"best under flow", func(options *Options) { options.Flow = FlowSetting{Variant: FindBestUnderFlow, Value: 20000} }},
+		{"best flow", func(options *Options) { options.Flow = FlowSetting{Variant: FindBestFlow, Value: 5000} }},
+		{"near flow", func(options *Options) { options.Flow = FlowSetting{Variant: FindBestNearFlow, Value: 20000} }},
+		{"partial rings", func(options *Options) { options.PartialRings = true }},
+		{"coil layers", func(options *Options) { options.MinCoilLayers, options.MaxCoilLayers = 2, 4 }},
+		{"one chunk", func(options *Options) { options.Chunks = ChunkSetting{Mode: RequireOneChunk, OffsetX: 10} }},
+		{"shaft limit", func(options *Options) {
+			options.Config = BiggerReactorsConfig.Clone()
+			options.Config.MaxShaftLength = 8
+		}},
+		{"evaluation budget", func(options *Options) { options.MaxEvaluations = 100 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 11, Y: 14, Z: 11})
+			test.modify(&options)
+
+			estimate := EstimateSearch(options)
+			if got := Search(options).Evaluations; estimate.Evaluations != got {
+				t.Errorf("estimated %d evaluations, the search made %d", estimate.Evaluations, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package turbine_test
+
+import (
+	"fmt"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func ExampleNew() {
+	built, err := turbine.New(nil, turbine.Design{Width: 9, Height: 14, CoilLayers: 3, Coil: "Enderium", FlowRate: 10000})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	stats := built.Stats()
+	fmt.Printf("%dx%d at %d mB/t: %.0f rpm, %.0f RF/t\n", stats.Width, stats.Height, stats.FlowRate, stats.RPM, stats.EnergyGenerated)
+	// Output:
+	// 9x14 at 10000 mB/t: 361 rpm, 62037 RF/t
+}
+
+func ExampleNew_validation() {
+	_, err := turbine.New(nil, turbine.Design{Width: 10, Height: 14, CoilLayers: 3, Coil: "Enderum", FlowRate: 2000})
+	fmt.Println(err)
+	// Output:
+	// Width 10 is even, turbines have to be odd, did you mean 9?
+}
+
+func ExampleOptimize() {
+	energy := func(candidate turbine.Turbine) float64 { return candidate.Stats().EnergyGenerated }
+	any := func(turbine.Turbine) bool { return true }
+	options := turbine.NewOptions(energy, any, turbine.BiggerReactorsConfig.Coils["Gold"],
+		turbine.FlowSetting{Variant: turbine.FindBestUnderFlow, Value: 10000}, turbine.Size{X: 11, Y: 16, Z: 11})
+
+	best, err := turbine.Optimize(options)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	stats := best.Stats()
+	fmt.Printf("%dx%d with %d coil layers at %d mB/t: %.0f RF/t\n", stats.Width, stats.Height, stats.CoilLayers, stats.FlowRate, stats.EnergyGenerated)
+	// Output:
+	// 9x8 with 2 coil layers at 10000 mB/t: 64882 RF/t
+}
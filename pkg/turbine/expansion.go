@@ -0,0 +1,191 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// an expansion plan gives up after this many upgrades, every one adds at least a block of height or a coil layer
+// so a plan can't run longer than a turbine is tall anyway
+const maxExpansionSteps = 200
+
+// UpgradeAction is one change to a built turbine that doesn't take it down
+type UpgradeAction int64
+
+const (
+	AddCoilLayer UpgradeAction = iota
+	ExtendHeight
+	SwapCoil
+)
+
+var upgradeActionNames = map[UpgradeAction]string{
+	AddCoilLayer: "addCoilLayer",
+	ExtendHeight: "extendHeight",
+	SwapCoil:     "swapCoil",
+}
+
+func (action UpgradeAction) String() string {
+	if name, ok := upgradeActionNames[action]; ok {
+		return name
+	}
+	return fmt.Sprintf("UpgradeAction(%d)", int64(action))
+}
+
+func (action UpgradeAction) MarshalText() ([]byte, error) {
+	return []byte(action.String()), nil
+}
+
+// ExpansionStep is one upgrade of a plan and the turbine after it
+type ExpansionStep struct {
+	Action UpgradeAction `json:"action"`
+	// the material a SwapCoil puts in
+	Coil            string  `json:"coil,omitempty"`
+	Height          int32   `json:"height"`
+	CoilLayers      int32   `json:"coilLayers"`
+	FlowRate        int64   `json:"flowRate"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	EnergyGain      float64 `json:"energyGain"`
+	// blocks placed, removed or swapped for another
+	BlocksChanged int64   `json:"blocksChanged"`
+	GainPerBlock  float64 `json:"gainPerBlock"`
+}
+
+type ExpansionPlan struct {
+	StartEnergy  float64         `json:"startEnergy"`
+	TargetEnergy float64         `json:"targetEnergy"`
+	Reached      bool            `json:"reached"`
+	Steps        []ExpansionStep `json:"steps"`
+}
+
+// PlanExpansion upgrades the turbine in place until it makes targetEnergy RF/t, taking the upgrade with the most
+// RF/t gained per block changed each time. It may grow up to maxHeight and every step runs at the flow rate making
+// the most energy, up to steamLimit mB/t unless that is 0. The plan stops early once no upgrade makes more energy.
+func (turbine Turbine) PlanExpansion(targetEnergy float64, maxHeight int32, steamLimit int64) (ExpansionPlan, error) {
+	if targetEnergy <= 0 || math.IsNaN(targetEnergy) || math.IsInf(targetEnergy, 0) {
+		return ExpansionPlan{}, ValidationError{"targetEnergy", fmt.Sprintf("Target energy %v RF/t has to be positive", targetEnergy), ""}
+	}
+	maxHeight = min(maxHeight, turbine.config.MaxHeight)
+	if limit := turbine.config.ShaftLimit(); limit > 0 {
+		maxHeight = min(maxHeight, limit+2)
+	}
+
+	current := turbine
+	current.Converge()
+	plan := ExpansionPlan{StartEnergy: current.energyGeneratedLastTick, TargetEnergy: targetEnergy}
+	for len(plan.Steps) < maxExpansionSteps && current.energyGeneratedLastTick < targetEnergy {
+		var best ExpansionStep
+		var bestTurbine Turbine
+		found := false
+		for _, candidate := range current.upgrades(maxHeight, steamLimit) {
+			if candidate.step.EnergyGain <= 0 {
+				continue
+			}
+			if !found || candidate.step.GainPerBlock > best.GainPerBlock {
+				best, bestTurbine, found = candidate.step, candidate.turbine, true
+			}
+		}
+		if !found {
+			break
+		}
+		plan.Steps = append(plan.Steps, best)
+		current = bestTurbine
+	}
+	plan.Reached = current.energyGeneratedLastTick >= targetEnergy
+	return plan, nil
+}
+
+type upgradeCandidate struct {
+	step    ExpansionStep
+	turbine Turbine
+}
+
+// upgrades tries every change that can be made to the turbine as it stands
+func (turbine Turbine) upgrades(maxHeight int32, steamLimit int64) []upgradeCandidate {
+	stats := turbine.Stats()
+	type change struct {
+		action             UpgradeAction
+		height, coilLayers int32
+		coil               CoilMaterial
+	}
+	changes := []change{
+		{AddCoilLayer, stats.Height, stats.CoilLayers + 1, CoilMaterial{CoilData: turbine.coil}},
+	}
+	if stats.Height < maxHeight {
+		changes = append(changes, change{ExtendHeight, stats.Height + 1, stats.CoilLayers, CoilMaterial{CoilData: turbine.coil}})
+	}
+	for _, material := range turbine.config.CoilMaterials() {
+		if compareCoilData(material.CoilData, turbine.coil) > 0 {
+			changes = append(changes, change{SwapCoil, stats.Height, stats.CoilLayers, material})
+		}
+	}
+
+	var candidates []upgradeCandidate
+	for _, change := range changes {
+		upgraded, err := NewTurbineWithOuterRing(turbine.config, change.height, stats.Width, change.coilLayers, stats.OuterRingCoils, change.coil.CoilData)
+		if err != nil {
+			// too many coil layers for the height
+			continue
+		}
+		upgraded.SetBlade(turbine.blade)
+		upgraded.SetFluid(turbine.fluid)
+		flowRate := upgraded.bestFlowRate(steamLimit)
+		upgraded.SetNominalFlowRate(flowRate)
+		upgraded.Converge()
+
+		step := ExpansionStep{
+			Action:          change.action,
+			Coil:            change.coil.Name,
+			Height:          change.height,
+			CoilLayers:      change.coilLayers,
+			FlowRate:        flowRate,
+			EnergyGenerated: upgraded.energyGeneratedLastTick,
+			EnergyGain:      upgraded.energyGeneratedLastTick - turbine.energyGeneratedLastTick,
+			BlocksChanged:   blocksChanged(turbine, upgraded),
+		}
+		if step.BlocksChanged > 0 {
+			step.GainPerBlock = step.EnergyGain / float64(step.BlocksChanged)
+		}
+		candidates = append(candidates, upgradeCandidate{step, upgraded})
+	}
+	return candidates
+}
+
+// how many flow rates an upgrade is tried at, a rotor given more steam than it can take overspeeds and makes nothing
+const expansionFlowSteps = 40
+
+// bestFlowRate is the flow rate up to steamLimit, or the most the turbine takes if that is 0, making the most energy
+func (turbine Turbine) bestFlowRate(steamLimit int64) int64 {
+	upper := turbine.maxMaxFlowRate
+	if steamLimit > 0 {
+		upper = min(upper, steamLimit)
+	}
+	step := max(1, upper/expansionFlowSteps)
+	best, bestEnergy := upper, math.Inf(-1)
+	for flowRate := upper; flowRate > 0; flowRate -= step {
+		turbine.SetNominalFlowRate(flowRate)
+		turbine.Converge()
+		if turbine.energyGeneratedLastTick > bestEnergy {
+			best, bestEnergy = flowRate, turbine.energyGeneratedLastTick
+		}
+	}
+	return best
+}
+
+// blocksChanged compares the build costs of two turbines item by item, coils of another material are all swapped
+func blocksChanged(from, to Turbine) int64 {
+	counts := map[string]int64{}
+	for _, item := range from.BuildCost() {
+		counts[item.Name] -= item.Count
+	}
+	for _, item := range to.BuildCost() {
+		counts[item.Name] += item.Count
+	}
+	changed := int64(0)
+	for _, count := range counts {
+		changed += max(count, -count)
+	}
+	if from.coil != to.coil {
+		changed += min(from.coilSize, to.coilSize)
+	}
+	return changed
+}
@@ -0,0 +1,58 @@
+package turbine
+
+import "testing"
+
+func TestPlanExpansion(t *testing.T) {
+	start, err := NewTurbine(&BiggerReactorsConfig, 10, 7, 5, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	start.SetNominalFlowRate(start.bestFlowRate(0))
+	start.Converge()
+	startEnergy := start.Stats().EnergyGenerated
+
+	plan, err := start.PlanExpansion(2*startEnergy, 16, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Reached || plan.StartEnergy != startEnergy {
+		t.Fatalf("plan from %v RF/t reached %v in %d steps", plan.StartEnergy, plan.Reached, len(plan.Steps))
+	}
+
+	energy := startEnergy
+	for _, step := range plan.Steps {
+		if step.EnergyGain <= 0 || step.EnergyGenerated != energy+step.EnergyGain {
+			t.Errorf("%s step gains %v RF/t from %v to %v", step.Action, step.EnergyGain, energy, step.EnergyGenerated)
+		}
+		if step.Height > 16 || step.BlocksChanged <= 0 {
+			t.Errorf("%s step to height %d changes %d blocks", step.Action, step.Height, step.BlocksChanged)
+		}
+		if (step.Action == SwapCoil) != (step.Coil != "") {
+			t.Errorf("%s step names coil %q", step.Action, step.Coil)
+		}
+		energy = step.EnergyGenerated
+	}
+	if energy < 2*startEnergy {
+		t.Errorf("plan ends at %v RF/t", energy)
+	}
+
+	if _, err := start.PlanExpansion(0, 16, 0); err == nil {
+		t.Error("planned an expansion to no energy")
+	}
+}
+
+func TestBlocksChanged(t *testing.T) {
+	iron, _ := NewTurbine(&BiggerReactorsConfig, 10, 7, 2, biggerReactorsCoils["Iron"])
+	gold, _ := NewTurbine(&BiggerReactorsConfig, 10, 7, 2, biggerReactorsCoils["Gold"])
+	taller, _ := NewTurbine(&BiggerReactorsConfig, 11, 7, 2, biggerReactorsCoils["Iron"])
+
+	if changed := blocksChanged(iron, iron); changed != 0 {
+		t.Errorf("same turbine changes %d blocks", changed)
+	}
+	if changed := blocksChanged(iron, gold); changed != iron.coilSize {
+		t.Errorf("coil swap changes %d blocks, want the %d coils", changed, iron.coilSize)
+	}
+	if changed := blocksChanged(iron, taller); changed <= 0 || changed >= taller.BlockCount() {
+		t.Errorf("one block taller changes %d blocks", changed)
+	}
+}
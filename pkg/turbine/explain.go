@@ -0,0 +1,71 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// below this much of the peak efficiency the rpm is called out as off-peak
+const nearPeakEfficiency = 0.99
+
+// Explain lists the reasons the turbine performs the way it does, worded for the user.
+// It compares the turbine against the designs with one coil layer more and less at the same flow rate.
+func (turbine Turbine) Explain() []string {
+	stats := turbine.Stats()
+	reasons := []string{}
+
+	peak := nearestPeakRPM(stats.RPM, turbine.config.EfficiencyPeakRPMs())
+	if stats.CoilEfficiency >= nearPeakEfficiency {
+		reasons = append(reasons, fmt.Sprintf("Rotor settles at %.0f RPM, next to the %.0f RPM coil efficiency peak (%.1f%%)", stats.RPM, peak, stats.CoilEfficiency*100))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Rotor settles at %.0f RPM, away from the %.0f RPM coil efficiency peak (%.1f%%)", stats.RPM, peak, stats.CoilEfficiency*100))
+	}
+
+	rotorCapacity := turbine.rotorCapacityPerRPM * max(100, stats.RPM)
+	if stats.RotorEfficiency < 1 {
+		reasons = append(reasons, fmt.Sprintf("Flow is above the rotor capacity of %.0f mB/t at this RPM, %.1f%% of the steam passes without pushing the blades", rotorCapacity, (1-stats.RotorEfficiency)*100))
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Rotor capacity of %.0f mB/t at this RPM covers the whole flow, no steam is wasted", rotorCapacity))
+	}
+	if stats.FlowRate == stats.MaxFlowRate {
+		reasons = append(reasons, fmt.Sprintf("Flow of %d mB/t is the most a %d wide turbine can take in", stats.FlowRate, stats.Width))
+	}
+
+	totalDrag := stats.InductorDrag + stats.FrictionDrag + stats.AeroDrag
+	if totalDrag > 0 {
+		reasons = append(reasons, fmt.Sprintf("Coils take %.1f%% of the drag, the rest is lost to friction and air", stats.InductorDrag/totalDrag*100))
+	}
+
+	for _, change := range []int32{1, -1} {
+		neighbor, err := NewTurbine(turbine.config, stats.Height, stats.Width, stats.CoilLayers+change, turbine.coil)
+		if err != nil {
+			continue
+		}
+		neighbor.SetBlade(turbine.blade)
+		neighbor.SetFluid(turbine.fluid)
+		neighbor.SetNominalFlowRate(stats.FlowRate)
+		neighbor.Converge()
+		neighborStats := neighbor.Stats()
+		if neighborStats.EnergyGenerated >= stats.EnergyGenerated {
+			continue
+		}
+
+		word := "more"
+		if change < 0 {
+			word = "less"
+		}
+		reasons = append(reasons, fmt.Sprintf("One coil layer %s would run at %.0f RPM and make %.0f RF/t less", word, neighborStats.RPM, stats.EnergyGenerated-neighborStats.EnergyGenerated))
+	}
+
+	return reasons
+}
+
+func nearestPeakRPM(rpm float64, peaks []float64) float64 {
+	nearest := peaks[0]
+	for _, peak := range peaks[1:] {
+		if math.Abs(peak-rpm) < math.Abs(nearest-rpm) {
+			nearest = peak
+		}
+	}
+	return nearest
+}
@@ -0,0 +1,33 @@
+package turbine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainComparesCoilLayers(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 11, Y: 16, Z: 11})
+	best, err := FindOptimalTurbine(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reasons := best.Explain()
+	if len(reasons) == 0 {
+		t.Fatal("no reasons given")
+	}
+	if !strings.Contains(reasons[0], "RPM") {
+		t.Errorf("first reason should be about the rpm, got %q", reasons[0])
+	}
+
+	// the optimizer picked the layer count, so both neighbors lose at the same flow
+	layerReasons := 0
+	for _, reason := range reasons {
+		if strings.HasPrefix(reason, "One coil layer") {
+			layerReasons++
+		}
+	}
+	if layerReasons != 2 {
+		t.Errorf("got %d coil layer comparisons, want 2: %q", layerReasons, reasons)
+	}
+}
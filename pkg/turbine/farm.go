@@ -0,0 +1,106 @@
+package turbine
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// how many footprint arrangements a farm plan suggests
+const maxArrangements = 3
+
+// FarmPlan is how many copies of the recommended turbine it takes to make a base's target energy
+type FarmPlan struct {
+	TargetEnergy     float64 `json:"targetEnergy"`
+	Turbines         int64   `json:"turbines"`
+	EnergyPerTurbine float64 `json:"energyPerTurbine"`
+	TotalEnergy      float64 `json:"totalEnergy"`
+	// steam every turbine takes together, in mB/t
+	SteamFlow int64 `json:"steamFlow"`
+	// blocks for every turbine together
+	BuildCost   build.Cost `json:"buildCost"`
+	TotalBlocks int64      `json:"totalBlocks"`
+	// ways to lay the turbines out in a grid, the one with the shortest long side first
+	Arrangements []FarmArrangement `json:"arrangements"`
+}
+
+// FarmArrangement lays the turbines out in rows along x and columns along z with spacing free blocks between
+// them, for pipes and to walk through
+type FarmArrangement struct {
+	Rows    int64 `json:"rows"`
+	Columns int64 `json:"columns"`
+	// grid cells left without a turbine
+	EmptySlots int64 `json:"emptySlots"`
+	// outer size of the whole farm, casings included
+	SizeX int64 `json:"sizeX"`
+	SizeZ int64 `json:"sizeZ"`
+	Area  int64 `json:"area"`
+}
+
+// PlanFarm searches for the turbine options recommend and builds enough copies to make targetEnergy RF/t
+func PlanFarm(options Options, walls WallMaterial, targetEnergy float64, spacing int32) (FarmPlan, SearchResult, error) {
+	if targetEnergy <= 0 || math.IsNaN(targetEnergy) || math.IsInf(targetEnergy, 0) {
+		return FarmPlan{}, SearchResult{}, ValidationError{"targetEnergy", fmt.Sprintf("Target energy %v RF/t has to be positive", targetEnergy), ""}
+	}
+	if spacing < 0 {
+		return FarmPlan{}, SearchResult{}, ValidationError{"spacing", fmt.Sprintf("Spacing of %d blocks cannot be negative", spacing), ""}
+	}
+
+	result := Search(options)
+	if err := result.Err(); err != nil {
+		return FarmPlan{}, result, err
+	}
+	stats := result.Turbine.Stats()
+	if stats.EnergyGenerated <= 0 {
+		return FarmPlan{}, result, InfeasibleError{"fitness", "The recommended turbine makes no energy"}
+	}
+
+	count := int64(math.Ceil(targetEnergy / stats.EnergyGenerated))
+	plan := FarmPlan{
+		TargetEnergy:     targetEnergy,
+		Turbines:         count,
+		EnergyPerTurbine: stats.EnergyGenerated,
+		TotalEnergy:      float64(count) * stats.EnergyGenerated,
+		SteamFlow:        count * stats.FlowRate,
+		TotalBlocks:      count * result.Turbine.BlockCount(),
+	}
+	for _, item := range result.Turbine.BuildCostWith(walls) {
+		plan.BuildCost = plan.BuildCost.Add(item.Name, item.Count*count)
+	}
+	plan.Arrangements = farmArrangements(count, int64(result.Turbine.size.X)+2, int64(result.Turbine.size.Z)+2, int64(spacing))
+	return plan, result, nil
+}
+
+// farmArrangements tries every grid that holds count turbines without a spare row or column
+func farmArrangements(count, sizeX, sizeZ, spacing int64) []FarmArrangement {
+	var arrangements []FarmArrangement
+	for rows := int64(1); rows <= count; rows++ {
+		columns := (count + rows - 1) / rows
+		// a row fewer holds them too when the last row is empty
+		if (rows-1)*columns >= count {
+			continue
+		}
+		// square turbines give the same grid turned around
+		if sizeX == sizeZ && rows > columns {
+			break
+		}
+		arrangement := FarmArrangement{
+			Rows:       rows,
+			Columns:    columns,
+			EmptySlots: rows*columns - count,
+			SizeX:      rows*sizeX + (rows-1)*spacing,
+			SizeZ:      columns*sizeZ + (columns-1)*spacing,
+		}
+		arrangement.Area = arrangement.SizeX * arrangement.SizeZ
+		arrangements = append(arrangements, arrangement)
+	}
+
+	// the gaps make a single row the smallest area, the smallest square plot it fits in is the better measure
+	slices.SortStableFunc(arrangements, func(a, b FarmArrangement) int {
+		return cmp.Or(cmp.Compare(max(a.SizeX, a.SizeZ), max(b.SizeX, b.SizeZ)), cmp.Compare(a.Area, b.Area))
+	})
+	return arrangements[:min(len(arrangements), maxArrangements)]
+}
@@ -0,0 +1,58 @@
+package turbine
+
+import "testing"
+
+func TestPlanFarm(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 9, Y: 10, Z: 9})
+	plan, result, err := PlanFarm(options, GlassWalls, 100000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := result.Turbine.Stats()
+
+	if plan.TotalEnergy < plan.TargetEnergy || plan.TotalEnergy-plan.EnergyPerTurbine >= plan.TargetEnergy {
+		t.Errorf("%d turbines make %v RF/t for a target of %v", plan.Turbines, plan.TotalEnergy, plan.TargetEnergy)
+	}
+	if plan.SteamFlow != plan.Turbines*stats.FlowRate {
+		t.Errorf("steam flow %d, want %d", plan.SteamFlow, plan.Turbines*stats.FlowRate)
+	}
+	if want := plan.Turbines * result.Turbine.BuildCost().Total(); plan.BuildCost.Total() != want {
+		t.Errorf("build cost has %d items, want %d", plan.BuildCost.Total(), want)
+	}
+
+	if len(plan.Arrangements) == 0 {
+		t.Fatal("no arrangements")
+	}
+	for i, arrangement := range plan.Arrangements {
+		if arrangement.Rows*arrangement.Columns-arrangement.EmptySlots != plan.Turbines {
+			t.Errorf("%+v doesn't hold %d turbines", arrangement, plan.Turbines)
+		}
+		if i > 0 && max(arrangement.SizeX, arrangement.SizeZ) < max(plan.Arrangements[i-1].SizeX, plan.Arrangements[i-1].SizeZ) {
+			t.Errorf("arrangements aren't sorted by their long side: %+v", plan.Arrangements)
+		}
+	}
+
+	if _, _, err := PlanFarm(options, GlassWalls, 0, 1); err == nil {
+		t.Error("planned a farm for no energy")
+	}
+}
+
+func TestFarmArrangements(t *testing.T) {
+	tests := []struct {
+		count, size, spacing int64
+		rows, columns        int64
+		sizeX                int64
+	}{
+		{1, 7, 1, 1, 1, 7},
+		{4, 7, 1, 2, 2, 15},
+		{5, 7, 0, 2, 3, 14},
+		{3, 7, 1, 2, 2, 15},
+		{6, 9, 2, 2, 3, 20},
+	}
+	for _, tc := range tests {
+		best := farmArrangements(tc.count, tc.size, tc.size, tc.spacing)[0]
+		if best.Rows != tc.rows || best.Columns != tc.columns || best.SizeX != tc.sizeX {
+			t.Errorf("farmArrangements(%d, %d, %d) = %+v, want %dx%d %d wide", tc.count, tc.size, tc.spacing, best, tc.rows, tc.columns, tc.sizeX)
+		}
+	}
+}
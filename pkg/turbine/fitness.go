@@ -0,0 +1,71 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type FitnessMetric int64
+
+const (
+	// most RF/t
+	MaximizeEnergy FitnessMetric = iota
+	// most RF per mB of steam at the chosen flow rate
+	MaximizeEnergyPerSteam
+	// most RF/t for every block of space the turbine takes up, walls included
+	MaximizeEnergyPerVolume
+)
+
+func ParseFitnessMetric(name string) (FitnessMetric, error) {
+	switch strings.ToLower(name) {
+	case "energy":
+		return MaximizeEnergy, nil
+	case "energypersteam":
+		return MaximizeEnergyPerSteam, nil
+	case "energypervolume":
+		return MaximizeEnergyPerVolume, nil
+	default:
+		return 0, fmt.Errorf("Unknown fitness %q", name)
+	}
+}
+
+// EnergyPerSteam is the RF made from each mB of steam at the last tick
+func (turbine Turbine) EnergyPerSteam() float64 {
+	if turbine.maxFlowRate == 0 {
+		return 0
+	}
+	return turbine.energyGeneratedLastTick / float64(turbine.maxFlowRate)
+}
+
+// ExteriorVolume is the space the turbine takes up, walls included
+func (turbine Turbine) ExteriorVolume() int64 {
+	return int64(turbine.size.X+2) * int64(turbine.size.Y+2) * int64(turbine.size.Z+2)
+}
+
+// EnergyPerVolume is the RF/t made for every block of ExteriorVolume
+func (turbine Turbine) EnergyPerVolume() float64 {
+	return turbine.energyGeneratedLastTick / float64(turbine.ExteriorVolume())
+}
+
+// MetricFitness scores turbines by metric, turbines making less than minEnergy RF/t are ruled out
+// so a steam efficiency search doesn't settle on a turbine too small to be useful
+func MetricFitness(metric FitnessMetric, minEnergy float64) func(Turbine) float64 {
+	return func(turbine Turbine) float64 {
+		energy := turbine.Stats().EnergyGenerated
+		if energy < minEnergy {
+			return math.Inf(-1)
+		}
+
+		switch metric {
+		case MaximizeEnergy:
+			return energy
+		case MaximizeEnergyPerSteam:
+			return turbine.EnergyPerSteam()
+		case MaximizeEnergyPerVolume:
+			return turbine.EnergyPerVolume()
+		default:
+			panic("Invalid FitnessMetric")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package turbine
+
+import "testing"
+
+func TestParseFitnessMetric(t *testing.T) {
+	for name, want := range map[string]FitnessMetric{"energy": MaximizeEnergy, "energyPerSteam": MaximizeEnergyPerSteam, "energyPerVolume": MaximizeEnergyPerVolume} {
+		if got, err := ParseFitnessMetric(name); err != nil || got != want {
+			t.Errorf("ParseFitnessMetric(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseFitnessMetric("size"); err == nil {
+		t.Error("unknown fitness accepted")
+	}
+}
+
+func TestEnergyPerSteamSearch(t *testing.T) {
+	maxSize := Size{X: 11, Y: 14, Z: 11}
+	flowSetting := FlowSetting{Variant: FindBestUnderFlow, Value: 20000}
+
+	energy := Search(NewOptions(MetricFitness(MaximizeEnergy, 0), noConstraints, biggerReactorsCoils["Gold"], flowSetting, maxSize)).Turbine
+	efficient := Search(NewOptions(MetricFitness(MaximizeEnergyPerSteam, 0), noConstraints, biggerReactorsCoils["Gold"], flowSetting, maxSize)).Turbine
+
+	if efficient.EnergyPerSteam() < energy.EnergyPerSteam() {
+		t.Errorf("steam efficiency search found %.3f RF/mB, energy search %.3f", efficient.EnergyPerSteam(), energy.EnergyPerSteam())
+	}
+
+	// a floor above what the most efficient turbine makes has to push the search to a bigger one
+	floor := efficient.Stats().EnergyGenerated * 1.2
+	floored := Search(NewOptions(MetricFitness(MaximizeEnergyPerSteam, floor), noConstraints, biggerReactorsCoils["Gold"], flowSetting, maxSize))
+	if !floored.Found {
+		t.Fatalf("no turbine makes %.0f RF/t", floor)
+	}
+	if got := floored.Turbine.Stats().EnergyGenerated; got < floor {
+		t.Errorf("floored search makes %.0f RF/t, under the %.0f floor", got, floor)
+	}
+}
+
+func TestEnergyPerVolumeSearch(t *testing.T) {
+	maxSize := Size{X: 15, Y: 20, Z: 15}
+	flowSetting := FlowSetting{Variant: FindBestUnderFlow, Value: 20000}
+
+	energy := Search(NewOptions(MetricFitness(MaximizeEnergy, 0), noConstraints, biggerReactorsCoils["Enderium"], flowSetting, maxSize)).Turbine
+	dense := Search(NewOptions(MetricFitness(MaximizeEnergyPerVolume, 0), noConstraints, biggerReactorsCoils["Enderium"], flowSetting, maxSize)).Turbine
+
+	if dense.EnergyPerVolume() < energy.EnergyPerVolume() {
+		t.Errorf("space efficiency search found %.3f RF/t per block, energy search %.3f", dense.EnergyPerVolume(), energy.EnergyPerVolume())
+	}
+	stats := dense.Stats()
+	if got, want := dense.ExteriorVolume(), int64(stats.Width)*int64(stats.Height)*int64(stats.Width); got != want {
+		t.Errorf("ExteriorVolume = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package turbine
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// FluidData is a hot fluid the turbine can run on
+type FluidData struct {
+	// RF per mB before the turbine multiplier
+	LatentHeat float64 `json:"latentHeat"`
+	// scales how much of the fluid each block of rotor area takes in, FlowRatePerBlock is for steam
+	FlowMultiplier float64 `json:"flowMultiplier"`
+}
+
+// steam is the fluid every turbine runs on unless another one is picked
+const SteamFluidName = "Steam"
+
+type FluidMaterial struct {
+	Name string `json:"name"`
+	FluidData
+}
+
+// FluidMaterials lists steam and the fluid table from the lowest to the highest latent heat
+func (config Config) FluidMaterials() []FluidMaterial {
+	materials := []FluidMaterial{config.DefaultFluid()}
+	for name, fluid := range config.Fluids {
+		materials = append(materials, FluidMaterial{name, fluid})
+	}
+
+	slices.SortFunc(materials, func(a, b FluidMaterial) int {
+		return cmp.Or(
+			cmp.Compare(a.LatentHeat, b.LatentHeat),
+			cmp.Compare(a.FlowMultiplier, b.FlowMultiplier),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	return materials
+}
+
+// DefaultFluid is steam with the config's LatentHeat
+func (config Config) DefaultFluid() FluidMaterial {
+	return FluidMaterial{SteamFluidName, FluidData{LatentHeat: config.LatentHeat, FlowMultiplier: 1}}
+}
+
+// Fluid looks up a fluid by name, suggesting the closest one if there is no such fluid
+func (config Config) Fluid(name string) (FluidMaterial, error) {
+	if name == SteamFluidName {
+		return config.DefaultFluid(), nil
+	}
+	if fluid, ok := config.Fluids[name]; ok {
+		return FluidMaterial{name, fluid}, nil
+	}
+
+	names := make([]string, 0, len(config.Fluids)+1)
+	for _, material := range config.FluidMaterials() {
+		names = append(names, material.Name)
+	}
+	return FluidMaterial{}, ValidationError{"fluid", fmt.Sprintf("Unknown fluid %q", name), closestName(name, names)}
+}
+
+// SetFluid runs the turbine on another fluid, the flow rate is capped to what the rotor takes in of it
+func (turbine *Turbine) SetFluid(fluid FluidMaterial) {
+	turbine.fluid = fluid
+	maxMaxFlowRate, err := turbine.intakeFlowRate()
+	if err != nil {
+		// only a config that skipped ValidateLimits has a fluid flowing that fast, take in as much as can be counted
+		maxMaxFlowRate = math.MaxInt64
+	}
+	turbine.maxMaxFlowRate = maxMaxFlowRate
+	turbine.SetNominalFlowRate(turbine.maxFlowRate)
+}
+
+// Fluid is what the turbine runs on
+func (turbine Turbine) Fluid() FluidMaterial {
+	return turbine.fluid
+}
+
+// intakeFlowRate is the most fluid the rotor area takes in per tick, an error if that overflows an int64
+func (turbine Turbine) intakeFlowRate() (int64, error) {
+	steamFlow, ok := multiplyInt64(int64(turbine.size.X)*int64(turbine.size.Z)-1 /* bearing*/, turbine.config.FlowRatePerBlock)
+	if !ok {
+		return 0, fmt.Errorf("Turbine %dx%d rotor takes in more than %d mB/t", turbine.size.X, turbine.size.Z, int64(math.MaxInt64))
+	}
+	if turbine.fluid.FlowMultiplier == 1 {
+		return steamFlow, nil
+	}
+	flow := float64(steamFlow) * turbine.fluid.FlowMultiplier
+	// float64(MaxInt64) rounds up to 2^63, which is already out of range
+	if math.IsNaN(flow) || flow < 0 || flow >= math.MaxInt64 {
+		return 0, fmt.Errorf("Turbine %dx%d rotor takes in more %s than can be counted", turbine.size.X, turbine.size.Z, turbine.fluid.Name)
+	}
+	return int64(flow), nil
+}
+
+// rfPerHeat is the energy one mB of the fluid puts into the rotor
+func (turbine Turbine) rfPerHeat() float64 {
+	return turbine.fluid.LatentHeat * turbine.config.TurbineMultiplier
+}
@@ -0,0 +1,71 @@
+package turbine
+
+import "testing"
+
+func TestSteamFollowsLatentHeat(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.LatentHeat = 6
+
+	steam, err := config.Fluid("Steam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steam.LatentHeat != 6 || steam.FlowMultiplier != 1 {
+		t.Errorf("steam is %+v with a latent heat of 6", steam)
+	}
+	if materials := BiggerReactorsConfig.FluidMaterials(); len(materials) != 1 || materials[0].Name != SteamFluidName {
+		t.Errorf("bundled fluids are %v, want only steam", materials)
+	}
+}
+
+func TestSetFluid(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.Fluids = map[string]FluidData{"Hot Sodium": {LatentHeat: 8, FlowMultiplier: 0.5}}
+	sodium, err := config.Fluid("Hot Sodium")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steamTurbine, err := NewTurbine(config, 12, 9, 3, biggerReactorsCoils["Gold"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sodiumTurbine := steamTurbine
+	sodiumTurbine.SetFluid(sodium)
+
+	if got, want := sodiumTurbine.Stats().MaxFlowRate, steamTurbine.Stats().MaxFlowRate/2; got != want {
+		t.Errorf("max flow rate on sodium is %d, want %d", got, want)
+	}
+
+	// twice the heat per mB needs half the flow for the same rpm
+	steamTurbine.SetNominalFlowRate(2000)
+	sodiumTurbine.SetNominalFlowRate(1000)
+	assertClose(t, "FinalRPM", sodiumTurbine.FinalRPM(), steamTurbine.FinalRPM())
+	if got := sodiumTurbine.Stats().Fluid; got != "Hot Sodium" {
+		t.Errorf("stats fluid is %q", got)
+	}
+
+	if _, err := config.Fluid("hot sodum"); err == nil || err.(ValidationError).Suggestion != "Hot Sodium" {
+		t.Errorf("got %v, want a suggestion of Hot Sodium", err)
+	}
+}
+
+func TestSearchWithFluid(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.Fluids = map[string]FluidData{"Hot Sodium": {LatentHeat: 8, FlowMultiplier: 0.5}}
+
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 12, Z: 9})
+	options.Config = config
+	options.Fluid, _ = config.Fluid("Hot Sodium")
+	result := Search(options)
+	if !result.Found {
+		t.Fatal("no turbine found")
+	}
+	stats := result.Turbine.Stats()
+	if stats.Fluid != "Hot Sodium" {
+		t.Errorf("search ran the turbine on %q", stats.Fluid)
+	}
+	if stats.FlowRate > stats.MaxFlowRate {
+		t.Errorf("flow rate %d is over the sodium intake of %d", stats.FlowRate, stats.MaxFlowRate)
+	}
+}
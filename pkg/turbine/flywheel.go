@@ -0,0 +1,62 @@
+package turbine
+
+import "errors"
+
+// FlywheelReport is the turbine run as a flywheel: the steam spins the rotor up with nothing drawing on it and the
+// rotor stores the energy until coils are added. Energies are rotor energy, the steam's heat times the turbine
+// multiplier, except the RF the coils make.
+type FlywheelReport struct {
+	// where friction and air drag stop the rotor speeding up
+	TopRPM float64 `json:"topRPM"`
+	// from rest to TopRPM
+	SpinUp PhaseResult `json:"spinUp"`
+	// rotor energy at TopRPM
+	StoredEnergy float64 `json:"storedEnergy"`
+	// drag at TopRPM, the steam has to keep making up for it every tick to hold the rotor there
+	HoldingDrag float64 `json:"holdingDrag"`
+	// rotors faster than Config.MaxSafeRPM break before they get to TopRPM
+	Overspeed bool `json:"overspeed"`
+
+	// the turbine rebuilt with the coils added and run on the stored energy alone, no steam, until it stops
+	Discharge PhaseResult `json:"discharge"`
+	// the RF the coils get out of the stored energy
+	ExtractableEnergy float64 `json:"extractableEnergy"`
+}
+
+// Flywheel spins the turbine up at its flow rate with the coils disengaged, so it works for a turbine with zero
+// coil layers, then rebuilds it with coilLayers of coil and runs it down on the stored energy. The blade, fluid
+// and shaft carry over. The turbine it's called on is not modified.
+func (turbine Turbine) Flywheel(coil CoilData, coilLayers int32) (FlywheelReport, error) {
+	if coilLayers < 1 {
+		return FlywheelReport{}, errors.New("Flywheel needs at least one coil layer added to get its energy out")
+	}
+	stats := turbine.Stats()
+	withCoils, err := NewTurbine(turbine.config, stats.Height, stats.Width, coilLayers, coil)
+	if err != nil {
+		return FlywheelReport{}, err
+	}
+
+	report := FlywheelReport{TopRPM: turbine.FinalRPMNoLoad()}
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetActive(true)
+	turbine.SetCoilEngaged(false)
+	turbine.Reset()
+	report.SpinUp = turbine.tickPhase(maxPhaseTicks, func() bool {
+		return turbine.RPM() >= report.TopRPM*(1-spinUpTolerance)
+	})
+	report.StoredEnergy = turbine.rotorEnergy
+	report.HoldingDrag = turbine.frictionDragLastTick + turbine.aeroDragLastTick
+	report.Overspeed = turbine.config.MaxSafeRPM > 0 && report.TopRPM > turbine.config.MaxSafeRPM
+
+	// the rotor keeps its energy, coils in place of blades change its mass and so its rpm
+	withCoils.SetBlade(turbine.blade)
+	withCoils.SetFluid(turbine.fluid)
+	withCoils.SetPrecision(PrecisionExact)
+	withCoils.SetActive(false)
+	withCoils.rotorEnergy = report.StoredEnergy
+	report.Discharge = withCoils.tickPhase(maxPhaseTicks, func() bool {
+		return withCoils.RPM() < stoppedRPM
+	})
+	report.ExtractableEnergy = report.Discharge.EnergyGenerated
+	return report, nil
+}
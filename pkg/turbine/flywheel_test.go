@@ -0,0 +1,79 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFlywheelWithoutCoils(t *testing.T) {
+	flywheel, err := NewTurbine(&BiggerReactorsConfig, 14, 9, 0, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	flywheel.SetNominalFlowRate(2000)
+	stats := flywheel.Stats()
+	if stats.CoilSize != 0 || stats.CoilLayers != 0 {
+		t.Errorf("flywheel has %d coils in %d layers", stats.CoilSize, stats.CoilLayers)
+	}
+	// nothing to drag on the rotor, so the loaded steady state is the free-spinning one
+	if loaded, free := flywheel.FinalRPM(), flywheel.FinalRPMNoLoad(); math.Abs(loaded-free) > 1e-9*free {
+		t.Errorf("FinalRPM %g differs from FinalRPMNoLoad %g without coils", loaded, free)
+	}
+
+	flywheel.Converge()
+	if energy := flywheel.Stats().EnergyGenerated; energy != 0 {
+		t.Errorf("flywheel generated %g RF/t", energy)
+	}
+}
+
+func TestFlywheelReport(t *testing.T) {
+	flywheel, err := NewTurbine(&BiggerReactorsConfig, 14, 9, 0, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	flywheel.SetNominalFlowRate(2000)
+
+	report, err := flywheel.Flywheel(biggerReactorsCoils["Enderium"], 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.TopRPM <= 0 || report.SpinUp.Ticks == 0 || report.SpinUp.Ticks >= maxPhaseTicks {
+		t.Fatalf("report = %+v, want a spin up to a positive rpm", report)
+	}
+	if want := flywheel.rotorAxialMass * report.TopRPM; math.Abs(report.StoredEnergy-want) > 2*spinUpTolerance*want {
+		t.Errorf("stored %g at %g rpm, want about %g", report.StoredEnergy, report.TopRPM, want)
+	}
+	if report.HoldingDrag <= 0 {
+		t.Errorf("holding drag %g", report.HoldingDrag)
+	}
+	if report.Discharge.Ticks == 0 || report.ExtractableEnergy <= 0 || report.Discharge.SteamUsed != 0 {
+		t.Errorf("discharge = %+v, want RF out of the stored energy without steam", report.Discharge)
+	}
+	if flywheel.RPM() != 0 {
+		t.Error("Flywheel changed the turbine it was called on")
+	}
+
+	if _, err := flywheel.Flywheel(biggerReactorsCoils["Enderium"], 0); err == nil {
+		t.Error("adding no coils should fail")
+	}
+	if _, err := flywheel.Flywheel(biggerReactorsCoils["Enderium"], 12); err == nil {
+		t.Error("adding more coil layers than fit should fail")
+	}
+}
+
+func TestFlywheelOverspeed(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.MaxSafeRPM = 2000
+	flywheel, err := NewTurbine(config, 14, 9, 0, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	flywheel.SetNominalFlowRate(40000)
+	report, err := flywheel.Flywheel(biggerReactorsCoils["Enderium"], 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Overspeed {
+		t.Errorf("top speed %g rpm is past the max safe 2000 rpm but not reported", report.TopRPM)
+	}
+}
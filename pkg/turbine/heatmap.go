@@ -0,0 +1,177 @@
+package turbine
+
+import "math"
+
+// HeatMap is RF/t over two of the design dimensions, Values[i][j] is the turbine at Y[i] and X[j].
+// Cells without a valid turbine are zero.
+type HeatMap struct {
+	XAxis  string      `json:"xAxis"`
+	YAxis  string      `json:"yAxis"`
+	X      []int32     `json:"x"`
+	Y      []int32     `json:"y"`
+	Values [][]float64 `json:"values,omitempty"`
+	// only every Stride-th value of each axis is mapped when the full map would have more than
+	// Options.MaxHeatMapCells cells, 1 for the full map
+	Stride int32 `json:"stride"`
+}
+
+// HeatMapRow is one row of a heat map, for streaming them out as they are computed
+type HeatMapRow struct {
+	Y      int32     `json:"y"`
+	Values []float64 `json:"values"`
+}
+
+// bestAtGeometry runs every flow rate of the options on one geometry and keeps the fittest, like Search does
+func bestAtGeometry(options Options, height, width, coilLayers int32) (Turbine, float64, bool) {
+	turbine, err := NewTurbine(options.Config, height, width, coilLayers, options.Coil)
+	if err != nil {
+		return turbine, 0, false
+	}
+	options.applyMaterials(&turbine)
+	if !options.Constraints(turbine) {
+		return turbine, 0, false
+	}
+	turbine.SetPrecision(options.SearchPrecision)
+
+	var best Turbine
+	bestFitness := math.Inf(-1)
+	options.Flow.tryFlowRates(turbine, func(flowRate int64) (float64, bool) {
+		turbine.SetNominalFlowRate(flowRate)
+		if options.LimitNoLoadRPM && !turbine.safeWithoutLoad() {
+			return math.Inf(-1), true
+		}
+		turbine.Settle()
+		if !turbine.curve.nearPeak(turbine.RPM(), options.TargetPeak) {
+			return math.Inf(-1), true
+		}
+		fitness := options.Fitness(turbine)
+		if fitness > bestFitness {
+			best = turbine
+			bestFitness = fitness
+		}
+		return fitness, true
+	})
+	return best, bestFitness, !math.IsInf(bestFitness, -1)
+}
+
+// widths are odd, the bounds come from the options clamped to the config
+func heatMapWidths(options Options) []int32 {
+	widths := []int32{}
+	minWidth := max(options.Config.MinWidth, options.MinSize.X)
+	minWidth += 1 - minWidth%2
+	for width := minWidth; width <= min(options.roomWidth(), options.Config.MaxWidth); width += 2 {
+		widths = append(widths, width)
+	}
+	return widths
+}
+
+func heatMapHeights(options Options) []int32 {
+	heights := []int32{}
+	for height := max(options.Config.MinHeight, options.MinSize.Y); height <= min(options.MaxSize.Y, options.Config.MaxHeight); height++ {
+		heights = append(heights, height)
+	}
+	return heights
+}
+
+// HeatMapWidthCoils maps width against coil layers at a fixed height
+func HeatMapWidthCoils(options Options, height int32) HeatMap {
+	values := [][]float64{}
+	heatMap := StreamHeatMapWidthCoils(options, height, func(HeatMap) {}, func(row HeatMapRow) {
+		values = append(values, row.Values)
+	})
+	heatMap.Values = values
+	return heatMap
+}
+
+// HeatMapHeightWidth maps height against width, each cell with its fittest coil layer count
+func HeatMapHeightWidth(options Options) HeatMap {
+	values := [][]float64{}
+	heatMap := StreamHeatMapHeightWidth(options, func(HeatMap) {}, func(row HeatMapRow) {
+		values = append(values, row.Values)
+	})
+	heatMap.Values = values
+	return heatMap
+}
+
+// gridStride is the smallest stride that brings an x by y grid down to maxCells, 1 for no limit.
+// A map is never cut below one cell.
+func gridStride(x, y, maxCells int) int32 {
+	if maxCells <= 0 {
+		return 1
+	}
+	stride := 1
+	for (x+stride-1)/stride*((y+stride-1)/stride) > maxCells && stride < max(x, y) {
+		stride++
+	}
+	return int32(stride)
+}
+
+// every takes every stride-th value, starting with the first
+func every(values []int32, stride int32) []int32 {
+	if stride <= 1 {
+		return values
+	}
+	kept := []int32{}
+	for i := 0; i < len(values); i += int(stride) {
+		kept = append(kept, values[i])
+	}
+	return kept
+}
+
+// coarsen thins both axes out evenly until the map fits Options.MaxHeatMapCells
+func (heatMap *HeatMap) coarsen(maxCells int) {
+	heatMap.Stride = gridStride(len(heatMap.X), len(heatMap.Y), maxCells)
+	heatMap.X = every(heatMap.X, heatMap.Stride)
+	heatMap.Y = every(heatMap.Y, heatMap.Stride)
+}
+
+// StreamHeatMapWidthCoils hands the axes to start and then every row to emit as soon as it is computed,
+// without keeping them. The returned heat map has no values.
+func StreamHeatMapWidthCoils(options Options, height int32, start func(HeatMap), emit func(HeatMapRow)) HeatMap {
+	heatMap := HeatMap{XAxis: "width", YAxis: "coilLayers", X: heatMapWidths(options)}
+	for coilLayers := max(1, options.MinCoilLayers); coilLayers <= height-3; coilLayers++ {
+		if options.MaxCoilLayers > 0 && coilLayers > options.MaxCoilLayers {
+			break
+		}
+		heatMap.Y = append(heatMap.Y, coilLayers)
+	}
+	heatMap.coarsen(options.MaxHeatMapCells)
+	start(heatMap)
+
+	for _, coilLayers := range heatMap.Y {
+		row := HeatMapRow{Y: coilLayers, Values: make([]float64, len(heatMap.X))}
+		for j, width := range heatMap.X {
+			if turbine, _, ok := bestAtGeometry(options, height, width, coilLayers); ok {
+				row.Values[j] = turbine.Stats().EnergyGenerated
+			}
+		}
+		emit(row)
+	}
+	return heatMap
+}
+
+// StreamHeatMapHeightWidth hands the axes to start and then every row to emit as soon as it is computed,
+// without keeping them. The returned heat map has no values.
+func StreamHeatMapHeightWidth(options Options, start func(HeatMap), emit func(HeatMapRow)) HeatMap {
+	heatMap := HeatMap{XAxis: "width", YAxis: "height", X: heatMapWidths(options), Y: heatMapHeights(options)}
+	heatMap.coarsen(options.MaxHeatMapCells)
+	start(heatMap)
+
+	for _, height := range heatMap.Y {
+		row := HeatMapRow{Y: height, Values: make([]float64, len(heatMap.X))}
+		for j, width := range heatMap.X {
+			bestFitness := math.Inf(-1)
+			for coilLayers := max(1, options.MinCoilLayers); coilLayers <= height-3; coilLayers++ {
+				if options.MaxCoilLayers > 0 && coilLayers > options.MaxCoilLayers {
+					break
+				}
+				if turbine, fitness, ok := bestAtGeometry(options, height, width, coilLayers); ok && fitness > bestFitness {
+					row.Values[j] = turbine.Stats().EnergyGenerated
+					bestFitness = fitness
+				}
+			}
+		}
+		emit(row)
+	}
+	return heatMap
+}
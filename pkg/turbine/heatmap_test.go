@@ -0,0 +1,74 @@
+package turbine
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHeatMapMatchesSearch(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 11, Y: 10, Z: 11})
+
+	heatMap := HeatMapHeightWidth(options)
+	if len(heatMap.Values) != len(heatMap.Y) || len(heatMap.X) != 4 {
+		t.Fatalf("got %d rows for %d heights and %d widths", len(heatMap.Values), len(heatMap.Y), len(heatMap.X))
+	}
+
+	// the hottest cell is the turbine the optimizer picks
+	best := 0.0
+	for _, row := range heatMap.Values {
+		for _, value := range row {
+			best = max(best, value)
+		}
+	}
+	searched := Search(options).Turbine
+	searched.SetPrecision(PrecisionFast)
+	searched.Settle()
+	assertClose(t, "hottest cell", best, searched.Stats().EnergyGenerated)
+}
+
+func TestHeatMapWidthCoils(t *testing.T) {
+	// at max flow the small coils let the rotor run past the last peak and make nothing
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 9, Y: 10, Z: 9})
+
+	heatMap := HeatMapWidthCoils(options, 8)
+	// heights of 8 fit up to 5 coil layers
+	if len(heatMap.Y) != 5 || len(heatMap.Values) != 5 {
+		t.Fatalf("got coil layers %v", heatMap.Y)
+	}
+	for i, row := range heatMap.Values {
+		for j, value := range row {
+			if value <= 0 {
+				t.Errorf("width %d, %d coil layers has no turbine", heatMap.X[j], heatMap.Y[i])
+			}
+		}
+	}
+}
+
+func TestHeatMapMaxCells(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 15, Y: 16, Z: 15})
+	full := HeatMapHeightWidth(options)
+	if full.Stride != 1 {
+		t.Errorf("unlimited map has stride %d", full.Stride)
+	}
+
+	cells := len(full.X) * len(full.Y)
+	for _, maxCells := range []int{cells, cells / 3, 5, 1} {
+		options.MaxHeatMapCells = maxCells
+		coarse := HeatMapHeightWidth(options)
+		if got := len(coarse.X) * len(coarse.Y); got > maxCells || got == 0 {
+			t.Errorf("limit of %d cells: got %d cells", maxCells, got)
+		}
+
+		// the cells that are kept hold what the full map has there
+		for i, height := range coarse.Y {
+			for j, width := range coarse.X {
+				fullRow := slices.Index(full.Y, height)
+				fullColumn := slices.Index(full.X, width)
+				if fullRow < 0 || fullColumn < 0 {
+					t.Fatalf("limit of %d cells mapped %dx%d, which isn't on the full map", maxCells, width, height)
+				}
+				assertClose(t, "cell", coarse.Values[i][j], full.Values[fullRow][fullColumn])
+			}
+		}
+	}
+}
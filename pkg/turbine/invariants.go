@@ -0,0 +1,64 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// invariantSlack absorbs rounding in ratios that are exactly 1 on paper
+const invariantSlack = 1e-9
+
+// invariant panics with what broke and a dump of the turbine, only called when debugInvariants is set
+func (turbine Turbine) invariant(ok bool, format string, args ...any) {
+	if ok {
+		return
+	}
+	panic(fmt.Sprintf("turbine invariant broken: %s\n%s", fmt.Sprintf(format, args...), turbine.debugDump()))
+}
+
+// debugDump is everything a physics regression could hinge on
+func (turbine Turbine) debugDump() string {
+	return fmt.Sprintf("size %dx%dx%d inside, %d coil layers, %d coils, fluid %s\n"+
+		"flow %d of %d mB/t, rpm %g, rotor energy %g, mass %g, axial mass %g\n"+
+		"capacity %g mB/t per rpm, blade meters %g, coil drag %g, induction efficiency %g, bonus %g\n"+
+		"last tick: %g RF, rotor efficiency %g, coil efficiency %g, drag %g coils %g friction %g air",
+		turbine.size.X, turbine.size.Y, turbine.size.Z, turbine.coilLayers, turbine.coilSize, turbine.fluid.Name,
+		turbine.maxFlowRate, turbine.maxMaxFlowRate, turbine.RPM(), turbine.rotorEnergy, turbine.rotorMass, turbine.rotorAxialMass,
+		turbine.rotorCapacityPerRPM, turbine.linearBladeMetersPerRevolution, turbine.inductorDragCoefficient, turbine.inductionEfficiency, turbine.inductionEnergyExponentBonus,
+		turbine.energyGeneratedLastTick, turbine.rotorEfficiencyLastTick, turbine.coilEfficiencyLastTick, turbine.inductorDragLastTick, turbine.frictionDragLastTick, turbine.aeroDragLastTick)
+}
+
+// inUnitRange is whether value is a fraction, allowing for rounding at both ends
+func inUnitRange(value float64) bool {
+	return value >= -invariantSlack && value <= 1+invariantSlack
+}
+
+// checkFlow holds the rotor capacity to what Tick used: it can't shrink as the rotor speeds up,
+// and the steam put to use is never more than what came in nor more than twice the capacity
+func (turbine Turbine) checkFlow(rpm, flowRate, effectiveFlowRate, rotorCapacity float64) {
+	turbine.invariant(turbine.rotorCapacityPerRPM >= 0, "rotor capacity per rpm %g is negative", turbine.rotorCapacityPerRPM)
+	turbine.invariant(rotorCapacity >= turbine.rotorCapacityPerRPM*100, "rotor capacity %g at %g rpm is under the capacity at 100 rpm", rotorCapacity, rpm)
+	turbine.invariant(effectiveFlowRate <= flowRate*(1+invariantSlack), "effective flow %g is over the flow %g", effectiveFlowRate, flowRate)
+	turbine.invariant(effectiveFlowRate <= 2*rotorCapacity*(1+invariantSlack), "effective flow %g is over twice the rotor capacity %g", effectiveFlowRate, rotorCapacity)
+}
+
+// checkTick holds the values a tick leaves behind to their physical ranges
+func (turbine Turbine) checkTick() {
+	turbine.invariant(turbine.energyGeneratedLastTick >= 0 && !math.IsInf(turbine.energyGeneratedLastTick, 0), "generated %g RF", turbine.energyGeneratedLastTick)
+	turbine.invariant(inUnitRange(turbine.rotorEfficiencyLastTick), "rotor efficiency %g is outside 0 to 1", turbine.rotorEfficiencyLastTick)
+	turbine.invariant(inUnitRange(turbine.coilEfficiencyLastTick), "coil efficiency %g is outside 0 to 1", turbine.coilEfficiencyLastTick)
+	turbine.invariant(turbine.rotorEnergy >= 0 && !math.IsInf(turbine.rotorEnergy, 0), "rotor energy %g", turbine.rotorEnergy)
+	for _, drag := range []float64{turbine.inductorDragLastTick, turbine.frictionDragLastTick, turbine.aeroDragLastTick} {
+		turbine.invariant(drag >= 0, "drag %g is negative", drag)
+	}
+}
+
+// checkFinalRPM holds the steady state to a real, non-negative rpm that more steam never lowers
+func (turbine Turbine) checkFinalRPM(rpm float64) {
+	turbine.invariant(rpm >= 0 && !math.IsInf(rpm, 0), "steady state at %g rpm", rpm)
+	if turbine.maxFlowRate < turbine.maxMaxFlowRate {
+		coilDrag := turbine.inductorDragCoefficient * float64(turbine.coilSize)
+		more := steadyRPM(float64(turbine.maxFlowRate+1), turbine.rotorCapacityPerRPM, turbine.rotorDragPerRPM2(), coilDrag, turbine.rfPerHeat())
+		turbine.invariant(more >= rpm*(1-invariantSlack), "one more mB/t drops the steady state from %g to %g rpm", rpm, more)
+	}
+}
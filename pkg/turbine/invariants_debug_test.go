@@ -0,0 +1,23 @@
+//go:build debug
+
+package turbine
+
+import "testing"
+
+func TestTickChecksInvariants(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 10, 9, 2, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(2000)
+	turbine.Settle()
+
+	// a coil that gives energy back as drag would have Tick make negative energy
+	turbine.inductionEfficiency = -1
+	defer func() {
+		if recover() == nil {
+			t.Error("Tick made negative energy without panicking")
+		}
+	}()
+	turbine.Tick()
+}
@@ -0,0 +1,5 @@
+//go:build !debug
+
+package turbine
+
+const debugInvariants = false
@@ -0,0 +1,6 @@
+//go:build debug
+
+package turbine
+
+// built with -tags debug, Tick and FinalRPM panic as soon as the physics goes out of range
+const debugInvariants = true
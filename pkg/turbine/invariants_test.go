@@ -0,0 +1,24 @@
+package turbine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestInvariantPanicsWithDump(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 10, 9, 2, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		message := fmt.Sprint(recover())
+		if !strings.Contains(message, "rotor efficiency 2") || !strings.Contains(message, "size 7x8x7") {
+			t.Errorf("panic %q is missing what broke or the turbine", message)
+		}
+	}()
+	turbine.invariant(true, "never shown")
+	turbine.invariant(false, "rotor efficiency %g", 2.0)
+	t.Error("a broken invariant did not panic")
+}
@@ -0,0 +1,25 @@
+package turbine
+
+// LadderStep is the fittest turbine exactly Height blocks tall, Found is false when none of that height passes
+type LadderStep struct {
+	Height  int32
+	Found   bool
+	Turbine Turbine
+}
+
+// SizeLadder searches every height the options allow on its own, from the lowest up, so a player can see what
+// each block of height adds. It stops early when options.Cancelled does, the time budget is for each height.
+func SizeLadder(options Options) []LadderStep {
+	bounds, _ := options.bounds()
+	var steps []LadderStep
+	for height := bounds.minHeight; height <= bounds.maxHeight; height++ {
+		options.MinSize.Y = height
+		options.MaxSize.Y = height
+		result := Search(options)
+		if result.Cancelled {
+			break
+		}
+		steps = append(steps, LadderStep{Height: height, Found: result.Found, Turbine: result.Turbine})
+	}
+	return steps
+}
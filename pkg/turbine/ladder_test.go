@@ -0,0 +1,36 @@
+package turbine
+
+import "testing"
+
+func TestSizeLadder(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 12, Z: 9})
+	steps := SizeLadder(options)
+
+	if len(steps) != int(12-BiggerReactorsConfig.MinHeight+1) {
+		t.Fatalf("%d steps from height %d to 12", len(steps), BiggerReactorsConfig.MinHeight)
+	}
+	best := 0.0
+	for i, step := range steps {
+		if step.Height != BiggerReactorsConfig.MinHeight+int32(i) {
+			t.Errorf("step %d is height %d", i, step.Height)
+		}
+		if step.Found && step.Turbine.Stats().Height != step.Height {
+			t.Errorf("height %d step has a %d high turbine", step.Height, step.Turbine.Stats().Height)
+		}
+		if step.Found {
+			best = max(best, step.Turbine.Stats().EnergyGenerated)
+		}
+	}
+	if search := Search(options); search.Turbine.Stats().EnergyGenerated != best {
+		t.Errorf("best step makes %v RF/t, a search of the room %v", best, search.Turbine.Stats().EnergyGenerated)
+	}
+
+	calls := 0
+	options.Cancelled = func() bool {
+		calls++
+		return calls > 1
+	}
+	if cancelled := SizeLadder(options); len(cancelled) >= len(steps) {
+		t.Error("cancelled ladder searched every height")
+	}
+}
@@ -0,0 +1,249 @@
+package turbine
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/drabart/turbine-calculator-website/pkg/toml"
+)
+
+// modConfigField sets one Config constant from a value of the mod's config file
+type modConfigField struct {
+	// the json name and the names the mods' config files use, compared by normalizeKey
+	names []string
+	set   func(config *Config, value float64) error
+}
+
+func floatField(field func(config *Config) *float64) func(config *Config, value float64) error {
+	return func(config *Config, value float64) error {
+		*field(config) = value
+		return nil
+	}
+}
+
+func intField[T int32 | int64](field func(config *Config) *T) func(config *Config, value float64) error {
+	return func(config *Config, value float64) error {
+		if value != math.Trunc(value) || value < math.MinInt32 || value > math.MaxInt32 {
+			return fmt.Errorf("%v is not a whole number", value)
+		}
+		*field(config) = T(value)
+		return nil
+	}
+}
+
+var modConfigFields = []modConfigField{
+	{[]string{"flowRatePerBlock", "fluidPerBlock"}, intField(func(config *Config) *int64 { return &config.FlowRatePerBlock })},
+	{[]string{"latentHeat", "steamLatentHeat"}, floatField(func(config *Config) *float64 { return &config.LatentHeat })},
+	{[]string{"turbineMultiplier", "powerMultiplier", "turbinePowerMultiplier"}, floatField(func(config *Config) *float64 { return &config.TurbineMultiplier })},
+	{[]string{"fluidPerBladeLinerKilometre", "fluidPerBlade"}, floatField(func(config *Config) *float64 { return &config.FluidPerBladeLinerKilometre })},
+	{[]string{"rotorAxialMassPerShaft"}, floatField(func(config *Config) *float64 { return &config.RotorAxialMassPerShaft })},
+	{[]string{"rotorAxialMassPerBlade"}, floatField(func(config *Config) *float64 { return &config.RotorAxialMassPerBlade })},
+	{[]string{"coilDragMultiplier", "coilDrag"}, floatField(func(config *Config) *float64 { return &config.CoilDragMultiplier })},
+	{[]string{"batterySizePerCoilBlock"}, floatField(func(config *Config) *float64 { return &config.BatterySizePerCoilBlock })},
+	{[]string{"tankVolumePerBlock"}, floatField(func(config *Config) *float64 { return &config.TankVolumePerBlock })},
+	{[]string{"frictionDragMultiplier", "frictionDrag"}, floatField(func(config *Config) *float64 { return &config.FrictionDragMultiplier })},
+	{[]string{"aerodynamicDragMultiplier", "aeroDragMultiplier"}, floatField(func(config *Config) *float64 { return &config.AerodynamicDragMultiplier })},
+	{[]string{"minWidth", "minLength", "minTurbineSize"}, intField(func(config *Config) *int32 { return &config.MinWidth })},
+	{[]string{"minHeight", "minTurbineHeight"}, intField(func(config *Config) *int32 { return &config.MinHeight })},
+	{[]string{"maxWidth", "maxLength", "maxTurbineSize"}, intField(func(config *Config) *int32 { return &config.MaxWidth })},
+	{[]string{"maxHeight", "maxTurbineHeight"}, intField(func(config *Config) *int32 { return &config.MaxHeight })},
+	{[]string{"maxShaftLength"}, intField(func(config *Config) *int32 { return &config.MaxShaftLength })},
+	{[]string{"bearings"}, intField(func(config *Config) *int32 { return &config.Bearings })},
+	{[]string{"shaftLengthPerBearing"}, intField(func(config *Config) *int32 { return &config.ShaftLengthPerBearing })},
+	{[]string{"maxSafeRPM", "maxRPM"}, floatField(func(config *Config) *float64 { return &config.MaxSafeRPM })},
+	{[]string{"gridFrequency"}, floatField(func(config *Config) *float64 { return &config.GridFrequency })},
+	{[]string{"efficiencyPeaks"}, intField(func(config *Config) *int32 { return &config.EfficiencyPeaks })},
+}
+
+var coilFields = map[string]func(coil *CoilData, value float64){
+	"efficiency":     func(coil *CoilData, value float64) { coil.Efficiency = value },
+	"bonus":          func(coil *CoilData, value float64) { coil.Bonus = value },
+	"extractionrate": func(coil *CoilData, value float64) { coil.ExtractionRate = value },
+	"extraction":     func(coil *CoilData, value float64) { coil.ExtractionRate = value },
+}
+
+// normalizeKey lets maxTurbineSize, max_turbine_size and max-turbine-size match
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
+
+// ImportModConfig reads the turbine section of a Bigger or Extreme Reactors TOML config file over base. The
+// sections are the top level tables with "turbine" in their name, a file without any is read whole. Keys are
+// matched by their last part anywhere in a section, coils are the tables inside a "coils" table. The same
+// constant set to two values is an error rather than a coin toss. It returns the keys it had no use for, so a user
+// can tell what was left at the base value.
+func ImportModConfig(text string, base *Config) (*Config, []string, error) {
+	document, err := toml.Parse(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := make(map[string]int)
+	for i, field := range modConfigFields {
+		for _, name := range field.names {
+			fields[normalizeKey(name)] = i
+		}
+	}
+
+	config := base.Clone()
+	var ignored []string
+	// where each field was set and to what, to catch a section setting it twice
+	setAt := map[int]string{}
+	values := map[int]float64{}
+	var walk func(table map[string]any, path []string) error
+	walk = func(table map[string]any, path []string) error {
+		for _, key := range slices.Sorted(maps.Keys(table)) {
+			value := table[key]
+			keyPath := append(slices.Clone(path), key)
+			name := strings.Join(keyPath, ".")
+			switch value := value.(type) {
+			case map[string]any:
+				if normalized := normalizeKey(key); normalized == "coils" || normalized == "coil" {
+					if err := importCoils(config, value, name); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := walk(value, keyPath); err != nil {
+					return err
+				}
+			case int64, float64:
+				i, ok := fields[normalizeKey(key)]
+				if !ok {
+					ignored = append(ignored, name)
+					continue
+				}
+				number := toFloat(value)
+				if previous, ok := setAt[i]; ok {
+					if values[i] != number {
+						return fmt.Errorf("%s = %v and %s = %v set the same value", previous, values[i], name, number)
+					}
+					continue
+				}
+				if err := modConfigFields[i].set(config, number); err != nil {
+					return fmt.Errorf("%s: %w", name, err)
+				}
+				setAt[i], values[i] = name, number
+			default:
+				ignored = append(ignored, name)
+			}
+		}
+		return nil
+	}
+
+	sections := map[string]any{}
+	for key, value := range document {
+		if _, ok := value.(map[string]any); ok && strings.Contains(normalizeKey(key), "turbine") {
+			sections[key] = value
+		}
+	}
+	if len(sections) == 0 {
+		sections = document
+	} else {
+		for key, value := range document {
+			if _, ok := sections[key]; !ok {
+				ignored = append(ignored, leafKeys(value, key)...)
+			}
+		}
+	}
+	if err := walk(sections, nil); err != nil {
+		return nil, nil, err
+	}
+	if err := config.ValidateLimits(); err != nil {
+		return nil, nil, err
+	}
+	slices.Sort(ignored)
+	return config, ignored, nil
+}
+
+// leafKeys names every value under a key outside the turbine sections
+func leafKeys(value any, name string) []string {
+	table, ok := value.(map[string]any)
+	if !ok {
+		return []string{name}
+	}
+	var keys []string
+	for key, value := range table {
+		keys = append(keys, leafKeys(value, name+"."+key)...)
+	}
+	return keys
+}
+
+func toFloat(value any) float64 {
+	switch value := value.(type) {
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	default:
+		return math.NaN()
+	}
+}
+
+// importCoils adds or changes a coil for every table in coils, a coil missing from base needs all three values
+func importCoils(config *Config, coils map[string]any, path string) error {
+	for _, name := range slices.Sorted(maps.Keys(coils)) {
+		value := coils[name]
+		table, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s.%s has to be a table of efficiency, bonus and extractionRate", path, name)
+		}
+		// the mods name coils by their block, like "gold_block" or "Gold"
+		material := coilMaterialName(config, name)
+		coil, known := config.Coils[material]
+		set := map[string]bool{}
+		for key, value := range table {
+			setField, ok := coilFields[normalizeKey(key)]
+			if !ok {
+				return fmt.Errorf("%s.%s.%s is not a coil value", path, name, key)
+			}
+			number := toFloat(value)
+			if math.IsNaN(number) {
+				return fmt.Errorf("%s.%s.%s has to be a number", path, name, key)
+			}
+			setField(&coil, number)
+			set[normalizeKey(key)] = true
+		}
+		if !known && (!set["efficiency"] || !set["bonus"] || !set["extractionrate"] && !set["extraction"]) {
+			return fmt.Errorf("New coil %s.%s needs its efficiency, bonus and extractionRate", path, name)
+		}
+		config.Coils[material] = coil
+	}
+	return nil
+}
+
+// coilMaterialName is the coil table's name for a config file coil name, ignoring case and a "block" suffix
+func coilMaterialName(config *Config, name string) string {
+	normalized := strings.TrimSuffix(normalizeKey(name), "block")
+	for material := range config.Coils {
+		if normalizeKey(material) == normalized {
+			return material
+		}
+	}
+	return name
+}
+
+// ImportProfile adds or replaces a profile from a mod config file over the base profile, the default one if
+// baseName is empty, and returns the keys ImportModConfig ignored
+func ImportProfile(name, description, text, baseName string) ([]string, error) {
+	if name == "" {
+		return nil, ValidationError{"name", "Profile needs a name", ""}
+	}
+	if baseName == "" {
+		baseName = DefaultProfileName
+	}
+	base, err := ProfileByName(baseName)
+	if err != nil {
+		return nil, err
+	}
+
+	config, ignored, err := ImportModConfig(text, base.Config)
+	if err != nil {
+		return nil, fmt.Errorf("Profile %q: %w", name, err)
+	}
+	setProfile(Profile{name, description, config})
+	return ignored, nil
+}
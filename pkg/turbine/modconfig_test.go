@@ -0,0 +1,142 @@
+package turbine
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestImportModConfig(t *testing.T) {
+	config, ignored, err := ImportModConfig(`
+[General]
+enableComputerCraft = true
+
+[Turbine]
+maxTurbineSize = 24
+max_turbine_height = 48
+powerMultiplier = 3.0
+fluidPerBlade = 25
+coilDrag = 12
+
+[Turbine.coils.gold_block]
+efficiency = 0.7
+
+[Turbine.coils.Tin]
+efficiency = 0.4
+bonus = 1
+extractionRate = 0.11
+`, &BiggerReactorsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.MaxWidth != 24 || config.MaxHeight != 48 {
+		t.Errorf("max size %dx%d, want 24x48", config.MaxWidth, config.MaxHeight)
+	}
+	if config.TurbineMultiplier != 3 || config.FluidPerBladeLinerKilometre != 25 || config.CoilDragMultiplier != 12 {
+		t.Errorf("constants %v %v %v", config.TurbineMultiplier, config.FluidPerBladeLinerKilometre, config.CoilDragMultiplier)
+	}
+	if config.LatentHeat != BiggerReactorsConfig.LatentHeat {
+		t.Errorf("LatentHeat = %v, want the base value", config.LatentHeat)
+	}
+	if gold := config.Coils["Gold"]; gold.Efficiency != 0.7 || gold.ExtractionRate != biggerReactorsCoils["Gold"].ExtractionRate {
+		t.Errorf("Gold coil %+v", gold)
+	}
+	if _, ok := config.Coils["Tin"]; !ok {
+		t.Error("new coil missing")
+	}
+	if BiggerReactorsConfig.Coils["Gold"].Efficiency != biggerReactorsCoils["Gold"].Efficiency || BiggerReactorsConfig.MaxWidth != 32 {
+		t.Error("import changed the bundled config")
+	}
+	if want := []string{"General.enableComputerCraft"}; !slices.Equal(ignored, want) {
+		t.Errorf("ignored %v, want %v", ignored, want)
+	}
+}
+
+func TestImportModConfigReactorSection(t *testing.T) {
+	// the mods keep the reactor limits next to the turbine ones under the same names
+	text := `
+[Reactor]
+MaxLength = 128
+MaxHeight = 256
+
+[Turbine]
+MaxLength = 32
+MaxHeight = 192
+`
+	for range 50 {
+		config, ignored, err := ImportModConfig(text, &BiggerReactorsConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.MaxWidth != 32 || config.MaxHeight != 192 {
+			t.Fatalf("max size %dx%d, want the turbine's 32x192", config.MaxWidth, config.MaxHeight)
+		}
+		if want := []string{"Reactor.MaxHeight", "Reactor.MaxLength"}; !slices.Equal(ignored, want) {
+			t.Fatalf("ignored %v, want %v", ignored, want)
+		}
+	}
+
+	if _, _, err := ImportModConfig("[Turbine]\nmaxLength = 32\n[Turbine.limits]\nmaxTurbineSize = 24", &BiggerReactorsConfig); err == nil {
+		t.Error("expected an error for a turbine section setting the max width twice")
+	}
+}
+
+func TestImportModConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"not toml":       "maxTurbineSize =",
+		"fraction":       "maxTurbineSize = 24.5",
+		"too wide":       "maxTurbineSize = 4096",
+		"partial coil":   "[coils.Tin]\nefficiency = 0.4",
+		"unknown coil":   "[coils.Gold]\nspeed = 2",
+		"coil not table": "[coils]\nGold = 2",
+	}
+	for name, text := range tests {
+		if _, _, err := ImportModConfig(text, &BiggerReactorsConfig); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestImportModConfigRejectsConstants(t *testing.T) {
+	// each of these made every steady state NaN
+	tests := map[string]string{
+		"latentHeat":                  "latentHeat = -4",
+		"turbineMultiplier":           "powerMultiplier = 0",
+		"fluidPerBladeLinerKilometre": "fluidPerBlade = -1",
+		"rotorAxialMassPerShaft":      "rotorAxialMassPerShaft = 0",
+		"rotorAxialMassPerBlade":      "rotorAxialMassPerBlade = -100",
+		"coilDragMultiplier":          "coilDrag = -1",
+		"frictionDragMultiplier":      "frictionDrag = -0.1",
+		"aerodynamicDragMultiplier":   "aeroDragMultiplier = -0.1",
+	}
+	for field, line := range tests {
+		_, _, err := ImportModConfig("[Turbine]\n"+line, &BiggerReactorsConfig)
+		var validationErr ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != field {
+			t.Errorf("%s: got %v, want a ValidationError for %s", line, err, field)
+		}
+	}
+	// no drag at all is a valid if odd config
+	if _, _, err := ImportModConfig("[Turbine]\nfrictionDrag = 0", &BiggerReactorsConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestImportProfile(t *testing.T) {
+	restoreProfiles(t)
+
+	if _, err := ImportProfile("MyPack", "", "[turbine]\nmaxTurbineHeight = 64", "ExtremeReactors-2.0"); err != nil {
+		t.Fatal(err)
+	}
+	profile, err := ProfileByName("MyPack")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Config.Variant != ExtremeReactors || profile.Config.MaxHeight != 64 {
+		t.Errorf("got %s with max height %d", profile.Config.Variant, profile.Config.MaxHeight)
+	}
+	if _, err := ImportProfile("", "", "", ""); err == nil {
+		t.Error("imported a profile without a name")
+	}
+}
@@ -0,0 +1,645 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type FlowSettingVariant int64
+
+const (
+	UseMaxFlow FlowSettingVariant = iota
+	FindBestFlow
+	UseSetFlow
+	FindBestUnderFlow
+	// Value is the rpm to hold, the flow rate is solved for each geometry
+	UseTargetRPM
+	// fine sweep in a small window around Value
+	FindBestNearFlow
+)
+
+type FlowSetting struct {
+	Variant FlowSettingVariant
+	Value   int64
+	// upper bound for FindBestNearFlow, zero means only the turbine's max flow rate
+	Limit int64
+	// FindBestUnderFlow narrows its steps down to this many mB/t, zero means defaultFlowTolerance
+	Tolerance int64
+}
+
+// FindBestUnderFlow looks this far under Value, first in coarse steps and then halving the step
+// around the fittest rate so far
+const underFlowWindow = 10000
+const underFlowCoarseStep = 1000
+const defaultFlowTolerance = 100
+
+// FindBestNearFlow sweeps this fraction of the center flow rate each way, in nearFlowSteps steps
+const nearFlowWindow = 0.05
+const minNearFlowWindow = 500
+const nearFlowSteps = 100
+
+type Options struct {
+	Config *Config
+
+	Fitness     func(Turbine) float64
+	Constraints func(Turbine) bool
+	Coil        CoilData
+	// rotor blade type and working fluid, the config's defaults when left empty
+	Blade BladeMaterial
+	Fluid FluidMaterial
+	Flow  FlowSetting
+	// room the turbine has to fit in, turbines are square so the narrower of X and Z bounds the width.
+	// A zero Z is taken to be the same as X.
+	MaxSize Size
+	// lower bound on the outer size, zero values fall back to the config minimum
+	MinSize Size
+
+	// bounds on the number of coil layers, zero leaves that side open
+	MinCoilLayers int32
+	MaxCoilLayers int32
+
+	// whether the turbine has to stay within one chunk
+	Chunks ChunkSetting
+
+	// leave a one block wide, three block tall passage along a wall of the room
+	KeepDoorway bool
+
+	// also try the top coil layer with only part of its outermost ring filled
+	PartialRings bool
+
+	// also try leaving the upper coil layers idle, which takes drag off the rotor at high flow rates
+	PartialEngagement bool
+
+	// skip flow rates that would push the rotor over Config.MaxSafeRPM if the coils disengaged
+	LimitNoLoadRPM bool
+
+	// coil efficiency peak the rotor has to settle near, 1 is the highest and 2 the one an octave below,
+	// zero takes any rpm
+	TargetPeak int32
+
+	// precision used while ranking candidates, the returned turbine is always converged with exact math
+	SearchPrecision Precision
+
+	// the search stops early with the best turbine so far once either budget runs out, zero means no limit
+	MaxEvaluations int64
+	TimeBudget     time.Duration
+	// polled as often as the clock, returning true stops the search like a spent budget
+	Cancelled func() bool
+
+	// heat maps with more cells skip sizes evenly until they fit, zero maps every size
+	MaxHeatMapCells int
+
+	// set by Continuous, geometries it holds are not evaluated again
+	cache *evaluationCache
+}
+
+type SearchResult struct {
+	Turbine Turbine
+	// the search ran out of budget or was cancelled before trying every candidate
+	Truncated   bool
+	Cancelled   bool
+	Evaluations int64
+	// geometries a Continuous search took from its cache instead of evaluating
+	Reused int64
+	// false when no candidate passed the constraints, Turbine is empty then
+	Found bool
+	// chunks the turbine covers from Options.Chunks' offset
+	ChunkSpan ChunkSpan
+	// what the search changed about the options, worded for the user
+	Notes []string
+
+	// what the search covered and how long it took
+	Telemetry SearchTelemetry
+
+	// the target peak doesn't exist, so nothing was searched
+	badPeak bool
+	// FindBestFlow was asked to step by a flow rate that never moves, so nothing was searched
+	badFlowStep bool
+	// what NewTurbine said about the first geometry it turned down
+	firstSkip string
+}
+
+// SearchTelemetry counts the candidates left after each filter of a search, in the order they are applied,
+// so it shows how much of the room the search covered and which option ruled out the most
+type SearchTelemetry struct {
+	// outer sizes within the room and the minimum size, and the ones the chunk setting allows
+	Sizes   int64 `json:"sizes"`
+	InChunk int64 `json:"inChunk"`
+	// sizes with every coil layer count and outer ring fill, the ones that can be built and the built ones
+	// Options.Constraints turned down
+	Geometries        int64 `json:"geometries"`
+	Built             int64 `json:"built"`
+	FailedConstraints int64 `json:"failedConstraints"`
+	// flow rates tried, cached ones included, then the ones safe without load and the ones near the target peak
+	FlowRates       int64 `json:"flowRates"`
+	SafeWithoutLoad int64 `json:"safeWithoutLoad"`
+	NearPeak        int64 `json:"nearPeak"`
+
+	Duration time.Duration `json:"-"`
+	// Duration in milliseconds, for js
+	DurationMs float64 `json:"durationMs"`
+}
+
+// add sums the telemetry of two passes over the room
+func (telemetry *SearchTelemetry) add(other SearchTelemetry) {
+	telemetry.Sizes += other.Sizes
+	telemetry.InChunk += other.InChunk
+	telemetry.Geometries += other.Geometries
+	telemetry.Built += other.Built
+	telemetry.FailedConstraints += other.FailedConstraints
+	telemetry.FlowRates += other.FlowRates
+	telemetry.SafeWithoutLoad += other.SafeWithoutLoad
+	telemetry.NearPeak += other.NearPeak
+	telemetry.setDuration(telemetry.Duration + other.Duration)
+}
+
+// finish records the time since the search started
+func (telemetry *SearchTelemetry) finish(start time.Time) {
+	telemetry.setDuration(time.Since(start))
+}
+
+func (telemetry *SearchTelemetry) setDuration(duration time.Duration) {
+	telemetry.Duration = duration
+	telemetry.DurationMs = float64(duration) / float64(time.Millisecond)
+}
+
+// how many evaluations go by between looking at the clock
+const timeCheckInterval = 256
+
+func NewOptions(fitnessFunction func(Turbine) float64, constraintsFunction func(Turbine) bool, coilType CoilData, flowSetting FlowSetting, maxSize Size) Options {
+	return Options{
+		Config: &BiggerReactorsConfig,
+
+		Fitness:     fitnessFunction,
+		Constraints: constraintsFunction,
+		Coil:        coilType,
+		Flow:        flowSetting,
+		MaxSize:     maxSize,
+
+		SearchPrecision: PrecisionFast,
+	}
+}
+
+// applyMaterials fits the blade type and fluid of the options to a freshly built turbine
+func (options Options) applyMaterials(turbine *Turbine) {
+	if options.Blade != (BladeMaterial{}) {
+		turbine.SetBlade(options.Blade)
+	}
+	if options.Fluid != (FluidMaterial{}) {
+		turbine.SetFluid(options.Fluid)
+	}
+}
+
+// FindOptimalTurbine is Search for callers that only want the turbine, an InfeasibleError says why there is none
+func FindOptimalTurbine(options Options) (Turbine, error) {
+	result := Search(options)
+	return result.Turbine, result.Err()
+}
+
+// Err is nil when the search found a turbine, and otherwise an InfeasibleError naming the option that ruled
+// out the last candidates
+func (result SearchResult) Err() error {
+	if result.Found {
+		return nil
+	}
+	telemetry := result.Telemetry
+	switch {
+	case result.Cancelled:
+		return InfeasibleError{"cancelled", "The search was cancelled before it found a turbine"}
+	case result.Truncated:
+		return InfeasibleError{"budget", "The search ran out of time before it found a turbine"}
+	case result.badPeak:
+		return InfeasibleError{"targetPeak", "The target efficiency peak doesn't exist"}
+	case result.badFlowStep:
+		return InfeasibleError{"flow", "The flow rate step of a best flow search has to be positive"}
+	case telemetry.Sizes == 0:
+		return InfeasibleError{"maxSize", "No turbine size fits between the minimum size and the room"}
+	case telemetry.InChunk == 0:
+		return InfeasibleError{"chunks", "No turbine that fits the room stays within one chunk"}
+	case telemetry.Geometries == 0:
+		return InfeasibleError{"coilLayers", "No turbine that fits the room can hold the coil layers asked for"}
+	case telemetry.Built == 0:
+		return InfeasibleError{"maxSize", fmt.Sprintf("No turbine that fits the room can be built, %s", result.firstSkip)}
+	case telemetry.FailedConstraints == telemetry.Built:
+		return InfeasibleError{"constraints", "Every turbine that fits the room breaks the constraints"}
+	case telemetry.FlowRates == 0:
+		return InfeasibleError{"flow", "No turbine that fits the room can run at the flow rate asked for"}
+	case telemetry.SafeWithoutLoad == 0:
+		return InfeasibleError{"limitNoLoadRPM", "Every turbine that fits the room would overspeed if its coils disengaged"}
+	case telemetry.NearPeak == 0:
+		return InfeasibleError{"targetPeak", "No turbine that fits the room settles near the target efficiency peak"}
+	default:
+		return InfeasibleError{"fitness", "Every turbine that fits the room falls short of the fitness, like a minimum energy"}
+	}
+}
+
+// TODO repeat-N statistics (best, median, variance) for a stochastic optimizer, Search is an exhaustive
+// deterministic scan so repeated runs always agree and there is nothing to aggregate yet
+func Search(options Options) SearchResult {
+	if options.Chunks.Mode != PreferOneChunk {
+		return search(options)
+	}
+
+	// look within one chunk first and fall back to the whole space
+	options.Chunks.Mode = RequireOneChunk
+	result := search(options)
+	if result.Found || result.Cancelled {
+		return result
+	}
+	first := result
+	options.Chunks.Mode = IgnoreChunks
+	result = search(options)
+	result.Evaluations += first.Evaluations
+	result.Telemetry.add(first.Telemetry)
+	if result.Found {
+		result.Notes = append(result.Notes, fmt.Sprintf("No turbine fits in one chunk, this one spans %d", result.ChunkSpan.Chunks))
+	}
+	return result
+}
+
+func search(options Options) SearchResult {
+	var bestTurbine Turbine
+	bestFitness := math.Inf(-1)
+
+	result := SearchResult{}
+	start := time.Now()
+	var deadline time.Time
+	if options.TimeBudget > 0 {
+		deadline = time.Now().Add(options.TimeBudget)
+	}
+	outOfBudget := func() bool {
+		if options.MaxEvaluations > 0 && result.Evaluations >= options.MaxEvaluations {
+			return true
+		}
+		if result.Evaluations%timeCheckInterval != 0 {
+			return false
+		}
+		if options.Cancelled != nil && options.Cancelled() {
+			result.Cancelled = true
+			return true
+		}
+		return !deadline.IsZero() && time.Now().After(deadline)
+	}
+
+	fitnessFunction := options.Fitness
+	constraintsFunction := options.Constraints
+	flowSetting := options.Flow
+	config := options.Config
+
+	bounds, notes := options.bounds()
+	result.Notes = append(result.Notes, notes...)
+	if peaks := config.efficiencyCurve().peaks; options.TargetPeak < 0 || options.TargetPeak > peaks {
+		result.Notes = append(result.Notes, fmt.Sprintf("There is no efficiency peak %d, the coils peak %d times", options.TargetPeak, peaks))
+		result.badPeak = true
+		result.Telemetry.finish(start)
+		return result
+	}
+	if flowSetting.Variant == FindBestFlow && flowSetting.Value <= 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("The best flow rate can't be searched in steps of %d mB/t", flowSetting.Value))
+		result.badFlowStep = true
+		result.Telemetry.finish(start)
+		return result
+	}
+
+	// fittest turbine with every coil layer engaged, to tell whether partial engagement ever won
+	bestFullFitness := math.Inf(-1)
+
+	// geometries NewTurbine turns down, reported once at the end
+	skipped := 0
+	firstSkip := ""
+
+search:
+	for height := int(bounds.minHeight); height <= int(bounds.maxHeight); height++ {
+		for width := int(bounds.minWidth); width <= int(bounds.maxWidth); width += 2 {
+			result.Telemetry.Sizes++
+			if !options.Chunks.allows(int32(width)) {
+				continue
+			}
+			result.Telemetry.InChunk++
+			minCoilLayers, maxCoilLayers := options.coilLayerRange(int32(height))
+			for coilLayers := int(minCoilLayers); coilLayers <= int(maxCoilLayers); coilLayers++ {
+				for _, outerRingCoils := range options.outerRingChoices(int32(width)) {
+					result.Telemetry.Geometries++
+					turbine, err := NewTurbineWithOuterRing(config, int32(height), int32(width), int32(coilLayers), outerRingCoils, options.Coil)
+					if err != nil {
+						if skipped == 0 {
+							firstSkip = fmt.Sprintf("%dx%d with %d coil layers: %s", width, height, coilLayers, err)
+						}
+						skipped++
+						continue
+					}
+					result.Telemetry.Built++
+					options.applyMaterials(&turbine)
+					turbine.SetPrecision(options.SearchPrecision)
+
+					if !constraintsFunction(turbine) {
+						result.Telemetry.FailedConstraints++
+						continue
+					}
+
+					for _, engagedCoilLayers := range options.engagementChoices(int32(coilLayers)) {
+						candidate := turbine
+						if err := candidate.EngageCoilLayers(engagedCoilLayers); err != nil {
+							continue
+						}
+
+						key := geometryKey{int32(height), int32(width), int32(coilLayers), outerRingCoils, engagedCoilLayers}
+						if cached, ok := options.cache.lookup(key); ok {
+							result.Reused++
+							result.Telemetry.FlowRates += cached.flowRates
+							result.Telemetry.SafeWithoutLoad += cached.safe
+							result.Telemetry.NearPeak += cached.nearPeak
+							if cached.fitness > bestFitness {
+								candidate.SetNominalFlowRate(cached.flowRate)
+								candidate.Settle()
+								bestTurbine = candidate
+								bestFitness = cached.fitness
+							}
+							if engagedCoilLayers == int32(coilLayers) {
+								bestFullFitness = max(bestFullFitness, cached.fitness)
+							}
+							continue
+						}
+
+						geometryBest := cachedGeometry{fitness: math.Inf(-1)}
+						before := result.Telemetry
+						stopped := flowSetting.tryFlowRates(candidate, func(flowRate int64) (float64, bool) {
+							if outOfBudget() {
+								result.Truncated = true
+								return 0, false
+							}
+							result.Evaluations++
+							result.Telemetry.FlowRates++
+
+							// set the rate to test
+							candidate.SetNominalFlowRate(flowRate)
+							if options.LimitNoLoadRPM && !candidate.safeWithoutLoad() {
+								return math.Inf(-1), true
+							}
+							result.Telemetry.SafeWithoutLoad++
+
+							// jump to the rpm from the closed form and tick to get all the bonus data
+							candidate.Settle()
+							if !candidate.curve.nearPeak(candidate.RPM(), options.TargetPeak) {
+								return math.Inf(-1), true
+							}
+							result.Telemetry.NearPeak++
+
+							// evaluate the turbine with the provided fitness function
+							turbineFitness := fitnessFunction(candidate)
+
+							if turbineFitness > bestFitness {
+								// candidate.PrintStats()
+								bestTurbine = candidate
+								bestFitness = turbineFitness
+							}
+							if turbineFitness > geometryBest.fitness {
+								geometryBest.fitness = turbineFitness
+								geometryBest.flowRate = candidate.maxFlowRate
+							}
+							if engagedCoilLayers == int32(coilLayers) {
+								bestFullFitness = max(bestFullFitness, turbineFitness)
+							}
+							return turbineFitness, true
+						})
+						if stopped {
+							break search
+						}
+						// only geometries whose every flow rate was tried are kept
+						geometryBest.flowRates = result.Telemetry.FlowRates - before.FlowRates
+						geometryBest.safe = result.Telemetry.SafeWithoutLoad - before.SafeWithoutLoad
+						geometryBest.nearPeak = result.Telemetry.NearPeak - before.NearPeak
+						options.cache.store(key, geometryBest)
+					}
+				}
+			}
+		}
+	}
+
+	result.firstSkip = firstSkip
+	if skipped > 0 {
+		result.Notes = append(result.Notes, fmt.Sprintf("Skipped %d invalid designs, the first was %s", skipped, firstSkip))
+	}
+
+	// the search only ranks candidates, re-evaluate the winner as accurately as possible
+	if !math.IsInf(bestFitness, -1) {
+		bestTurbine.Converge()
+		result.Found = true
+		result.ChunkSpan = options.Chunks.Span(bestTurbine.Stats().Width)
+		if options.PartialEngagement {
+			result.Notes = append(result.Notes, engagementNote(bestTurbine, bestFitness, bestFullFitness))
+		}
+	}
+
+	result.Turbine = bestTurbine
+	result.Telemetry.finish(start)
+	return result
+}
+
+// searchBounds is the range of outer sizes a search walks through
+type searchBounds struct {
+	minHeight, maxHeight int32
+	minWidth, maxWidth   int32
+}
+
+// bounds fits the room to the config, with a note for every limit that cut it down
+func (options Options) bounds() (searchBounds, []string) {
+	config := options.Config
+	notes := []string{}
+
+	minHeight := max(config.MinHeight, options.MinSize.Y)
+	// widths are odd, so round an even lower bound up
+	minWidth := max(config.MinWidth, options.MinSize.X)
+	minWidth += 1 - minWidth%2
+	roomWidth := options.roomWidth()
+	if roomWidth < min(options.MaxSize.X, options.depth()) {
+		notes = append(notes, fmt.Sprintf("Leaving a doorway along the wall, searched up to width %d", roomWidth))
+	}
+	maxWidth := min(roomWidth, config.MaxWidth)
+	if maxWidth < roomWidth {
+		notes = append(notes, fmt.Sprintf("Max width %d is over the %s limit of %d, searched up to %d", roomWidth, config.Variant, config.MaxWidth, maxWidth))
+	}
+	maxHeight := min(options.MaxSize.Y, config.MaxHeight)
+	if maxHeight < options.MaxSize.Y {
+		notes = append(notes, fmt.Sprintf("Max height %d is over the %s limit of %d, searched up to %d", options.MaxSize.Y, config.Variant, config.MaxHeight, maxHeight))
+	}
+	if limit := config.ShaftLimit(); limit > 0 && maxHeight > limit+2 {
+		maxHeight = limit + 2
+		notes = append(notes, fmt.Sprintf("Rotor shaft can be at most %d blocks long, searched up to height %d", limit, maxHeight))
+	}
+	return searchBounds{minHeight, maxHeight, minWidth, maxWidth}, notes
+}
+
+// coilLayerRange is the coil layer counts tried at a height
+func (options Options) coilLayerRange(height int32) (int32, int32) {
+	maxCoilLayers := height - 3
+	if options.MaxCoilLayers > 0 {
+		maxCoilLayers = min(maxCoilLayers, options.MaxCoilLayers)
+	}
+	return max(1, options.MinCoilLayers), maxCoilLayers
+}
+
+// depth is the room's Z size, the same as its X size unless given
+func (options Options) depth() int32 {
+	if options.MaxSize.Z == 0 {
+		return options.MaxSize.X
+	}
+	return options.MaxSize.Z
+}
+
+// roomWidth is the widest square turbine that fits the room
+func (options Options) roomWidth() int32 {
+	x, z := options.MaxSize.X, options.depth()
+	if options.KeepDoorway && options.MaxSize.Y >= doorwayHeight {
+		// the passage goes along the longer side, where it takes the least from the turbine
+		if x >= z {
+			x--
+		} else {
+			z--
+		}
+	}
+	return min(x, z)
+}
+
+// a player needs this much headroom to walk through
+const doorwayHeight = 3
+
+// partial outer rings are tried in steps of this fraction of the ring
+const partialRingSteps = 4
+
+// outerRingChoices lists the outer ring fills to try, just the full ring unless PartialRings is set
+func (options Options) outerRingChoices(width int32) []int64 {
+	ringSize := OuterRingSize(width)
+	if !options.PartialRings {
+		return []int64{ringSize}
+	}
+
+	choices := []int64{}
+	for step := int64(1); step < partialRingSteps; step++ {
+		choices = append(choices, ringSize*step/partialRingSteps)
+	}
+	return append(choices, ringSize)
+}
+
+// engagementChoices lists the engaged coil layer counts to try, every layer first and fewer only with PartialEngagement
+func (options Options) engagementChoices(coilLayers int32) []int32 {
+	if !options.PartialEngagement {
+		return []int32{coilLayers}
+	}
+
+	choices := []int32{}
+	for layers := coilLayers; layers >= 1; layers-- {
+		choices = append(choices, layers)
+	}
+	return choices
+}
+
+// engagementNote tells whether idling some coil layers beat engaging all of them
+func engagementNote(best Turbine, bestFitness, bestFullFitness float64) string {
+	stats := best.Stats()
+	if stats.EngagedCoilLayers == stats.CoilLayers {
+		return "Partial coil engagement never beat engaging every coil layer"
+	}
+	if math.IsInf(bestFullFitness, -1) {
+		return fmt.Sprintf("Only %d of %d coil layers engaged, no fully engaged turbine passed the options", stats.EngagedCoilLayers, stats.CoilLayers)
+	}
+	return fmt.Sprintf("Engaging %d of %d coil layers beat every fully engaged turbine, fitness %.4g against %.4g", stats.EngagedCoilLayers, stats.CoilLayers, bestFitness, bestFullFitness)
+}
+
+// tryFlowRates hands try the flow rates to evaluate on a turbine, try returns the fitness at a rate
+// or false to stop. Returns whether try stopped it.
+func (flowSetting FlowSetting) tryFlowRates(turbine Turbine, try func(flowRate int64) (float64, bool)) bool {
+	if flowSetting.Variant == FindBestUnderFlow {
+		return flowSetting.tryUnderFlow(turbine, try)
+	}
+	for _, flowRate := range flowSetting.flowRates(turbine) {
+		if _, ok := try(flowRate); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tryUnderFlow steps coarsely through the window under Value, then closes in on the fittest rate
+// by trying half the step to either side of it until the step is down to the tolerance
+func (flowSetting FlowSetting) tryUnderFlow(turbine Turbine, try func(flowRate int64) (float64, bool)) bool {
+	lower := max(0, flowSetting.Value-underFlowWindow)
+	upper := min(turbine.maxMaxFlowRate, flowSetting.Value)
+	if upper < lower {
+		return false
+	}
+	tolerance := flowSetting.Tolerance
+	if tolerance <= 0 {
+		tolerance = defaultFlowTolerance
+	}
+
+	best := lower
+	bestFitness := math.Inf(-1)
+	tryRate := func(flowRate int64) bool {
+		fitness, ok := try(flowRate)
+		if fitness > bestFitness {
+			best = flowRate
+			bestFitness = fitness
+		}
+		return ok
+	}
+
+	step := max(underFlowCoarseStep, tolerance)
+	for flowRate := lower; ; flowRate += step {
+		// always try the upper end, it is often the best
+		flowRate = min(flowRate, upper)
+		if !tryRate(flowRate) {
+			return true
+		}
+		if flowRate == upper {
+			break
+		}
+	}
+
+	for step > tolerance {
+		step = max(step/2, tolerance)
+		center := best
+		for _, flowRate := range []int64{center - step, center + step} {
+			// rates outside the window are still tried at the edge so the count doesn't depend on the turbine
+			if !tryRate(min(max(flowRate, lower), upper)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// flowRates lists the flow rates to try on a turbine for every variant but FindBestUnderFlow
+func (flowSetting FlowSetting) flowRates(turbine Turbine) []int64 {
+	flowRates := []int64{}
+	switch flowSetting.Variant {
+	case UseMaxFlow:
+		flowRates = append(flowRates, turbine.maxMaxFlowRate)
+	case FindBestFlow:
+		// a step that never moves would never reach the max flow rate
+		for flowRate := flowSetting.Value; flowSetting.Value > 0 && flowRate <= turbine.maxMaxFlowRate; flowRate += flowSetting.Value {
+			flowRates = append(flowRates, int64(flowRate))
+		}
+	case UseSetFlow:
+		flowRates = append(flowRates, flowSetting.Value)
+	case UseTargetRPM:
+		if flowRate, ok := turbine.FlowForRPM(float64(flowSetting.Value)); ok {
+			flowRates = append(flowRates, int64(math.Round(flowRate)))
+		}
+	case FindBestNearFlow:
+		window := max(minNearFlowWindow, int64(float64(flowSetting.Value)*nearFlowWindow))
+		step := max(1, 2*window/nearFlowSteps)
+		upper := min(turbine.maxMaxFlowRate, flowSetting.Value+window)
+		if flowSetting.Limit > 0 {
+			upper = min(upper, flowSetting.Limit)
+		}
+		for flowRate := max(0, flowSetting.Value-window); flowRate <= upper; flowRate += step {
+			flowRates = append(flowRates, flowRate)
+		}
+	default:
+		panic("Invalid FlowSettingVariant")
+	}
+
+	return flowRates
+}
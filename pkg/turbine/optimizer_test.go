@@ -0,0 +1,410 @@
+package turbine
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func energyFitness(turbine Turbine) float64 {
+	return turbine.Stats().EnergyGenerated
+}
+
+func noConstraints(Turbine) bool {
+	return true
+}
+
+func TestFindOptimalTurbineStaysWithinMaxSize(t *testing.T) {
+	maxSize := Size{X: 9, Y: 12, Z: 9}
+	best, err := FindOptimalTurbine(NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: UseSetFlow, Value: 20000}, maxSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := best.Stats()
+
+	if stats.Width > maxSize.X || stats.Height > maxSize.Y {
+		t.Errorf("best turbine %dx%d exceeds max size %dx%d", stats.Width, stats.Height, maxSize.X, maxSize.Y)
+	}
+	if stats.EnergyGenerated <= 0 {
+		t.Errorf("best turbine generates %.1f RF/t", stats.EnergyGenerated)
+	}
+}
+
+func TestFindOptimalTurbineBeatsEveryCandidate(t *testing.T) {
+	maxSize := Size{X: 7, Y: 8, Z: 7}
+	flowSetting := FlowSetting{Variant: UseSetFlow, Value: 8000}
+	best, err := FindOptimalTurbine(NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], flowSetting, maxSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for height := BiggerReactorsConfig.MinHeight; height <= maxSize.Y; height++ {
+		for width := BiggerReactorsConfig.MinWidth; width <= maxSize.X; width += 2 {
+			for coilLayers := int32(1); coilLayers <= height-3; coilLayers++ {
+				turbine, err := NewTurbine(&BiggerReactorsConfig, height, width, coilLayers, biggerReactorsCoils["Gold"])
+				if err != nil {
+					t.Fatal(err)
+				}
+				turbine.SetNominalFlowRate(flowSetting.Value)
+				turbine.SetPrecision(PrecisionExact)
+				turbine.Settle()
+
+				if energyFitness(turbine) > energyFitness(best) {
+					t.Errorf("%dx%d with %d coil layers beats the optimizer result", width, height, coilLayers)
+				}
+			}
+		}
+	}
+}
+
+func TestFindOptimalTurbineRespectsConstraints(t *testing.T) {
+	maxSize := Size{X: 9, Y: 10, Z: 9}
+	onlyNarrow := func(turbine Turbine) bool {
+		return turbine.Stats().Width <= 7
+	}
+	best, err := FindOptimalTurbine(NewOptions(energyFitness, onlyNarrow, biggerReactorsCoils["Iron"], FlowSetting{Variant: UseMaxFlow}, maxSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if width := best.Stats().Width; width > 7 {
+		t.Errorf("constraint ignored, got width %d", width)
+	}
+}
+
+func TestFindOptimalTurbineCoilLayerBounds(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 14, Z: 9})
+	options.MinCoilLayers = 2
+	options.MaxCoilLayers = 2
+
+	best, err := FindOptimalTurbine(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := best.Stats()
+	// a full layer fills the interior except for the shaft
+	interior := int64(stats.Width - 2)
+	if coilLayers := stats.CoilSize / (interior*interior - 1); coilLayers != 2 {
+		t.Errorf("got %d coil layers, want exactly 2", coilLayers)
+	}
+}
+
+func TestFindOptimalTurbineTargetRPM(t *testing.T) {
+	for _, targetRPM := range []int64{900, 1800} {
+		options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: UseTargetRPM, Value: targetRPM}, Size{X: 13, Y: 16, Z: 13})
+		best, err := FindOptimalTurbine(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the flow rate is rounded to whole mB/t so the rpm lands close to but not exactly on target
+		if rpm := best.RPM(); math.Abs(rpm-float64(targetRPM)) > 1 {
+			t.Errorf("target %d rpm, got %.2f", targetRPM, rpm)
+		}
+	}
+}
+
+func TestSearchEvaluationBudget(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseSetFlow, Value: 8000}, Size{X: 9, Y: 10, Z: 9})
+
+	full := Search(options)
+	if full.Truncated {
+		t.Fatal("unlimited search was truncated")
+	}
+
+	options.MaxEvaluations = full.Evaluations / 2
+	truncated := Search(options)
+	if !truncated.Truncated {
+		t.Error("search over budget not flagged as truncated")
+	}
+	if truncated.Evaluations != options.MaxEvaluations {
+		t.Errorf("ran %d evaluations with a budget of %d", truncated.Evaluations, options.MaxEvaluations)
+	}
+	if truncated.Turbine.Stats().EnergyGenerated <= 0 {
+		t.Error("truncated search lost its best so far")
+	}
+
+	options.MaxEvaluations = full.Evaluations
+	if Search(options).Truncated {
+		t.Error("a budget that fits the whole search should not truncate")
+	}
+}
+
+func TestSearchTimeBudget(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1}, Size{X: 31, Y: 64, Z: 31})
+	options.TimeBudget = 20 * time.Millisecond
+
+	start := time.Now()
+	result := Search(options)
+	if !result.Truncated {
+		t.Error("huge search finished within 20ms")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("time budget ignored, search took %s", elapsed)
+	}
+}
+
+func TestSearchClampsToConfigLimits(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, ExtremeReactorsConfig.Coils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 7, Y: 40, Z: 7})
+	options.Config = &ExtremeReactorsConfig
+
+	result := Search(options)
+	if height := result.Turbine.Stats().Height; height > ExtremeReactorsConfig.MaxHeight {
+		t.Errorf("height %d is over the limit", height)
+	}
+	if len(result.Notes) != 1 {
+		t.Errorf("got notes %q, want one about the height", result.Notes)
+	}
+
+	options.MaxSize.Y = 20
+	if notes := Search(options).Notes; len(notes) != 0 {
+		t.Errorf("got notes %q for a size within the limits", notes)
+	}
+}
+
+func TestSearchPartialRings(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseSetFlow, Value: 8000}, Size{X: 9, Y: 10, Z: 9})
+	full := Search(options)
+	options.PartialRings = true
+	partial := Search(options)
+
+	if partial.Evaluations != 4*full.Evaluations {
+		t.Errorf("partial ring search ran %d evaluations, want %d", partial.Evaluations, 4*full.Evaluations)
+	}
+	if partial.Turbine.Stats().EnergyGenerated < full.Turbine.Stats().EnergyGenerated {
+		t.Errorf("partial ring search found %.1f RF/t, full rings alone %.1f", partial.Turbine.Stats().EnergyGenerated, full.Turbine.Stats().EnergyGenerated)
+	}
+}
+
+func TestSearchFitsRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		room        Size
+		keepDoorway bool
+		wantWidth   int32
+	}{
+		{"square", Size{X: 11, Y: 10, Z: 11}, false, 11},
+		{"shallow", Size{X: 11, Y: 10, Z: 7}, false, 7},
+		{"depth defaults to width", Size{X: 9, Y: 10}, false, 9},
+		{"doorway along the longer side", Size{X: 11, Y: 10, Z: 9}, true, 9},
+		{"doorway in a square room", Size{X: 11, Y: 10, Z: 11}, true, 9},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			widest := int32(0)
+			recordWidth := func(turbine Turbine) bool {
+				widest = max(widest, turbine.Stats().Width)
+				return true
+			}
+			options := NewOptions(energyFitness, recordWidth, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, tc.room)
+			options.KeepDoorway = tc.keepDoorway
+			Search(options)
+			if widest != tc.wantWidth {
+				t.Errorf("searched up to width %d, want %d", widest, tc.wantWidth)
+			}
+		})
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1}, Size{X: 31, Y: 64, Z: 31})
+	polls := 0
+	options.Cancelled = func() bool {
+		polls++
+		return polls > 3
+	}
+
+	result := Search(options)
+	if !result.Truncated || !result.Cancelled {
+		t.Errorf("cancelled search reported truncated %v, cancelled %v", result.Truncated, result.Cancelled)
+	}
+	if want := int64(3 * timeCheckInterval); result.Evaluations != want {
+		t.Errorf("ran %d evaluations after cancelling, want %d", result.Evaluations, want)
+	}
+}
+
+func TestFindBestUnderFlowCloseToFullSweep(t *testing.T) {
+	flowSetting := FlowSetting{Variant: FindBestUnderFlow, Value: 20000}
+	energyAt := func(turbine Turbine, flowRate int64) float64 {
+		turbine.SetNominalFlowRate(flowRate)
+		turbine.Settle()
+		return energyFitness(turbine)
+	}
+
+	for _, size := range []struct{ width, height, coilLayers int32 }{{7, 10, 3}, {11, 14, 4}, {15, 20, 6}, {9, 16, 8}} {
+		turbine, err := NewTurbine(&BiggerReactorsConfig, size.height, size.width, size.coilLayers, biggerReactorsCoils["Gold"])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		adaptive := math.Inf(-1)
+		evaluations := 0
+		flowSetting.tryFlowRates(turbine, func(flowRate int64) (float64, bool) {
+			evaluations++
+			energy := energyAt(turbine, flowRate)
+			adaptive = max(adaptive, energy)
+			return energy, true
+		})
+
+		// the exhaustive sweep it replaced, every 100 mB/t of the window
+		full := math.Inf(-1)
+		for flowRate := flowSetting.Value - underFlowWindow; flowRate <= min(flowSetting.Value, turbine.maxMaxFlowRate); flowRate += 100 {
+			full = max(full, energyAt(turbine, flowRate))
+		}
+
+		if adaptive < full*0.99 {
+			t.Errorf("%dx%d: adaptive search found %.1f RF/t, the full sweep %.1f", size.width, size.height, adaptive, full)
+		}
+		if evaluations >= 101 {
+			t.Errorf("%dx%d: adaptive search made %d evaluations, no fewer than the full sweep", size.width, size.height, evaluations)
+		}
+	}
+}
+
+func TestFindBestUnderFlowTolerance(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestUnderFlow, Value: 20000}, Size{X: 9, Y: 12, Z: 9})
+	fine := Search(options)
+	options.Flow.Tolerance = 1000
+	coarse := Search(options)
+
+	if coarse.Evaluations >= fine.Evaluations {
+		t.Errorf("a coarser tolerance made %d evaluations, the default %d", coarse.Evaluations, fine.Evaluations)
+	}
+	if rate := coarse.Turbine.Stats().FlowRate; rate%1000 != 0 {
+		t.Errorf("a 1000 mB/t tolerance picked %d mB/t, off the coarse steps", rate)
+	}
+}
+
+func TestTargetPeak(t *testing.T) {
+	maxSize := Size{X: 11, Y: 16, Z: 11}
+	for _, tc := range []struct {
+		peak     int32
+		min, max float64
+	}{
+		{1, 1800 / math.Sqrt2, 1800 * math.Sqrt2},
+		{2, 900 / math.Sqrt2, 900 * math.Sqrt2},
+	} {
+		options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: UseMaxFlow}, maxSize)
+		options.TargetPeak = tc.peak
+		result := Search(options)
+		if !result.Found {
+			t.Fatalf("peak %d: no turbine found", tc.peak)
+		}
+		// the winner is converged after the search, allow it to drift a little past the edge
+		if rpm := result.Turbine.RPM(); rpm < tc.min*0.99 || rpm > tc.max*1.01 {
+			t.Errorf("peak %d: settled at %.0f RPM, want %.0f to %.0f", tc.peak, rpm, tc.min, tc.max)
+		}
+	}
+
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: UseMaxFlow}, maxSize)
+	options.TargetPeak = 3
+	if result := Search(options); result.Found || len(result.Notes) == 0 {
+		t.Errorf("peak 3 of 2 found %v with notes %v", result.Found, result.Notes)
+	}
+}
+
+func TestSearchPartialEngagement(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Ludicrite"], FlowSetting{Variant: UseSetFlow, Value: 60000}, Size{X: 9, Y: 12, Z: 9})
+	full := Search(options)
+	options.PartialEngagement = true
+	partial := Search(options)
+
+	if partial.Turbine.Stats().EnergyGenerated < full.Turbine.Stats().EnergyGenerated {
+		t.Errorf("partial engagement search found %.1f RF/t, full engagement alone %.1f", partial.Turbine.Stats().EnergyGenerated, full.Turbine.Stats().EnergyGenerated)
+	}
+	// at this flow a fully engaged coil holds the rotor too slow for its best output
+	stats := partial.Turbine.Stats()
+	if stats.EngagedCoilLayers >= stats.CoilLayers {
+		t.Errorf("all %d coil layers engaged", stats.CoilLayers)
+	}
+	if len(partial.Notes) == 0 || !strings.HasPrefix(partial.Notes[len(partial.Notes)-1], "Engaging") {
+		t.Errorf("notes %q don't report partial engagement winning", partial.Notes)
+	}
+	if got := full.Turbine.Stats(); got.EngagedCoilLayers != got.CoilLayers {
+		t.Errorf("search without partial engagement idled %d of %d coil layers", got.CoilLayers-got.EngagedCoilLayers, got.CoilLayers)
+	}
+}
+
+func TestSearchErrNamesBindingConstraint(t *testing.T) {
+	unsafe := BiggerReactorsConfig.Clone()
+	unsafe.MaxSafeRPM = 1
+
+	tests := []struct {
+		name       string
+		change     func(*Options)
+		constraint string
+	}{
+		{"room below the minimum", func(options *Options) { options.MaxSize = Size{X: 3, Y: 3, Z: 3} }, "maxSize"},
+		{"wider than a chunk", func(options *Options) {
+			options.MinSize = Size{X: 17}
+			options.MaxSize = Size{X: 19, Y: 10, Z: 19}
+			options.Chunks = ChunkSetting{Mode: RequireOneChunk}
+		}, "chunks"},
+		{"too many coil layers", func(options *Options) { options.MinCoilLayers = 20 }, "coilLayers"},
+		{"constraints", func(options *Options) { options.Constraints = func(Turbine) bool { return false } }, "constraints"},
+		{"unreachable rpm", func(options *Options) { options.Flow = FlowSetting{Variant: UseTargetRPM, Value: 100000} }, "flow"},
+		{"no flow step", func(options *Options) { options.Flow = FlowSetting{Variant: FindBestFlow} }, "flow"},
+		{"negative flow step", func(options *Options) { options.Flow = FlowSetting{Variant: FindBestFlow, Value: -1000} }, "flow"},
+		{"overspeed without load", func(options *Options) {
+			options.Config = unsafe
+			options.LimitNoLoadRPM = true
+		}, "limitNoLoadRPM"},
+		{"missing peak", func(options *Options) { options.TargetPeak = 3 }, "targetPeak"},
+		{"fitness floor", func(options *Options) { options.Fitness = func(Turbine) float64 { return math.Inf(-1) } }, "fitness"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 10, Z: 9})
+			tc.change(&options)
+
+			_, err := FindOptimalTurbine(options)
+			var infeasible InfeasibleError
+			if !errors.As(err, &infeasible) || !errors.Is(err, ErrNoTurbine) {
+				t.Fatalf("error %v is not an InfeasibleError", err)
+			}
+			if infeasible.Constraint != tc.constraint {
+				t.Errorf("binding constraint %q (%s), want %q", infeasible.Constraint, infeasible.Message, tc.constraint)
+			}
+		})
+	}
+
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 9, Y: 10, Z: 9})
+	if _, err := FindOptimalTurbine(options); err != nil {
+		t.Errorf("feasible search failed with %v", err)
+	}
+}
+
+func TestSearchTelemetry(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 9, Y: 10, Z: 9})
+	options.Constraints = func(turbine Turbine) bool { return turbine.Stats().Height%2 == 0 }
+	result := Search(options)
+	telemetry := result.Telemetry
+
+	if !result.Found {
+		t.Fatal("search found no turbine")
+	}
+	if telemetry.Sizes == 0 || telemetry.InChunk > telemetry.Sizes || telemetry.Built > telemetry.Geometries {
+		t.Errorf("inconsistent geometry counts %+v", telemetry)
+	}
+	if telemetry.FailedConstraints == 0 || telemetry.FailedConstraints >= telemetry.Built {
+		t.Errorf("constraints turned down %d of %d built geometries", telemetry.FailedConstraints, telemetry.Built)
+	}
+	if telemetry.FlowRates == 0 || telemetry.NearPeak > telemetry.SafeWithoutLoad || telemetry.SafeWithoutLoad > telemetry.FlowRates {
+		t.Errorf("inconsistent flow rate counts %+v", telemetry)
+	}
+	if telemetry.Duration <= 0 || telemetry.DurationMs != float64(telemetry.Duration)/float64(time.Millisecond) {
+		t.Errorf("search took %v, %v ms", telemetry.Duration, telemetry.DurationMs)
+	}
+
+	// both passes of PreferOneChunk count
+	options.Constraints = noConstraints
+	options.MinSize = Size{X: 17}
+	options.MaxSize = Size{X: 19, Y: 10, Z: 19}
+	options.Chunks = ChunkSetting{Mode: PreferOneChunk}
+	if result := Search(options); result.Telemetry.Sizes <= result.Telemetry.InChunk {
+		t.Errorf("fallback search counted %d sizes and %d in chunk", result.Telemetry.Sizes, result.Telemetry.InChunk)
+	}
+}
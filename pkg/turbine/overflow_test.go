@@ -0,0 +1,31 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMultiplyInt64(t *testing.T) {
+	tests := []struct {
+		a, b   int64
+		want   int64
+		wantOK bool
+	}{
+		{6, 7, 42, true},
+		{-6, 7, -42, true},
+		{0, math.MaxInt64, 0, true},
+		{math.MaxInt64, 1, math.MaxInt64, true},
+		{math.MaxInt64, 2, 0, false},
+		{math.MinInt64, -1, 0, false},
+		{-1, math.MinInt64, 0, false},
+		{1 << 31, 1 << 31, 1 << 62, true},
+		{1 << 32, 1 << 32, 0, false},
+	}
+
+	for _, tc := range tests {
+		got, ok := multiplyInt64(tc.a, tc.b)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("multiplyInt64(%d, %d) = %d, %v, want %d, %v", tc.a, tc.b, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
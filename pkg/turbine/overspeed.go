@@ -0,0 +1,31 @@
+package turbine
+
+// OverspeedReport compares the rotor speed against Config.MaxSafeRPM, a negative margin means the rotor breaks
+type OverspeedReport struct {
+	MaxSafeRPM float64 `json:"maxSafeRPM"`
+	Margin     float64 `json:"margin"`
+	// if the coils trip, the rotor keeps speeding up to the no-load rpm
+	NoLoadRPM    float64 `json:"noLoadRPM"`
+	NoLoadMargin float64 `json:"noLoadMargin"`
+}
+
+// Overspeed reports the margins to the max safe rpm, false if the config has no limit
+func (turbine Turbine) Overspeed() (OverspeedReport, bool) {
+	maxSafeRPM := turbine.config.MaxSafeRPM
+	if maxSafeRPM <= 0 {
+		return OverspeedReport{}, false
+	}
+
+	noLoadRPM := turbine.FinalRPMNoLoad()
+	return OverspeedReport{
+		MaxSafeRPM:   maxSafeRPM,
+		Margin:       maxSafeRPM - turbine.RPM(),
+		NoLoadRPM:    noLoadRPM,
+		NoLoadMargin: maxSafeRPM - noLoadRPM,
+	}, true
+}
+
+// safeWithoutLoad tells if the rotor stays under the max safe rpm even with the coils disengaged
+func (turbine Turbine) safeWithoutLoad() bool {
+	return turbine.config.MaxSafeRPM <= 0 || turbine.FinalRPMNoLoad() <= turbine.config.MaxSafeRPM
+}
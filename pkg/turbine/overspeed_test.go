@@ -0,0 +1,62 @@
+package turbine
+
+import "testing"
+
+func TestOverspeed(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := turbine.Overspeed(); ok {
+		t.Error("no limit in the config, expected no report")
+	}
+
+	config := BiggerReactorsConfig.Clone()
+	config.MaxSafeRPM = 2000
+	turbine, err = NewTurbine(config, 16, 13, 3, config.Coils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	peakFlow, _ := turbine.FlowForRPM(1800)
+	turbine.SetNominalFlowRate(int64(peakFlow))
+	turbine.Converge()
+
+	report, ok := turbine.Overspeed()
+	if !ok {
+		t.Fatal("expected a report")
+	}
+	assertClose(t, "Margin", report.Margin, 2000-turbine.RPM())
+	// free spinning is always faster than under load
+	if report.NoLoadRPM <= turbine.RPM() || report.NoLoadMargin >= report.Margin {
+		t.Errorf("no-load %.1f rpm, loaded %.1f rpm", report.NoLoadRPM, turbine.RPM())
+	}
+}
+
+func TestSearchLimitsNoLoadRPM(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.MaxSafeRPM = 2000
+
+	options := NewOptions(energyFitness, noConstraints, config.Coils["Enderium"], FlowSetting{Variant: FindBestFlow, Value: 1000}, Size{X: 11, Y: 14, Z: 11})
+	options.Config = config
+	options.LimitNoLoadRPM = true
+
+	best, err := FindOptimalTurbine(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, _ := best.Overspeed()
+	if report.NoLoadRPM > config.MaxSafeRPM {
+		t.Errorf("no-load rpm %.1f is over the %.0f limit", report.NoLoadRPM, config.MaxSafeRPM)
+	}
+
+	// otherwise the limit didn't change anything and the test proves nothing
+	options.LimitNoLoadRPM = false
+	unlimitedBest, err := FindOptimalTurbine(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlimited, _ := unlimitedBest.Overspeed()
+	if unlimited.NoLoadRPM <= config.MaxSafeRPM {
+		t.Errorf("unconstrained winner free-spins at %.1f rpm, expected it over the limit", unlimited.NoLoadRPM)
+	}
+}
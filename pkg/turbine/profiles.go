@@ -0,0 +1,96 @@
+package turbine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Profile is a named config matching a specific mod version
+type Profile struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Config      *Config `json:"config"`
+}
+
+const DefaultProfileName = "BiggerReactors-0.6"
+
+// TODO ATM8 and ATM9 profiles with the coil tables and constants of those packs, they need the packs' own Bigger
+// Reactors config dumps to be built from and tested against. Until then the allthemodium, vibranium and unobtanium
+// coils come from the default profile and a pack's values can be added with LoadProfiles.
+//
+// TODO older mod versions such as Bigger Reactors 0.5, each mod only has the version its bundled config was taken
+// from until the constants that changed between versions are sourced. Any number of versions per mod can already be
+// loaded and listed, a version's profile name carries its number.
+var profiles = []Profile{
+	{DefaultProfileName, "Bigger Reactors 0.6 (Minecraft 1.16+)", &BiggerReactorsConfig},
+	{"ExtremeReactors-2.0", "Extreme Reactors 2.0 (Minecraft 1.16+)", &ExtremeReactorsConfig},
+}
+
+func Profiles() []Profile {
+	return profiles
+}
+
+func ProfileByName(name string) (Profile, error) {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+	return Profile{}, fmt.Errorf("Unknown profile %q", name)
+}
+
+type profileOverride struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Base        string          `json:"base"`
+	Config      json.RawMessage `json:"config"`
+}
+
+// LoadProfiles adds or replaces profiles from a json array. Each entry starts from its base profile
+// (the default one if not given) and only the fields present in its config are overridden.
+func LoadProfiles(data []byte) error {
+	var overrides []profileOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return err
+	}
+
+	for _, override := range overrides {
+		if override.Name == "" {
+			return errors.New("Profile needs a name")
+		}
+
+		baseName := override.Base
+		if baseName == "" {
+			baseName = DefaultProfileName
+		}
+		base, err := ProfileByName(baseName)
+		if err != nil {
+			return err
+		}
+
+		config := base.Config.Clone()
+		if len(override.Config) > 0 {
+			if err := json.Unmarshal(override.Config, config); err != nil {
+				return fmt.Errorf("Profile %q: %w", override.Name, err)
+			}
+		}
+		if err := config.ValidateLimits(); err != nil {
+			return fmt.Errorf("Profile %q: %w", override.Name, err)
+		}
+
+		setProfile(Profile{override.Name, override.Description, config})
+	}
+
+	return nil
+}
+
+func setProfile(profile Profile) {
+	for i := range profiles {
+		if profiles[i].Name == profile.Name {
+			profiles[i] = profile
+			return
+		}
+	}
+	profiles = append(profiles, profile)
+}
@@ -0,0 +1,81 @@
+package turbine
+
+import "testing"
+
+func restoreProfiles(t *testing.T) {
+	saved := append([]Profile(nil), profiles...)
+	t.Cleanup(func() {
+		profiles = saved
+	})
+}
+
+func TestLoadProfilesOverridesOnlyGivenFields(t *testing.T) {
+	restoreProfiles(t)
+
+	err := LoadProfiles([]byte(`[{
+		"name": "FastSteam",
+		"config": {"latentHeat": 8, "coils": {"Tin": {"efficiency": 0.4, "bonus": 1, "extractionRate": 0.11}}}
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := ProfileByName("FastSteam")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Config.LatentHeat != 8 {
+		t.Errorf("LatentHeat = %.1f, want 8", profile.Config.LatentHeat)
+	}
+	if profile.Config.FlowRatePerBlock != BiggerReactorsConfig.FlowRatePerBlock {
+		t.Errorf("FlowRatePerBlock = %d, want the base value", profile.Config.FlowRatePerBlock)
+	}
+	if _, ok := profile.Config.Coils["Iron"]; !ok {
+		t.Error("base coils were dropped")
+	}
+	if _, ok := profile.Config.Coils["Tin"]; !ok {
+		t.Error("override coil missing")
+	}
+	if _, ok := BiggerReactorsConfig.Coils["Tin"]; ok {
+		t.Error("override leaked into the bundled coil table")
+	}
+}
+
+func TestLoadProfilesFromBase(t *testing.T) {
+	restoreProfiles(t)
+
+	err := LoadProfiles([]byte(`[{"name": "TallExtreme", "base": "ExtremeReactors-2.0", "config": {"maxHeight": 48}}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := ProfileByName("TallExtreme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.Config.Variant != ExtremeReactors || profile.Config.MaxHeight != 48 {
+		t.Errorf("got %s with max height %d", profile.Config.Variant, profile.Config.MaxHeight)
+	}
+}
+
+func TestLoadProfilesErrors(t *testing.T) {
+	restoreProfiles(t)
+
+	tests := map[string]string{
+		"unnamed":      `[{"config": {}}]`,
+		"unknown base": `[{"name": "x", "base": "nope"}]`,
+		"bad variant":  `[{"name": "x", "config": {"variant": "BigReactors"}}]`,
+		"not json":     `{`,
+		"huge width":   `[{"name": "x", "config": {"maxWidth": 2147483647}}]`,
+		"no flow":      `[{"name": "x", "config": {"flowRatePerBlock": -5}}]`,
+		"bearings":     `[{"name": "x", "config": {"bearings": 100000, "shaftLengthPerBearing": 100000}}]`,
+		"fast fluid":   `[{"name": "x", "config": {"fluids": {"Plasma": {"latentHeat": 10, "flowMultiplier": 1e300}}}}]`,
+		"no grid":      `[{"name": "x", "config": {"gridFrequency": -30}}]`,
+		"many peaks":   `[{"name": "x", "config": {"efficiencyPeaks": 100}}]`,
+	}
+	for name, data := range tests {
+		if err := LoadProfiles([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package turbine
+
+import "fmt"
+
+// Overrides are the changes a what-if makes to a turbine, zero values keep what the turbine has
+type Overrides struct {
+	FlowRate int64
+	Coil     *CoilData
+	// added to the outer height, a block up or down at most so the rest of the design still fits
+	HeightDelta int32
+}
+
+// maxHeightDelta is how far a what-if moves the height
+const maxHeightDelta = 1
+
+// Recompute applies the overrides to a copy of the turbine and converges it, without searching again.
+// The coil layers, outer ring, engaged layers, blade and fluid stay as they were.
+func (turbine Turbine) Recompute(overrides Overrides) (Turbine, error) {
+	if overrides.FlowRate < 0 {
+		return Turbine{}, ValidationError{"flowRate", fmt.Sprintf("Flow rate %d mB/t cannot be negative", overrides.FlowRate), ""}
+	}
+	if overrides.HeightDelta < -maxHeightDelta || overrides.HeightDelta > maxHeightDelta {
+		return Turbine{}, ValidationError{"heightDelta", fmt.Sprintf("Height can move by at most %d block", maxHeightDelta), ""}
+	}
+
+	recomputed := turbine
+	if overrides.Coil != nil || overrides.HeightDelta != 0 {
+		coil := turbine.coil
+		if overrides.Coil != nil {
+			coil = *overrides.Coil
+		}
+		stats := turbine.Stats()
+		height := stats.Height + overrides.HeightDelta
+		if err := turbine.config.ValidateDesignSize(stats.Width, height); err != nil {
+			return Turbine{}, err
+		}
+
+		rebuilt, err := NewTurbineWithOuterRing(turbine.config, height, stats.Width, stats.CoilLayers, stats.OuterRingCoils, coil)
+		if err != nil {
+			return Turbine{}, err
+		}
+		if err := rebuilt.EngageCoilLayers(stats.EngagedCoilLayers); err != nil {
+			return Turbine{}, err
+		}
+		rebuilt.SetBlade(turbine.blade)
+		rebuilt.SetFluid(turbine.fluid)
+		rebuilt.SetNominalFlowRate(turbine.maxFlowRate)
+		recomputed = rebuilt
+	}
+
+	if overrides.FlowRate > 0 {
+		recomputed.SetNominalFlowRate(overrides.FlowRate)
+	}
+	recomputed.Converge()
+	return recomputed, nil
+}
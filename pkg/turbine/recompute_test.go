@@ -0,0 +1,78 @@
+package turbine
+
+import "testing"
+
+func TestRecompute(t *testing.T) {
+	base, err := NewTurbine(&BiggerReactorsConfig, 10, 9, 2, biggerReactorsCoils["Ludicrite"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	base.SetNominalFlowRate(24000)
+
+	// no overrides is the turbine converged as it is
+	same, err := base.Recompute(Overrides{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertClose(t, "unchanged RPM", same.RPM(), 1063.343538)
+
+	enderium := biggerReactorsCoils["Enderium"]
+	tests := []struct {
+		name      string
+		overrides Overrides
+		height    int32
+		flowRate  int64
+		coil      CoilData
+	}{
+		{"flow rate", Overrides{FlowRate: 12000}, 10, 12000, biggerReactorsCoils["Ludicrite"]},
+		{"coil", Overrides{Coil: &enderium}, 10, 24000, enderium},
+		{"one block taller", Overrides{HeightDelta: 1}, 11, 24000, biggerReactorsCoils["Ludicrite"]},
+		{"one block shorter with less flow", Overrides{FlowRate: 20000, HeightDelta: -1}, 9, 20000, biggerReactorsCoils["Ludicrite"]},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recomputed, err := base.Recompute(tc.overrides)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// a what-if is the same as building the changed design from scratch
+			want, err := NewTurbine(&BiggerReactorsConfig, tc.height, 9, 2, tc.coil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want.SetNominalFlowRate(tc.flowRate)
+			want.Converge()
+			assertClose(t, "EnergyGenerated", recomputed.Stats().EnergyGenerated, want.Stats().EnergyGenerated)
+			if got := recomputed.Stats().Height; got != tc.height {
+				t.Errorf("Height = %d, want %d", got, tc.height)
+			}
+		})
+	}
+
+	for _, overrides := range []Overrides{{FlowRate: -1}, {HeightDelta: 2}, {HeightDelta: -7}} {
+		if _, err := base.Recompute(overrides); err == nil {
+			t.Errorf("Recompute(%+v) accepted", overrides)
+		}
+	}
+}
+
+func TestRecomputeKeepsEngagement(t *testing.T) {
+	base, err := NewTurbine(&BiggerReactorsConfig, 12, 9, 5, biggerReactorsCoils["Ludicrite"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := base.EngageCoilLayers(2); err != nil {
+		t.Fatal(err)
+	}
+	base.SetNominalFlowRate(60000)
+
+	gold := biggerReactorsCoils["Gold"]
+	recomputed, err := base.Recompute(Overrides{Coil: &gold})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recomputed.Stats().EngagedCoilLayers; got != 2 {
+		t.Errorf("EngagedCoilLayers = %d, want 2", got)
+	}
+}
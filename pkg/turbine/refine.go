@@ -0,0 +1,39 @@
+package turbine
+
+const refineRadius int32 = 2
+
+// Refine searches the neighborhood of a previous result, each dimension and the coil layers within
+// refineRadius and the flow rate in fine steps, with exact math. The bounds in options still apply.
+func Refine(options Options, previous Turbine) SearchResult {
+	stats := previous.Stats()
+
+	options.MinSize = Size{
+		X: max(options.MinSize.X, stats.Width-refineRadius),
+		Y: max(options.MinSize.Y, stats.Height-refineRadius),
+		Z: max(options.MinSize.Z, stats.Width-refineRadius),
+	}
+	options.MaxSize = Size{
+		X: min(options.MaxSize.X, stats.Width+refineRadius),
+		Y: min(options.MaxSize.Y, stats.Height+refineRadius),
+		Z: min(options.depth(), stats.Width+refineRadius),
+	}
+
+	options.MinCoilLayers = max(options.MinCoilLayers, stats.CoilLayers-refineRadius)
+	if options.MaxCoilLayers > 0 {
+		options.MaxCoilLayers = min(options.MaxCoilLayers, stats.CoilLayers+refineRadius)
+	} else {
+		options.MaxCoilLayers = stats.CoilLayers + refineRadius
+	}
+
+	switch options.Flow.Variant {
+	case FindBestFlow:
+		options.Flow = FlowSetting{Variant: FindBestNearFlow, Value: stats.FlowRate}
+	case FindBestUnderFlow:
+		options.Flow = FlowSetting{Variant: FindBestNearFlow, Value: stats.FlowRate, Limit: options.Flow.Value}
+	case FindBestNearFlow:
+		options.Flow.Value = stats.FlowRate
+	}
+
+	options.SearchPrecision = PrecisionExact
+	return Search(options)
+}
@@ -0,0 +1,33 @@
+package turbine
+
+import "testing"
+
+func TestRefineStaysInNeighborhood(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Enderium"], FlowSetting{Variant: FindBestFlow, Value: 5000}, Size{X: 15, Y: 20, Z: 15})
+	coarse := Search(options)
+
+	refined := Refine(options, coarse.Turbine)
+	before := coarse.Turbine.Stats()
+	after := refined.Turbine.Stats()
+
+	if abs(after.Width-before.Width) > refineRadius || abs(after.Height-before.Height) > refineRadius {
+		t.Errorf("refined %dx%d is too far from %dx%d", after.Width, after.Height, before.Width, before.Height)
+	}
+	if after.Width > options.MaxSize.X || after.Height > options.MaxSize.Y {
+		t.Errorf("refined %dx%d exceeds the max size", after.Width, after.Height)
+	}
+	// the coarse winner is part of the neighborhood, so refining can't do worse
+	if after.EnergyGenerated < before.EnergyGenerated {
+		t.Errorf("refined %.1f RF/t, coarse %.1f RF/t", after.EnergyGenerated, before.EnergyGenerated)
+	}
+}
+
+func TestRefineKeepsFlowLimit(t *testing.T) {
+	options := NewOptions(energyFitness, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: FindBestUnderFlow, Value: 20000}, Size{X: 11, Y: 14, Z: 11})
+	coarse := Search(options)
+
+	refined := Refine(options, coarse.Turbine)
+	if flowRate := refined.Turbine.Stats().FlowRate; flowRate > 20000 {
+		t.Errorf("refined flow %d exceeds the 20000 limit", flowRate)
+	}
+}
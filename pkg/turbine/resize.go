@@ -0,0 +1,122 @@
+package turbine
+
+import (
+	"fmt"
+
+	"github.com/drabart/turbine-calculator-website/pkg/build"
+)
+
+// ResizeDirection is how a built turbine grows while its shaft and floor bearing stay where they are
+type ResizeDirection int64
+
+const (
+	// the same footprint, the roof and the coil layers under it go up and blades fill the gap
+	GrowTaller ResizeDirection = iota
+	// a ring of blocks on every side, the shaft stays in the middle so the blades get longer
+	GrowWider
+)
+
+var resizeDirectionNames = map[ResizeDirection]string{
+	GrowTaller: "taller",
+	GrowWider:  "wider",
+}
+
+func (direction ResizeDirection) String() string {
+	if name, ok := resizeDirectionNames[direction]; ok {
+		return name
+	}
+	return fmt.Sprintf("ResizeDirection(%d)", int64(direction))
+}
+
+func (direction ResizeDirection) MarshalText() ([]byte, error) {
+	return []byte(direction.String()), nil
+}
+
+// ResizeOption is one size a built turbine can grow to and the rebuilding it takes
+type ResizeOption struct {
+	Direction ResizeDirection `json:"direction"`
+	Width     int32           `json:"width"`
+	Height    int32           `json:"height"`
+	Feasible  bool            `json:"feasible"`
+	// why the config doesn't allow the size, empty when it is feasible
+	Reason string `json:"reason,omitempty"`
+	// the side walls come down, so the controller, power tap and ports are placed again
+	MovesController bool `json:"movesController"`
+	// bearings that go somewhere else, the top one rides up with the roof of a taller turbine
+	MovedBearings int32 `json:"movedBearings"`
+	// blocks taken down and placed again, on top of the ones added
+	MovedBlocks int64 `json:"movedBlocks"`
+	// the blocks the new size needs over the old one
+	Added           build.Cost `json:"added"`
+	FlowRate        int64      `json:"flowRate"`
+	EnergyGenerated float64    `json:"energyGenerated"`
+	EnergyGain      float64    `json:"energyGain"`
+}
+
+// ResizeOptions lists every taller and every wider turbine up to maxWidth and maxHeight that keeps the shaft and the
+// floor bearing in place, with the coil layers of the turbine. Each runs at the flow rate making the most energy, up
+// to steamLimit mB/t unless that is 0. Sizes the config turns down are listed with the reason.
+func (turbine Turbine) ResizeOptions(maxWidth, maxHeight int32, steamLimit int64) []ResizeOption {
+	turbine.Converge()
+	stats := turbine.Stats()
+	maxWidth = min(maxWidth, turbine.config.MaxWidth)
+	maxHeight = min(maxHeight, turbine.config.MaxHeight)
+
+	options := []ResizeOption{}
+	for height := stats.Height + 1; height <= maxHeight; height++ {
+		resized, err := NewTurbineWithOuterRing(turbine.config, height, stats.Width, stats.CoilLayers, stats.OuterRingCoils, turbine.coil)
+		option := ResizeOption{
+			Direction:     GrowTaller,
+			Width:         stats.Width,
+			Height:        height,
+			MovedBearings: max(turbine.config.Bearings-1, 0),
+			// the roof and the coil layers hanging under it
+			MovedBlocks: int64(stats.Width)*int64(stats.Width) + turbine.coilSize,
+		}
+		options = append(options, turbine.resizeOption(option, resized, err, steamLimit))
+	}
+	for width := stats.Width + 2; width <= maxWidth; width += 2 {
+		resized, err := NewTurbine(turbine.config, stats.Height, width, stats.CoilLayers, turbine.coil)
+		option := ResizeOption{
+			Direction:       GrowWider,
+			Width:           width,
+			Height:          stats.Height,
+			MovesController: true,
+			// the side walls and the edges of the floor and roof move out a block
+			MovedBlocks: 4 * int64(stats.Width-1) * int64(stats.Height),
+		}
+		options = append(options, turbine.resizeOption(option, resized, err, steamLimit))
+	}
+	return options
+}
+
+// resizeOption fills in what the resized turbine makes and costs, or why it can't be built
+func (turbine Turbine) resizeOption(option ResizeOption, resized Turbine, err error, steamLimit int64) ResizeOption {
+	if err != nil {
+		option.Reason = err.Error()
+		return option
+	}
+	option.Feasible = true
+	resized.SetBlade(turbine.blade)
+	resized.SetFluid(turbine.fluid)
+	option.FlowRate = resized.bestFlowRate(steamLimit)
+	resized.SetNominalFlowRate(option.FlowRate)
+	resized.Converge()
+	option.EnergyGenerated = resized.energyGeneratedLastTick
+	option.EnergyGain = resized.energyGeneratedLastTick - turbine.energyGeneratedLastTick
+	option.Added = addedCost(turbine.BuildCost(), resized.BuildCost())
+	return option
+}
+
+// addedCost is every item of to that from has fewer of, in the order of to
+func addedCost(from, to build.Cost) build.Cost {
+	have := map[string]int64{}
+	for _, item := range from {
+		have[item.Name] += item.Count
+	}
+	added := build.Cost{}
+	for _, item := range to {
+		added = added.Add(item.Name, item.Count-have[item.Name])
+	}
+	return added
+}
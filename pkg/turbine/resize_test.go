@@ -0,0 +1,57 @@
+package turbine
+
+import "testing"
+
+func TestResizeOptions(t *testing.T) {
+	config := BiggerReactorsConfig.Clone()
+	config.MaxShaftLength = 10
+	start, err := NewTurbine(config, 10, 7, 5, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := start.ResizeOptions(11, 13, 0)
+	sizes := []struct {
+		direction     ResizeDirection
+		width, height int32
+		feasible      bool
+	}{
+		{GrowTaller, 7, 11, true},
+		{GrowTaller, 7, 12, true},
+		{GrowTaller, 7, 13, false},
+		{GrowWider, 9, 10, true},
+		{GrowWider, 11, 10, true},
+	}
+	if len(options) != len(sizes) {
+		t.Fatalf("%d resize options, want %d", len(options), len(sizes))
+	}
+	for i, want := range sizes {
+		option := options[i]
+		if option.Direction != want.direction || option.Width != want.width || option.Height != want.height || option.Feasible != want.feasible {
+			t.Errorf("option %d is %s to %dx%d feasible %v, want %s to %dx%d feasible %v", i, option.Direction, option.Width, option.Height, option.Feasible, want.direction, want.width, want.height, want.feasible)
+		}
+		if option.Feasible == (option.Reason != "") {
+			t.Errorf("%dx%d feasible %v with reason %q", option.Width, option.Height, option.Feasible, option.Reason)
+		}
+		if option.MovesController != (option.Direction == GrowWider) || option.MovedBlocks <= 0 {
+			t.Errorf("%s to %dx%d moves the controller %v and %d blocks", option.Direction, option.Width, option.Height, option.MovesController, option.MovedBlocks)
+		}
+		if option.Feasible && (option.EnergyGenerated <= 0 || len(option.Added) == 0) {
+			t.Errorf("%dx%d makes %v RF/t adding %v", option.Width, option.Height, option.EnergyGenerated, option.Added)
+		}
+	}
+
+	// a taller turbine only adds shaft, blades and a ring of walls, the coils are the same
+	for _, item := range options[0].Added {
+		if item.Name == "Coil Blocks" {
+			t.Errorf("taller turbine adds %d coils", item.Count)
+		}
+	}
+	if options[0].MovedBearings != 1 || options[3].MovedBearings != 0 {
+		t.Errorf("taller moves %d bearings, wider %d", options[0].MovedBearings, options[3].MovedBearings)
+	}
+
+	if got := start.ResizeOptions(7, 10, 0); len(got) != 0 {
+		t.Errorf("%d options without room to grow", len(got))
+	}
+}
@@ -0,0 +1,64 @@
+package turbine
+
+import "math"
+
+type PeakFlow struct {
+	RPM float64 `json:"rpm"`
+	// mB/t needed to hold the rotor at RPM, zero if no flow rate can
+	FlowRate int64 `json:"flowRate"`
+	// false when the flow rate is above what the turbine can take in
+	Reachable bool `json:"reachable"`
+}
+
+// Result is what gets reported for a chosen turbine: its stats plus values that are
+// too expensive to compute for every candidate during the search
+type Result struct {
+	Stats
+
+	PeakFlows []PeakFlow `json:"peakFlows"`
+	// RF made from each mB of steam
+	EnergyPerSteam float64 `json:"energyPerSteam"`
+	// steam the blades can use at the final rpm
+	RotorCapacity float64 `json:"rotorCapacity"`
+	// highest flow rate with the rotor at 100% efficiency
+	SweetSpotFlow int64 `json:"sweetSpotFlow"`
+	// how fast the rotor free-spins if the coils trip
+	NoLoadRPM float64 `json:"noLoadRPM"`
+
+	Explanation []string  `json:"explanation"`
+	Warnings    []Warning `json:"warnings"`
+
+	Storage StorageRecommendation `json:"storage"`
+	Water   WaterLoop             `json:"water"`
+	// only when the config has a max safe rpm
+	Overspeed  *OverspeedReport `json:"overspeed,omitempty"`
+	Thresholds CoilThresholds   `json:"thresholds"`
+}
+
+func (turbine Turbine) Result() Result {
+	result := Result{Stats: turbine.Stats()}
+
+	for _, rpm := range turbine.config.EfficiencyPeakRPMs() {
+		peakFlow := PeakFlow{RPM: rpm}
+		if flowRate, ok := turbine.FlowForRPM(rpm); flowRate > 0 {
+			peakFlow.FlowRate = int64(math.Round(flowRate))
+			peakFlow.Reachable = ok
+		}
+		result.PeakFlows = append(result.PeakFlows, peakFlow)
+	}
+
+	result.EnergyPerSteam = turbine.EnergyPerSteam()
+	result.RotorCapacity = turbine.RotorCapacity()
+	result.SweetSpotFlow = turbine.SweetSpotFlow()
+	result.NoLoadRPM = turbine.FinalRPMNoLoad()
+	result.Explanation = turbine.Explain()
+	result.Warnings = turbine.Warnings()
+	result.Storage = turbine.Storage()
+	result.Water = turbine.WaterLoop()
+	if overspeed, ok := turbine.Overspeed(); ok {
+		result.Overspeed = &overspeed
+	}
+	result.Thresholds = turbine.CoilThresholds()
+
+	return result
+}
@@ -0,0 +1,112 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResultPeakFlows(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(20000)
+	turbine.Converge()
+
+	result := turbine.Result()
+	if len(result.PeakFlows) != 2 {
+		t.Fatalf("got %d peaks, want 900 and 1800", len(result.PeakFlows))
+	}
+
+	for _, peak := range result.PeakFlows {
+		if !peak.Reachable {
+			t.Errorf("%.0f rpm should be reachable", peak.RPM)
+			continue
+		}
+
+		// running at the reported flow has to land on the peak
+		turbine.SetNominalFlowRate(peak.FlowRate)
+		if rpm := turbine.FinalRPM(); math.Abs(rpm-peak.RPM) > 1 {
+			t.Errorf("flow %d mB/t holds %.2f rpm, want %.0f", peak.FlowRate, rpm, peak.RPM)
+		}
+	}
+}
+
+func TestStorage(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if storage := turbine.Storage(); storage.TicksToFill != 0 || storage.RequiredExtraction != 0 {
+		t.Errorf("idle turbine: %+v", storage)
+	}
+
+	turbine.SetNominalFlowRate(40000)
+	turbine.Converge()
+	storage := turbine.Storage()
+	energy := turbine.Stats().EnergyGenerated
+
+	// 360 coils plus the controller's own storage
+	assertClose(t, "BatteryCapacity", storage.BatteryCapacity, 361*BiggerReactorsConfig.BatterySizePerCoilBlock)
+	if storage.RequiredExtraction < energy {
+		t.Errorf("RequiredExtraction %.1f is less than the %.1f RF/t made", storage.RequiredExtraction, energy)
+	}
+	if filled := float64(storage.TicksToFill) * energy; filled < storage.BatteryCapacity || filled-energy >= storage.BatteryCapacity {
+		t.Errorf("%d ticks at %.1f RF/t doesn't just fill %.0f RF", storage.TicksToFill, energy, storage.BatteryCapacity)
+	}
+}
+
+func TestSweetSpotFlow(t *testing.T) {
+	for _, tc := range snapshotTurbines {
+		t.Run(tc.name, func(t *testing.T) {
+			turbine, err := NewTurbine(&BiggerReactorsConfig, tc.height, tc.width, tc.coilLayers, biggerReactorsCoils[tc.coil])
+			if err != nil {
+				t.Fatal(err)
+			}
+			sweetSpot := turbine.SweetSpotFlow()
+
+			turbine.SetNominalFlowRate(sweetSpot)
+			turbine.Converge()
+			if efficiency := turbine.Stats().RotorEfficiency; efficiency < 1 {
+				t.Errorf("rotor efficiency at the %d mB/t sweet spot is %.6f", sweetSpot, efficiency)
+			}
+			if capacity := turbine.RotorCapacity(); capacity < float64(sweetSpot) {
+				t.Errorf("rotor capacity %.1f mB/t is under the %d mB/t sweet spot", capacity, sweetSpot)
+			}
+
+			if sweetSpot == turbine.Stats().MaxFlowRate {
+				return
+			}
+			turbine.SetNominalFlowRate(sweetSpot + sweetSpot/100 + 1)
+			turbine.Converge()
+			if efficiency := turbine.Stats().RotorEfficiency; efficiency >= 1 {
+				t.Errorf("rotor efficiency just past the %d mB/t sweet spot is still %.6f", sweetSpot, efficiency)
+			}
+		})
+	}
+}
+
+func TestWaterLoop(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loop := turbine.WaterLoop(); loop.ReturnFlow != 0 || loop.TicksToBackUp != 0 {
+		t.Errorf("idle turbine: %+v", loop)
+	}
+
+	turbine.SetNominalFlowRate(40000)
+	loop := turbine.WaterLoop()
+	if loop.ReturnFlow != 40000 || loop.PipeThroughput != 40000 {
+		t.Errorf("40000 mB/t of steam returns %d mB/t through %d mB/t pipes", loop.ReturnFlow, loop.PipeThroughput)
+	}
+	if loop.WaterBuffer != 40000*waterLoopTicks {
+		t.Errorf("buffer is %d mB, want %d", loop.WaterBuffer, 40000*waterLoopTicks)
+	}
+	// 11x14x11 inside, less the shaft and coils, at 10 B a block
+	if want := int64(math.Ceil((11*14*11 - 14 - 360) * 10000 / 40000.0)); loop.TicksToBackUp != want {
+		t.Errorf("backs up in %d ticks, want %d", loop.TicksToBackUp, want)
+	}
+}
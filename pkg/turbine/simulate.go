@@ -0,0 +1,474 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+)
+
+type DutyCycleResult struct {
+	OnTicks  int `json:"onTicks"`
+	OffTicks int `json:"offTicks"`
+
+	// averaged over whole cycles once the oscillation has settled
+	AverageEnergy float64 `json:"averageEnergy"`
+	AverageRPM    float64 `json:"averageRPM"`
+	MinRPM        float64 `json:"minRPM"`
+	MaxRPM        float64 `json:"maxRPM"`
+
+	// cycles it took for the rpm at the end of a cycle to stop drifting
+	WarmupCycles int `json:"warmupCycles"`
+	// false when the tick budget ran out before the drift stopped, the averages are still moving
+	Settled bool `json:"settled"`
+}
+
+const maxWarmupCycles = 10000
+
+// MeasuredDutyCycles are averaged after the warmup, a duty cycle simulation ticks at least this many cycles
+const MeasuredDutyCycles = 10
+
+// runCycle ticks the turbine through one on/off cycle and calls observe after every tick
+func (turbine *Turbine) runCycle(onTicks, offTicks int, observe func()) {
+	turbine.SetCoilEngaged(true)
+	for range onTicks {
+		turbine.Tick()
+		observe()
+	}
+	turbine.SetCoilEngaged(false)
+	for range offTicks {
+		turbine.Tick()
+		observe()
+	}
+}
+
+// SimulateDutyCycle models the coil being engaged for onTicks and disengaged for offTicks, over and over,
+// starting from the loaded steady state. The warmup stops early so the whole run stays within maxTicks, unless
+// that is 0. The turbine it's called on is not modified.
+func (turbine Turbine) SimulateDutyCycle(onTicks, offTicks, maxTicks int) DutyCycleResult {
+	result := DutyCycleResult{OnTicks: onTicks, OffTicks: offTicks}
+	if onTicks+offTicks <= 0 {
+		return result
+	}
+	warmupCycles := maxWarmupCycles
+	if maxTicks > 0 {
+		warmupCycles = min(warmupCycles, max(0, maxTicks/(onTicks+offTicks)-MeasuredDutyCycles))
+	}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetEnergyForRPM(turbine.FinalRPM())
+
+	// run until the rpm at the end of a cycle repeats itself
+	for result.WarmupCycles < warmupCycles {
+		previousRPM := turbine.RPM()
+		turbine.runCycle(onTicks, offTicks, func() {})
+		result.WarmupCycles++
+		if math.Abs(turbine.RPM()-previousRPM) <= convergenceTolerance*max(1, previousRPM) {
+			result.Settled = true
+			break
+		}
+	}
+
+	result.MinRPM = math.Inf(1)
+	result.MaxRPM = math.Inf(-1)
+	var totalEnergy, totalRPM float64
+	ticks := 0
+	for range MeasuredDutyCycles {
+		turbine.runCycle(onTicks, offTicks, func() {
+			rpm := turbine.RPM()
+			totalEnergy += turbine.energyGeneratedLastTick
+			totalRPM += rpm
+			result.MinRPM = min(result.MinRPM, rpm)
+			result.MaxRPM = max(result.MaxRPM, rpm)
+			ticks++
+		})
+	}
+
+	result.AverageEnergy = totalEnergy / float64(ticks)
+	result.AverageRPM = totalRPM / float64(ticks)
+	return result
+}
+
+type PhaseResult struct {
+	Ticks           int     `json:"ticks"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	SteamUsed       float64 `json:"steamUsed"`
+
+	// rotor energy lost to each drag term
+	FrictionLoss float64 `json:"frictionLoss"`
+	AeroLoss     float64 `json:"aeroLoss"`
+	// the part of the coil drag that didn't turn into RF
+	CoilLoss float64 `json:"coilLoss"`
+}
+
+func (phase *PhaseResult) add(other PhaseResult) {
+	phase.Ticks += other.Ticks
+	phase.EnergyGenerated += other.EnergyGenerated
+	phase.SteamUsed += other.SteamUsed
+	phase.FrictionLoss += other.FrictionLoss
+	phase.AeroLoss += other.AeroLoss
+	phase.CoilLoss += other.CoilLoss
+}
+
+type StartStopResult struct {
+	SpinUp   PhaseResult `json:"spinUp"`
+	Run      PhaseResult `json:"run"`
+	SpinDown PhaseResult `json:"spinDown"`
+	Total    PhaseResult `json:"total"`
+
+	// RF/mB over the whole cycle against running at the steady state forever
+	CycleEnergyPerMB  float64 `json:"cycleEnergyPerMB"`
+	SteadyEnergyPerMB float64 `json:"steadyEnergyPerMB"`
+}
+
+const maxPhaseTicks = 1000000
+
+// spin up is over once the rotor is this close to its steady state rpm
+const spinUpTolerance = 0.001
+
+// the rotor counts as stopped below this rpm
+const stoppedRPM = 1.0
+
+// tickPhase ticks the turbine until done returns true (or maxTicks) and accounts for every tick
+func (turbine *Turbine) tickPhase(maxTicks int, done func() bool) PhaseResult {
+	phase := PhaseResult{}
+	for phase.Ticks < maxTicks && !done() {
+		turbine.Tick()
+		phase.Ticks++
+		phase.EnergyGenerated += turbine.energyGeneratedLastTick
+		if turbine.active {
+			phase.SteamUsed += float64(turbine.maxFlowRate)
+		}
+		phase.FrictionLoss += turbine.frictionDragLastTick
+		phase.AeroLoss += turbine.aeroDragLastTick
+		phase.CoilLoss += turbine.inductorDragLastTick - turbine.energyGeneratedLastTick
+	}
+	return phase
+}
+
+// SimulateStartStop spins the turbine up from rest, runs it for runTicks, then cuts the steam and lets it
+// spin down with the coil engaged. The turbine it's called on is not modified.
+func (turbine Turbine) SimulateStartStop(runTicks int) StartStopResult {
+	result := StartStopResult{}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+	targetRPM := turbine.FinalRPM()
+
+	turbine.Reset()
+	result.SpinUp = turbine.tickPhase(maxPhaseTicks, func() bool {
+		return turbine.RPM() >= targetRPM*(1-spinUpTolerance)
+	})
+
+	result.Run = turbine.tickPhase(runTicks, func() bool { return false })
+	if result.Run.Ticks > 0 && turbine.maxFlowRate > 0 {
+		result.SteadyEnergyPerMB = turbine.energyGeneratedLastTick / float64(turbine.maxFlowRate)
+	}
+
+	turbine.SetActive(false)
+	result.SpinDown = turbine.tickPhase(maxPhaseTicks, func() bool {
+		return turbine.RPM() < stoppedRPM
+	})
+
+	result.Total.add(result.SpinUp)
+	result.Total.add(result.Run)
+	result.Total.add(result.SpinDown)
+	if result.Total.SteamUsed > 0 {
+		result.CycleEnergyPerMB = result.Total.EnergyGenerated / result.Total.SteamUsed
+	}
+
+	return result
+}
+
+// TickSeries holds one entry per tick for each value, for plotting
+type TickSeries struct {
+	RPM             []float64 `json:"rpm"`
+	EnergyGenerated []float64 `json:"energyGenerated"`
+	InductorDrag    []float64 `json:"inductorDrag"`
+	FrictionDrag    []float64 `json:"frictionDrag"`
+	AeroDrag        []float64 `json:"aeroDrag"`
+}
+
+// SimulateTicks spins the turbine up from rest and records every tick. The turbine it's called on is not modified.
+func (turbine Turbine) SimulateTicks(ticks int) TickSeries {
+	ticks = max(0, ticks)
+	series := TickSeries{
+		RPM:             make([]float64, 0, ticks),
+		EnergyGenerated: make([]float64, 0, ticks),
+		InductorDrag:    make([]float64, 0, ticks),
+		FrictionDrag:    make([]float64, 0, ticks),
+		AeroDrag:        make([]float64, 0, ticks),
+	}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+	turbine.Reset()
+
+	for range ticks {
+		turbine.Tick()
+		series.RPM = append(series.RPM, turbine.RPM())
+		series.EnergyGenerated = append(series.EnergyGenerated, turbine.energyGeneratedLastTick)
+		series.InductorDrag = append(series.InductorDrag, turbine.inductorDragLastTick)
+		series.FrictionDrag = append(series.FrictionDrag, turbine.frictionDragLastTick)
+		series.AeroDrag = append(series.AeroDrag, turbine.aeroDragLastTick)
+	}
+
+	return series
+}
+
+// SinkBehavior is what happens once the internal battery is full
+type SinkBehavior int64
+
+const (
+	// the coils keep dragging the rotor, whatever doesn't fit in the battery is lost
+	SinkClampsOutput SinkBehavior = iota
+	// a controller program disengages the coils while the battery is full, so the rotor speeds up
+	SinkDisengagesCoil
+)
+
+func ParseSinkBehavior(name string) (SinkBehavior, error) {
+	switch strings.ToLower(name) {
+	case "clamp":
+		return SinkClampsOutput, nil
+	case "disengage":
+		return SinkDisengagesCoil, nil
+	default:
+		return 0, fmt.Errorf("Unknown sink behavior %q", name)
+	}
+}
+
+type SinkResult struct {
+	ExtractionRate float64 `json:"extractionRate"`
+
+	// false when the sink keeps up and the battery never fills
+	BatteryFills bool `json:"batteryFills"`
+	// ticks from an empty battery at the steady state until it is full
+	TicksToFill int64 `json:"ticksToFill"`
+
+	// averaged over sinkMeasuredTicks once the battery is full
+	AverageDelivered float64 `json:"averageDelivered"`
+	AverageWasted    float64 `json:"averageWasted"`
+	AverageRPM       float64 `json:"averageRPM"`
+	MinRPM           float64 `json:"minRPM"`
+	MaxRPM           float64 `json:"maxRPM"`
+
+	// the rotor went past the last efficiency peak while the coils were off
+	OverspeedRisk bool `json:"overspeedRisk"`
+}
+
+const sinkMeasuredTicks = 24000
+
+// with SinkDisengagesCoil the coils come back once the battery drops under this fraction
+const sinkReengageLevel = 0.5
+
+// SimulateSink runs the turbine into a sink that can only take extractionRate RF/t, starting from the
+// loaded steady state with an empty battery. The turbine it's called on is not modified.
+func (turbine Turbine) SimulateSink(extractionRate float64, behavior SinkBehavior) SinkResult {
+	result := SinkResult{ExtractionRate: extractionRate}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+	turbine.Settle()
+
+	// until the battery is full the rotor sits at the steady state, so the filling can be skipped
+	surplus := turbine.energyGeneratedLastTick - extractionRate
+	stored := 0.0
+	if surplus > 0 {
+		result.BatteryFills = true
+		result.TicksToFill = int64(math.Ceil(turbine.batteryCapacity / surplus))
+		stored = turbine.batteryCapacity
+	}
+
+	result.MinRPM = math.Inf(1)
+	result.MaxRPM = math.Inf(-1)
+	var totalDelivered, totalWasted, totalRPM float64
+	for range sinkMeasuredTicks {
+		if behavior == SinkDisengagesCoil {
+			if stored >= turbine.batteryCapacity {
+				turbine.SetCoilEngaged(false)
+			} else if stored <= turbine.batteryCapacity*sinkReengageLevel {
+				turbine.SetCoilEngaged(true)
+			}
+		}
+
+		turbine.Tick()
+		stored += turbine.energyGeneratedLastTick
+		delivered := min(stored, extractionRate)
+		stored -= delivered
+		if stored > turbine.batteryCapacity {
+			totalWasted += stored - turbine.batteryCapacity
+			stored = turbine.batteryCapacity
+		}
+
+		rpm := turbine.RPM()
+		totalDelivered += delivered
+		totalRPM += rpm
+		result.MinRPM = min(result.MinRPM, rpm)
+		result.MaxRPM = max(result.MaxRPM, rpm)
+	}
+
+	result.AverageDelivered = totalDelivered / sinkMeasuredTicks
+	result.AverageWasted = totalWasted / sinkMeasuredTicks
+	result.AverageRPM = totalRPM / sinkMeasuredTicks
+	result.OverspeedRisk = result.MaxRPM > turbine.config.EfficiencyPeakRPMs()[0]
+	return result
+}
+
+type JitterResult struct {
+	// the flow rate is drawn evenly from within this fraction of the nominal one every tick
+	Jitter float64 `json:"jitter"`
+	Runs   int     `json:"runs"`
+	Ticks  int     `json:"ticks"`
+
+	// the turbine at its nominal flow, for comparison
+	SteadyEnergy float64 `json:"steadyEnergy"`
+	SteadyRPM    float64 `json:"steadyRPM"`
+
+	AverageEnergy float64 `json:"averageEnergy"`
+	// the run with the lowest average and the lowest single tick
+	WorstRunEnergy float64 `json:"worstRunEnergy"`
+	MinEnergy      float64 `json:"minEnergy"`
+	MinRPM         float64 `json:"minRPM"`
+	MaxRPM         float64 `json:"maxRPM"`
+	// the furthest the rpm strayed from the steady rpm either way
+	MaxRPMExcursion float64 `json:"maxRPMExcursion"`
+
+	// the rotor went past Config.MaxSafeRPM in some run, never set when the config has no limit
+	Overspeeds bool `json:"overspeeds"`
+}
+
+// SimulateFlowJitter runs the turbine from the loaded steady state for ticks ticks, runs times, with the flow rate
+// off by up to jitter of the nominal one each tick. The same seed gives the same result. The turbine it's called
+// on is not modified.
+func (turbine Turbine) SimulateFlowJitter(jitter float64, ticks, runs int, seed uint64) JitterResult {
+	result := JitterResult{Jitter: jitter, Runs: runs, Ticks: ticks}
+	if ticks <= 0 || runs <= 0 {
+		return result
+	}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+	turbine.Settle()
+	result.SteadyEnergy = turbine.energyGeneratedLastTick
+	result.SteadyRPM = turbine.RPM()
+
+	nominal := float64(turbine.maxFlowRate)
+	random := rand.New(rand.NewPCG(seed, seed))
+	result.WorstRunEnergy = math.Inf(1)
+	result.MinEnergy = math.Inf(1)
+	result.MinRPM = math.Inf(1)
+	result.MaxRPM = math.Inf(-1)
+	var totalEnergy float64
+	for range runs {
+		run := turbine
+		runEnergy := 0.0
+		for range ticks {
+			offset := (2*random.Float64() - 1) * jitter
+			run.SetNominalFlowRate(int64(math.Round(nominal * (1 + offset))))
+			run.Tick()
+
+			rpm := run.RPM()
+			runEnergy += run.energyGeneratedLastTick
+			result.MinEnergy = min(result.MinEnergy, run.energyGeneratedLastTick)
+			result.MinRPM = min(result.MinRPM, rpm)
+			result.MaxRPM = max(result.MaxRPM, rpm)
+		}
+		totalEnergy += runEnergy
+		result.WorstRunEnergy = min(result.WorstRunEnergy, runEnergy/float64(ticks))
+	}
+
+	result.AverageEnergy = totalEnergy / float64(ticks*runs)
+	result.MaxRPMExcursion = max(result.MaxRPM-result.SteadyRPM, result.SteadyRPM-result.MinRPM)
+	result.Overspeeds = turbine.config.MaxSafeRPM > 0 && result.MaxRPM > turbine.config.MaxSafeRPM
+	return result
+}
+
+// PIController sets the flow rate every tick toward a target rpm, like a computer program on the turbine would
+type PIController struct {
+	TargetRPM float64 `json:"targetRPM"`
+	// mB/t of flow for each rpm under the target, and for each rpm under it summed over the ticks so far
+	ProportionalGain float64 `json:"proportionalGain"`
+	IntegralGain     float64 `json:"integralGain"`
+}
+
+// the rpm counts as settled once it stays within this fraction of the target
+const controllerSettleBand = 0.02
+
+// the steady state error is averaged over this last fraction of the ticks
+const controllerMeasuredFraction = 0.1
+
+type ControllerResult struct {
+	Controller PIController `json:"controller"`
+	// the tick after which the rpm stays in the band around the target, only set when Settled
+	Settled      bool `json:"settled"`
+	SettlingTick int  `json:"settlingTick"`
+	// target less the rpm averaged over the last ticks, positive when the rotor runs slow
+	SteadyStateError float64 `json:"steadyStateError"`
+	// the most the rpm went over the target
+	Overshoot     float64 `json:"overshoot"`
+	AverageEnergy float64 `json:"averageEnergy"`
+	// the flow rate the controller ends up asking for
+	FinalFlowRate int64 `json:"finalFlowRate"`
+	// one entry per tick, for plotting
+	RPM      []float64 `json:"rpm"`
+	FlowRate []int64   `json:"flowRate"`
+}
+
+// SimulateController spins the turbine up from rest with the controller setting the flow rate every tick, between
+// none and the most the turbine takes. The integral only grows while the flow rate isn't pinned at either end, so
+// it doesn't wind up during the spin up. The turbine it's called on is not modified.
+func (turbine Turbine) SimulateController(controller PIController, ticks int) ControllerResult {
+	ticks = max(0, ticks)
+	result := ControllerResult{
+		Controller: controller,
+		RPM:        make([]float64, 0, ticks),
+		FlowRate:   make([]int64, 0, ticks),
+	}
+	if ticks == 0 {
+		return result
+	}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+	turbine.Reset()
+
+	integral := 0.0
+	band := controllerSettleBand * max(1, controller.TargetRPM)
+	measured := max(1, int(math.Ceil(float64(ticks)*controllerMeasuredFraction)))
+	var totalEnergy, measuredRPM float64
+	for tick := range ticks {
+		rpmError := controller.TargetRPM - turbine.RPM()
+		output := controller.ProportionalGain*rpmError + controller.IntegralGain*(integral+rpmError)
+		if output > 0 && output < float64(turbine.maxMaxFlowRate) {
+			integral += rpmError
+		}
+		turbine.SetNominalFlowRate(int64(math.Round(min(max(output, 0), float64(turbine.maxMaxFlowRate)))))
+		turbine.Tick()
+
+		rpm := turbine.RPM()
+		result.RPM = append(result.RPM, rpm)
+		result.FlowRate = append(result.FlowRate, turbine.maxFlowRate)
+		totalEnergy += turbine.energyGeneratedLastTick
+		result.Overshoot = max(result.Overshoot, rpm-controller.TargetRPM)
+		if tick >= ticks-measured {
+			measuredRPM += rpm
+		}
+
+		if math.Abs(rpm-controller.TargetRPM) > band {
+			result.Settled, result.SettlingTick = false, 0
+		} else if !result.Settled {
+			result.Settled = true
+			result.SettlingTick = tick
+		}
+	}
+
+	result.SteadyStateError = controller.TargetRPM - measuredRPM/float64(measured)
+	result.AverageEnergy = totalEnergy / float64(ticks)
+	result.FinalFlowRate = turbine.maxFlowRate
+	return result
+}
@@ -0,0 +1,231 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimulateDutyCycleAlwaysOn(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+
+	result := turbine.SimulateDutyCycle(20, 0, 0)
+
+	// never disengaging is just the steady state
+	steady := turbine
+	steady.Converge()
+	assertClose(t, "MinRPM", result.MinRPM, steady.RPM())
+	assertClose(t, "MaxRPM", result.MaxRPM, steady.RPM())
+	if math.Abs(result.AverageEnergy-steady.Stats().EnergyGenerated) > 1e-6*result.AverageEnergy {
+		t.Errorf("AverageEnergy = %.3f, want %.3f", result.AverageEnergy, steady.Stats().EnergyGenerated)
+	}
+}
+
+func TestSimulateDutyCycleOscillates(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+	steadyRPM := turbine.FinalRPM()
+
+	result := turbine.SimulateDutyCycle(20, 20, 0)
+
+	if result.MaxRPM <= result.MinRPM {
+		t.Errorf("rpm range %.2f-%.2f does not oscillate", result.MinRPM, result.MaxRPM)
+	}
+	// spinning freely half the time has to push the rotor above the loaded steady state
+	if result.AverageRPM <= steadyRPM {
+		t.Errorf("AverageRPM = %.2f, expected above the loaded %.2f", result.AverageRPM, steadyRPM)
+	}
+	if turbine.RPM() != 0 {
+		t.Error("simulation modified the original turbine")
+	}
+	if !result.Settled {
+		t.Errorf("oscillation did not settle in %d cycles", result.WarmupCycles)
+	}
+}
+
+func TestSimulateDutyCycleTickBudget(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+
+	// room for the measured cycles and two of warmup
+	result := turbine.SimulateDutyCycle(20, 20, 40*(MeasuredDutyCycles+2))
+	if result.WarmupCycles > 2 || result.Settled {
+		t.Errorf("budget of 2 warmup cycles ran %d, settled %v", result.WarmupCycles, result.Settled)
+	}
+	if result.MaxRPM <= result.MinRPM {
+		t.Errorf("rpm range %.2f-%.2f was not measured", result.MinRPM, result.MaxRPM)
+	}
+}
+
+func TestSimulateStartStop(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+
+	short := turbine.SimulateStartStop(100)
+	long := turbine.SimulateStartStop(100000)
+
+	for name, result := range map[string]StartStopResult{"short": short, "long": long} {
+		if result.SpinUp.Ticks == 0 || result.SpinUp.Ticks == maxPhaseTicks {
+			t.Errorf("%s: spin up took %d ticks", name, result.SpinUp.Ticks)
+		}
+		if result.SpinDown.Ticks == 0 || result.SpinDown.Ticks == maxPhaseTicks {
+			t.Errorf("%s: spin down took %d ticks", name, result.SpinDown.Ticks)
+		}
+		if result.SpinDown.SteamUsed != 0 {
+			t.Errorf("%s: used steam while spinning down", name)
+		}
+		// spin up and down run below the steady state rpm, so the cycle is always worse
+		if result.CycleEnergyPerMB >= result.SteadyEnergyPerMB {
+			t.Errorf("%s: cycle %.4f RF/mB beats steady %.4f RF/mB", name, result.CycleEnergyPerMB, result.SteadyEnergyPerMB)
+		}
+	}
+
+	// the longer it runs the less the transients matter
+	if long.CycleEnergyPerMB <= short.CycleEnergyPerMB {
+		t.Errorf("long run %.4f RF/mB, short run %.4f RF/mB", long.CycleEnergyPerMB, short.CycleEnergyPerMB)
+	}
+}
+
+func TestSimulateStartStopZeroFlow(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(0)
+
+	// no steam makes no RF/mB to speak of, it used to come out as NaN
+	result := turbine.SimulateStartStop(100)
+	if math.IsNaN(result.SteadyEnergyPerMB) || math.IsNaN(result.CycleEnergyPerMB) {
+		t.Errorf("got %+v, want no NaN", result)
+	}
+	if result.SteadyEnergyPerMB != 0 || result.CycleEnergyPerMB != 0 {
+		t.Errorf("got %.4f steady and %.4f cycle RF/mB, want 0", result.SteadyEnergyPerMB, result.CycleEnergyPerMB)
+	}
+}
+
+func TestSimulateTicksSpinsUp(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+	spinUp := turbine.SimulateStartStop(0).SpinUp.Ticks
+
+	series := turbine.SimulateTicks(spinUp + 100)
+	if len(series.RPM) != spinUp+100 || len(series.AeroDrag) != spinUp+100 {
+		t.Fatalf("got %d ticks, want %d", len(series.RPM), spinUp+100)
+	}
+
+	for i := 1; i < spinUp; i++ {
+		if series.RPM[i] < series.RPM[i-1] {
+			t.Fatalf("rpm dropped from %.3f to %.3f at tick %d while spinning up", series.RPM[i-1], series.RPM[i], i)
+		}
+	}
+	last := len(series.RPM) - 1
+	if math.Abs(series.RPM[last]-turbine.FinalRPM()) > spinUpTolerance*turbine.FinalRPM() {
+		t.Errorf("ended at %.2f rpm, want %.2f", series.RPM[last], turbine.FinalRPM())
+	}
+}
+
+func TestSimulateSink(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	peakFlow, _ := turbine.FlowForRPM(1800)
+	turbine.SetNominalFlowRate(int64(peakFlow))
+	turbine.Converge()
+	energy := turbine.Stats().EnergyGenerated
+
+	// a sink that takes everything sees the steady state
+	open := turbine.SimulateSink(2*energy, SinkClampsOutput)
+	if open.BatteryFills || math.Abs(open.AverageDelivered-energy) > 1e-6*energy {
+		t.Errorf("open sink: %+v, want %.1f RF/t delivered", open, energy)
+	}
+
+	// clamping keeps the rotor where it was and throws the rest away
+	clamped := turbine.SimulateSink(energy/2, SinkClampsOutput)
+	assertClose(t, "clamped delivered", clamped.AverageDelivered, energy/2)
+	if math.Abs(clamped.AverageWasted-energy/2) > 1e-6*energy || clamped.OverspeedRisk {
+		t.Errorf("clamped sink: %+v", clamped)
+	}
+
+	// disengaging lets the rotor run away past the peak, only the tick that fills the battery overflows
+	disengaged := turbine.SimulateSink(energy/2, SinkDisengagesCoil)
+	if !disengaged.OverspeedRisk || disengaged.AverageWasted > clamped.AverageWasted/10 {
+		t.Errorf("disengaging sink: %+v", disengaged)
+	}
+}
+
+func TestSimulateFlowJitter(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+
+	// without jitter every tick is the steady state
+	steady := turbine.SimulateFlowJitter(0, 200, 2, 1)
+	assertClose(t, "MinRPM", steady.MinRPM, steady.SteadyRPM)
+	assertClose(t, "MaxRPM", steady.MaxRPM, steady.SteadyRPM)
+	assertClose(t, "AverageEnergy", steady.AverageEnergy, steady.SteadyEnergy)
+
+	result := turbine.SimulateFlowJitter(0.2, 2000, 5, 1)
+	if result.MaxRPM <= result.MinRPM || result.MaxRPMExcursion <= 0 {
+		t.Errorf("rpm range %.2f-%.2f with excursion %.2f under jitter", result.MinRPM, result.MaxRPM, result.MaxRPMExcursion)
+	}
+	if result.MinEnergy > result.WorstRunEnergy || result.WorstRunEnergy > result.AverageEnergy {
+		t.Errorf("min %.1f, worst run %.1f and average %.1f RF/t out of order", result.MinEnergy, result.WorstRunEnergy, result.AverageEnergy)
+	}
+	// the rotor smooths the noise out, the average stays close to the steady state
+	if math.Abs(result.AverageEnergy-result.SteadyEnergy) > 0.05*result.SteadyEnergy {
+		t.Errorf("AverageEnergy = %.1f, steady %.1f", result.AverageEnergy, result.SteadyEnergy)
+	}
+
+	if again := turbine.SimulateFlowJitter(0.2, 2000, 5, 1); again != result {
+		t.Error("same seed gave another result")
+	}
+}
+
+func TestSimulateController(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := turbine.SimulateController(PIController{TargetRPM: 775, ProportionalGain: 100, IntegralGain: 1}, 20000)
+	if len(result.RPM) != 20000 || len(result.FlowRate) != 20000 {
+		t.Fatalf("%d rpm and %d flow rate entries for 20000 ticks", len(result.RPM), len(result.FlowRate))
+	}
+	if !result.Settled || result.SettlingTick <= 0 {
+		t.Errorf("settled %v at tick %d", result.Settled, result.SettlingTick)
+	}
+	if math.Abs(result.SteadyStateError) > 0.01 {
+		t.Errorf("SteadyStateError = %v with an integral term", result.SteadyStateError)
+	}
+	// the flow rate it ends on holds the rotor at the target, up to rounding it to whole mB/t
+	steady := turbine
+	steady.SetNominalFlowRate(result.FinalFlowRate)
+	steady.Converge()
+	if math.Abs(steady.RPM()-775) > 1 {
+		t.Errorf("final flow rate %d settles at %.2f rpm", result.FinalFlowRate, steady.RPM())
+	}
+
+	// without the integral term the rotor settles slow of the target
+	proportional := turbine.SimulateController(PIController{TargetRPM: 775, ProportionalGain: 50}, 20000)
+	if proportional.Settled || proportional.SteadyStateError <= 0 {
+		t.Errorf("proportional controller settled %v with error %v", proportional.Settled, proportional.SteadyStateError)
+	}
+}
@@ -0,0 +1,103 @@
+package turbine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GameStage is how far a player got in a modpack, it decides which coil materials they can craft
+type GameStage int64
+
+const (
+	EarlyGame GameStage = iota
+	MidGame
+	LateGame
+)
+
+func ParseGameStage(name string) (GameStage, error) {
+	switch strings.ToLower(name) {
+	case "early":
+		return EarlyGame, nil
+	case "mid":
+		return MidGame, nil
+	case "late":
+		return LateGame, nil
+	default:
+		return 0, fmt.Errorf("Unknown game stage %q", name)
+	}
+}
+
+func (stage GameStage) String() string {
+	switch stage {
+	case EarlyGame:
+		return "early"
+	case MidGame:
+		return "mid"
+	default:
+		return "late"
+	}
+}
+
+// coilStages is when a coil material becomes craftable, the same for both mods. Materials missing here, like the
+// ones of a loaded profile, count as late game.
+var coilStages = map[string]GameStage{
+	"Iron":     EarlyGame,
+	"Copper":   EarlyGame,
+	"Osmium":   EarlyGame,
+	"Lead":     EarlyGame,
+	"Bronze":   EarlyGame,
+	"Steel":    EarlyGame,
+	"Invar":    EarlyGame,
+	"Silver":   EarlyGame,
+	"Gold":     EarlyGame,
+	"Electrum": MidGame,
+	"Platinum": MidGame,
+	"Enderium": MidGame,
+}
+
+func coilStage(name string) GameStage {
+	if stage, ok := coilStages[name]; ok {
+		return stage
+	}
+	return LateGame
+}
+
+// ForStage copies the config with only the coils a player can craft by stage
+func (config Config) ForStage(stage GameStage) (*Config, error) {
+	filtered := config.withCoils(func(name string) bool { return coilStage(name) <= stage })
+	if len(filtered.Coils) == 0 {
+		return nil, ValidationError{"gameStage", fmt.Sprintf("No coil material is craftable in the %s game", stage), ""}
+	}
+	return filtered, nil
+}
+
+// WithUnlockedCoils copies the config with only the named coils, for players who know what they can craft
+func (config Config) WithUnlockedCoils(names []string) (*Config, error) {
+	if len(names) == 0 {
+		return nil, ValidationError{"gameStage", "Unlock at least one coil material", ""}
+	}
+	unlocked := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, err := config.Coil(name); err != nil {
+			return nil, err
+		}
+		unlocked[name] = true
+	}
+	return config.withCoils(func(name string) bool { return unlocked[name] }), nil
+}
+
+// withCoils copies the config without the coils keep turns down, they stay known as locked
+func (config Config) withCoils(keep func(name string) bool) *Config {
+	filtered := config.Clone()
+	filtered.lockedCoils = make(map[string]CoilData)
+	for name, coil := range config.lockedCoils {
+		filtered.lockedCoils[name] = coil
+	}
+	for name, coil := range config.Coils {
+		if !keep(name) {
+			delete(filtered.Coils, name)
+			filtered.lockedCoils[name] = coil
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,73 @@
+package turbine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestForStage(t *testing.T) {
+	tests := []struct {
+		config  Config
+		stage   GameStage
+		best    string
+		present int
+	}{
+		{BiggerReactorsConfig, EarlyGame, "Gold", 7},
+		{BiggerReactorsConfig, MidGame, "Enderium", 10},
+		{BiggerReactorsConfig, LateGame, "Unobtanium", 14},
+		{ExtremeReactorsConfig, EarlyGame, "Gold", 9},
+		{ExtremeReactorsConfig, LateGame, "Ludicrite", 13},
+	}
+	for _, tc := range tests {
+		config, err := tc.config.ForStage(tc.stage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		materials := config.CoilMaterials()
+		if best := materials[len(materials)-1].Name; best != tc.best || len(materials) != tc.present {
+			t.Errorf("%s %s game: best coil %s of %d, want %s of %d", tc.config.Variant, tc.stage, best, len(materials), tc.best, tc.present)
+		}
+	}
+
+	early, _ := BiggerReactorsConfig.ForStage(EarlyGame)
+	var validationErr ValidationError
+	if _, err := early.Coil("Unobtanium"); !errors.As(err, &validationErr) || validationErr.Suggestion != "" {
+		t.Errorf("early game Unobtanium: %v", err)
+	}
+	if len(BiggerReactorsConfig.Coils) != 14 {
+		t.Error("ForStage changed the bundled coil table")
+	}
+}
+
+func TestWithUnlockedCoils(t *testing.T) {
+	config, err := BiggerReactorsConfig.WithUnlockedCoils([]string{"Iron", "Electrum"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if materials := config.CoilMaterials(); len(materials) != 2 || materials[1].Name != "Electrum" {
+		t.Errorf("unlocked coils %v", materials)
+	}
+	if _, err := BiggerReactorsConfig.WithUnlockedCoils([]string{"Electrun"}); err == nil {
+		t.Error("unlocked a misspelt coil")
+	}
+	if _, err := BiggerReactorsConfig.WithUnlockedCoils(nil); err == nil {
+		t.Error("unlocked no coils")
+	}
+
+	// stage filters stack
+	early, _ := config.ForStage(EarlyGame)
+	if _, err := early.Coil("Electrum"); err == nil || early.Coils["Iron"] != biggerReactorsCoils["Iron"] {
+		t.Errorf("early game of the unlocked coils has %v", early.Coils)
+	}
+}
+
+func TestParseGameStage(t *testing.T) {
+	for _, stage := range []GameStage{EarlyGame, MidGame, LateGame} {
+		if parsed, err := ParseGameStage(stage.String()); err != nil || parsed != stage {
+			t.Errorf("ParseGameStage(%q) = %v, %v", stage, parsed, err)
+		}
+	}
+	if _, err := ParseGameStage("endgame"); err == nil {
+		t.Error("ParseGameStage accepted \"endgame\"")
+	}
+}
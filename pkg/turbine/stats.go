@@ -0,0 +1,54 @@
+package turbine
+
+// Stats is a read-only snapshot of a turbine and the values from its last tick
+type Stats struct {
+	// outer dimensions of the turbine, including casing
+	Width  int32 `json:"width"`
+	Height int32 `json:"height"`
+
+	RPM        float64 `json:"rpm"`
+	CoilSize   int64   `json:"coilSize"`
+	CoilLayers int32   `json:"coilLayers"`
+	// coils on the outermost ring of the top coil layer, OuterRingSize(Width) when it's full
+	OuterRingCoils int64 `json:"outerRingCoils"`
+	// coil layers making power, the rest are built but left idle
+	EngagedCoilLayers int32 `json:"engagedCoilLayers"`
+	FlowRate          int64 `json:"flowRate"`
+	MaxFlowRate       int64 `json:"maxFlowRate"`
+	RotorShafts       int32 `json:"rotorShafts"`
+	// rotor blade type, empty for a config without a blade table
+	Blade string `json:"blade,omitempty"`
+	Fluid string `json:"fluid"`
+
+	EnergyGenerated float64 `json:"energyGenerated"`
+	RotorEfficiency float64 `json:"rotorEfficiency"`
+	InductorDrag    float64 `json:"inductorDrag"`
+	FrictionDrag    float64 `json:"frictionDrag"`
+	AeroDrag        float64 `json:"aeroDrag"`
+	CoilEfficiency  float64 `json:"coilEfficiency"`
+}
+
+func (turbine Turbine) Stats() Stats {
+	return Stats{
+		Width:  turbine.size.X + 2,
+		Height: turbine.size.Y + 2,
+
+		RPM:               turbine.RPM(),
+		CoilSize:          turbine.coilSize,
+		CoilLayers:        turbine.coilLayers,
+		OuterRingCoils:    turbine.outerRingCoils,
+		EngagedCoilLayers: turbine.engagedCoilLayers,
+		FlowRate:          turbine.maxFlowRate,
+		MaxFlowRate:       turbine.maxMaxFlowRate,
+		RotorShafts:       turbine.rotorShafts,
+		Blade:             turbine.blade.Name,
+		Fluid:             turbine.fluid.Name,
+
+		EnergyGenerated: turbine.energyGeneratedLastTick,
+		RotorEfficiency: turbine.rotorEfficiencyLastTick,
+		InductorDrag:    turbine.inductorDragLastTick,
+		FrictionDrag:    turbine.frictionDragLastTick,
+		AeroDrag:        turbine.aeroDragLastTick,
+		CoilEfficiency:  turbine.coilEfficiencyLastTick,
+	}
+}
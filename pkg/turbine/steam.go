@@ -0,0 +1,42 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// SteamSource converts a count of something the player built into a steam flow rate
+type SteamSource struct {
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Unit         string  `json:"unit"`
+	SteamPerUnit float64 `json:"steamPerUnit"`
+}
+
+func (source SteamSource) FlowRate(units float64) int64 {
+	return int64(math.Floor(source.SteamPerUnit * units))
+}
+
+// the reactor presets are typical values, real output depends heavily on the reactor design
+//
+// TODO cyanite output and the time to reprocess it into the blutonium and ludicrite for coil upgrades, there is no
+// reactor model here to take a fuel burn rate from, only these per-rod steam figures
+var steamSources = []SteamSource{
+	{"MekanismBoiler", "Mekanism thermoelectric boiler, limited by superheating elements", "superheating element", 320},
+	{"MekanismFusion", "Mekanism fusion reactor cooled with water, per injection rate", "injection rate", 2000},
+	{"ActiveReactorRod", "Actively cooled Bigger/Extreme Reactors reactor, per fuel rod", "fuel rod", 1000},
+	{"ActiveReactorRodGraphite", "Actively cooled reactor with graphite moderators, per fuel rod", "fuel rod", 1500},
+}
+
+func SteamSources() []SteamSource {
+	return steamSources
+}
+
+func SteamSourceByName(name string) (SteamSource, error) {
+	for _, source := range steamSources {
+		if source.Name == name {
+			return source, nil
+		}
+	}
+	return SteamSource{}, fmt.Errorf("Unknown steam source %q", name)
+}
@@ -0,0 +1,21 @@
+package turbine
+
+import "testing"
+
+func TestSteamSourceFlowRate(t *testing.T) {
+	boiler, err := SteamSourceByName("MekanismBoiler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := boiler.FlowRate(12); got != 3840 {
+		t.Errorf("12 superheating elements make %d mB/t, want 3840", got)
+	}
+	// partial units round down, the turbine can't use steam that isn't there
+	if got := boiler.FlowRate(0.5); got != 160 {
+		t.Errorf("half an element makes %d mB/t, want 160", got)
+	}
+
+	if _, err := SteamSourceByName("Nuclear"); err == nil {
+		t.Error("expected an error for an unknown source")
+	}
+}
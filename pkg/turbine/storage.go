@@ -0,0 +1,41 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// a minute of output, enough to ride out the gaps of a sink that only pulls in bursts
+const recommendedBufferTicks = 1200
+
+// StorageRecommendation sizes what has to be attached to the power tap. Once the internal battery is full
+// the coils stop generating, so anything that can't take the full output eventually stalls the turbine.
+type StorageRecommendation struct {
+	BatteryCapacity float64 `json:"batteryCapacity"`
+	// ticks until the internal battery is full with nothing pulling power out
+	TicksToFill int64 `json:"ticksToFill"`
+
+	// RF/t the cables and storage have to take to keep up
+	RequiredExtraction float64 `json:"requiredExtraction"`
+	// external storage for recommendedBufferTicks of output
+	RecommendedStorage float64 `json:"recommendedStorage"`
+
+	Message string `json:"message"`
+}
+
+func (turbine Turbine) Storage() StorageRecommendation {
+	energy := turbine.energyGeneratedLastTick
+	storage := StorageRecommendation{
+		BatteryCapacity:    turbine.batteryCapacity,
+		RequiredExtraction: math.Ceil(energy),
+		RecommendedStorage: math.Ceil(energy * recommendedBufferTicks),
+	}
+	if energy <= 0 {
+		storage.Message = "Turbine makes no power, nothing has to be extracted"
+		return storage
+	}
+
+	storage.TicksToFill = int64(math.Ceil(turbine.batteryCapacity / energy))
+	storage.Message = fmt.Sprintf("Needs at least %.0f RF/t extraction, the internal battery fills in %d ticks otherwise and generation stops", storage.RequiredExtraction, storage.TicksToFill)
+	return storage
+}
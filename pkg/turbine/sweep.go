@@ -0,0 +1,34 @@
+package turbine
+
+// FlowPoint is the steady state of a turbine at one flow rate
+type FlowPoint struct {
+	FlowRate        int64   `json:"flowRate"`
+	RPM             float64 `json:"rpm"`
+	EnergyGenerated float64 `json:"energyGenerated"`
+	RotorEfficiency float64 `json:"rotorEfficiency"`
+	CoilEfficiency  float64 `json:"coilEfficiency"`
+}
+
+// SweepFlow settles the turbine at every flow rate from from to to (inclusive) in steps of step with exact math
+// and hands each point to emit. The turbine it's called on is not modified.
+func (turbine Turbine) SweepFlow(from, to, step int64, emit func(FlowPoint)) {
+	if step <= 0 {
+		return
+	}
+
+	turbine.SetPrecision(PrecisionExact)
+	turbine.SetCoilEngaged(true)
+	turbine.SetActive(true)
+
+	for flowRate := max(0, from); flowRate <= min(to, turbine.maxMaxFlowRate); flowRate += step {
+		turbine.SetNominalFlowRate(flowRate)
+		turbine.Settle()
+		emit(FlowPoint{
+			FlowRate:        flowRate,
+			RPM:             turbine.RPM(),
+			EnergyGenerated: turbine.energyGeneratedLastTick,
+			RotorEfficiency: turbine.rotorEfficiencyLastTick,
+			CoilEfficiency:  turbine.coilEfficiencyLastTick,
+		})
+	}
+}
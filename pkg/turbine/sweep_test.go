@@ -0,0 +1,32 @@
+package turbine
+
+import "testing"
+
+func TestSweepFlow(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points := []FlowPoint{}
+	turbine.SweepFlow(0, 2000000, 20000, func(point FlowPoint) {
+		points = append(points, point)
+	})
+
+	// the sweep stops at the 11x11 intake
+	if want := int(turbine.Stats().MaxFlowRate/20000) + 1; len(points) != want {
+		t.Fatalf("got %d points, want %d", len(points), want)
+	}
+
+	for _, point := range points {
+		if point.FlowRate != 20000 && point.FlowRate != 40000 {
+			continue
+		}
+		// the reference turbines at the same flow
+		for _, tc := range snapshotTurbines {
+			if tc.name == "enderium under capacity" && point.FlowRate == 20000 || tc.name == "enderium double flow" && point.FlowRate == 40000 {
+				assertClose(t, tc.name+" rpm", point.RPM, tc.finalRPM)
+			}
+		}
+	}
+}
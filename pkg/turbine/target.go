@@ -0,0 +1,84 @@
+package turbine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+type SizeMetric int64
+
+const (
+	MinimizeBlocks SizeMetric = iota
+	MinimizeFootprint
+)
+
+func ParseSizeMetric(name string) (SizeMetric, error) {
+	switch strings.ToLower(name) {
+	case "blocks":
+		return MinimizeBlocks, nil
+	case "footprint":
+		return MinimizeFootprint, nil
+	default:
+		return 0, fmt.Errorf("Unknown size metric %q", name)
+	}
+}
+
+func (turbine Turbine) measure(metric SizeMetric) float64 {
+	switch metric {
+	case MinimizeBlocks:
+		return float64(turbine.BlockCount())
+	case MinimizeFootprint:
+		return float64(turbine.Footprint())
+	default:
+		panic("Invalid SizeMetric")
+	}
+}
+
+var ErrTargetUnreachable = errors.New("Can't be done within max size")
+
+// TargetEnergyFitness prefers the smallest turbine making at least targetEnergy RF/t
+func TargetEnergyFitness(targetEnergy float64, metric SizeMetric) func(Turbine) float64 {
+	return func(turbine Turbine) float64 {
+		energy := turbine.Stats().EnergyGenerated
+		if energy < targetEnergy {
+			return math.Inf(-1)
+		}
+		// the second term is in [0, 1) so it only breaks ties between equally sized turbines
+		return -turbine.measure(metric) + 1 - targetEnergy/energy
+	}
+}
+
+// FindSmallestTurbine replaces the fitness in options and searches for the smallest turbine reaching targetEnergy.
+// Candidates are ranked by their settled steady state and the result is converged, which can land a rounding under
+// the target, so a result falling short is ruled out and the search runs again for the next one.
+func FindSmallestTurbine(options Options, targetEnergy float64, metric SizeMetric) (SearchResult, error) {
+	fitness := TargetEnergyFitness(targetEnergy, metric)
+	var shortfalls []Turbine
+	options.Fitness = func(turbine Turbine) float64 {
+		if slices.ContainsFunc(shortfalls, turbine.sameCandidate) {
+			return math.Inf(-1)
+		}
+		return fitness(turbine)
+	}
+
+	for {
+		result := Search(options)
+		if !result.Found {
+			return result, ErrTargetUnreachable
+		}
+		if result.Turbine.Stats().EnergyGenerated >= targetEnergy {
+			return result, nil
+		}
+		shortfalls = append(shortfalls, result.Turbine)
+	}
+}
+
+// sameCandidate is whether other is the same geometry at the same flow rate, whatever it has settled to
+func (turbine Turbine) sameCandidate(other Turbine) bool {
+	return turbine.size == other.size && turbine.coilLayers == other.coilLayers &&
+		turbine.outerRingCoils == other.outerRingCoils && turbine.EngagedCoilLayers() == other.EngagedCoilLayers() &&
+		turbine.maxFlowRate == other.maxFlowRate
+}
@@ -0,0 +1,67 @@
+package turbine
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFindSmallestTurbine(t *testing.T) {
+	options := NewOptions(nil, noConstraints, biggerReactorsCoils["Gold"], FlowSetting{Variant: UseMaxFlow}, Size{X: 15, Y: 16, Z: 15})
+	target := 50000.0
+
+	for _, metric := range []SizeMetric{MinimizeBlocks, MinimizeFootprint} {
+		result, err := FindSmallestTurbine(options, target, metric)
+		if err != nil {
+			t.Fatal(err)
+		}
+		best := result.Turbine
+		if energy := best.Stats().EnergyGenerated; energy < target {
+			t.Errorf("metric %d: result makes %.1f RF/t, below the %.1f target", metric, energy, target)
+		}
+
+		// nothing smaller may reach the target
+		options.Fitness = energyFitness
+		options.Constraints = func(turbine Turbine) bool {
+			return turbine.measure(metric) < best.measure(metric)
+		}
+		if smaller, err := FindOptimalTurbine(options); err == nil && smaller.Stats().EnergyGenerated >= target {
+			t.Errorf("metric %d: a smaller turbine also reaches the target", metric)
+		}
+		options.Constraints = noConstraints
+	}
+}
+
+func TestFindSmallestTurbineUnreachable(t *testing.T) {
+	options := NewOptions(nil, noConstraints, biggerReactorsCoils["Iron"], FlowSetting{Variant: UseMaxFlow}, Size{X: 5, Y: 5, Z: 5})
+
+	if _, err := FindSmallestTurbine(options, 1e9, MinimizeBlocks); !errors.Is(err, ErrTargetUnreachable) {
+		t.Errorf("err = %v, want ErrTargetUnreachable", err)
+	}
+}
+
+func TestFindSmallestTurbineAtTheConvergedEnergy(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 8, 5, 4, biggerReactorsCoils["Iron"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(turbine.maxMaxFlowRate)
+	turbine.Settle()
+	settled := turbine.Stats().EnergyGenerated
+	turbine.Converge()
+	converged := turbine.Stats().EnergyGenerated
+	if settled <= converged {
+		t.Fatalf("settled %.17g RF/t isn't above converged %.17g RF/t, pick another turbine", settled, converged)
+	}
+
+	// the search ranks this turbine as reaching the target, converged it falls short by a rounding
+	target := math.Nextafter(converged, math.Inf(1))
+	options := NewOptions(nil, noConstraints, biggerReactorsCoils["Iron"], FlowSetting{Variant: UseMaxFlow}, Size{X: 5, Y: 9, Z: 5})
+	result, err := FindSmallestTurbine(options, target, MinimizeBlocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if energy := result.Turbine.Stats().EnergyGenerated; energy < target {
+		t.Errorf("result makes %.17g RF/t, below the %.17g target", energy, target)
+	}
+}
@@ -0,0 +1,56 @@
+package turbine
+
+import "math"
+
+// the coils go off this far under the loaded rpm, close enough to catch a steam shortage early and far enough that
+// the coils don't flicker with every tick
+const coilHysteresis = 0.1
+
+// the slowest recovery still has to get past EngageRPM, a steady state right on it would take forever to reach
+const recoveryMargin = 0.05
+
+// CoilThresholds are setpoints for automation that engages the coils once the rotor is up to speed and
+// disengages them when it slows down, so it can spin back up
+type CoilThresholds struct {
+	LoadedRPM float64 `json:"loadedRPM"`
+	NoLoadRPM float64 `json:"noLoadRPM"`
+	// the loaded steady state, engaging there doesn't jolt the rotor
+	EngageRPM    float64 `json:"engageRPM"`
+	DisengageRPM float64 `json:"disengageRPM"`
+	// the least flow rate bringing the rotor back up to EngageRPM with the coils off, zero if it's more than the
+	// turbine takes in
+	MinRecoveryFlowRate int64 `json:"minRecoveryFlowRate"`
+	// the flow rate to run while the coils are off: the nominal one, cut down if it would spin the rotor past
+	// Config.MaxSafeRPM
+	RecoveryFlowRate int64 `json:"recoveryFlowRate"`
+	// false when the recovery flow rate leaves the free spinning rotor short of EngageRPM
+	Recoverable bool `json:"recoverable"`
+}
+
+// CoilThresholds works out the setpoints from the loaded and the no-load steady states at the nominal flow rate
+func (turbine Turbine) CoilThresholds() CoilThresholds {
+	thresholds := CoilThresholds{
+		LoadedRPM:        turbine.FinalRPM(),
+		NoLoadRPM:        turbine.FinalRPMNoLoad(),
+		RecoveryFlowRate: turbine.maxFlowRate,
+	}
+	thresholds.EngageRPM = thresholds.LoadedRPM
+	thresholds.DisengageRPM = thresholds.LoadedRPM * (1 - coilHysteresis)
+	if thresholds.LoadedRPM <= 0 {
+		return thresholds
+	}
+
+	if minFlow, ok := turbine.flowForRPM(thresholds.EngageRPM*(1+recoveryMargin), 0); ok {
+		thresholds.MinRecoveryFlowRate = int64(math.Ceil(minFlow))
+	}
+
+	recoveryRPM := thresholds.NoLoadRPM
+	if maxSafeRPM := turbine.config.MaxSafeRPM; maxSafeRPM > 0 && thresholds.NoLoadRPM > maxSafeRPM {
+		// the flow rate holding the free spinning rotor right at the limit
+		safeFlow, _ := turbine.flowForRPM(maxSafeRPM, 0)
+		thresholds.RecoveryFlowRate = int64(math.Floor(safeFlow))
+		recoveryRPM = maxSafeRPM
+	}
+	thresholds.Recoverable = recoveryRPM > thresholds.EngageRPM
+	return thresholds
+}
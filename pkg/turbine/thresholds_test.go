@@ -0,0 +1,54 @@
+package turbine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoilThresholds(t *testing.T) {
+	turbine, err := NewTurbine(&BiggerReactorsConfig, 16, 13, 3, biggerReactorsCoils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	turbine.SetNominalFlowRate(40000)
+
+	thresholds := turbine.CoilThresholds()
+	assertClose(t, "EngageRPM", thresholds.EngageRPM, turbine.FinalRPM())
+	if !(thresholds.DisengageRPM < thresholds.EngageRPM && thresholds.EngageRPM < thresholds.NoLoadRPM) {
+		t.Errorf("disengage at %v, engage at %v, no load at %v rpm", thresholds.DisengageRPM, thresholds.EngageRPM, thresholds.NoLoadRPM)
+	}
+	if !thresholds.Recoverable || thresholds.RecoveryFlowRate != 40000 {
+		t.Errorf("recoverable %v at %d mB/t without a max safe rpm", thresholds.Recoverable, thresholds.RecoveryFlowRate)
+	}
+
+	// the least recovery flow spins the free rotor a little past the engage rpm
+	recovery := turbine
+	recovery.SetNominalFlowRate(thresholds.MinRecoveryFlowRate)
+	if rpm := recovery.FinalRPMNoLoad(); rpm <= thresholds.EngageRPM || rpm > thresholds.EngageRPM*(1+2*recoveryMargin) {
+		t.Errorf("min recovery flow %d mB/t spins the rotor to %v rpm, engaging at %v", thresholds.MinRecoveryFlowRate, rpm, thresholds.EngageRPM)
+	}
+
+	// a limit under the no-load rpm cuts the recovery flow down to hold the free rotor at it
+	config := BiggerReactorsConfig.Clone()
+	config.MaxSafeRPM = math.Round((thresholds.EngageRPM + thresholds.NoLoadRPM) / 2)
+	limited, err := NewTurbine(config, 16, 13, 3, config.Coils["Enderium"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	limited.SetNominalFlowRate(40000)
+	cut := limited.CoilThresholds()
+	if !cut.Recoverable || cut.RecoveryFlowRate >= 40000 || cut.RecoveryFlowRate < cut.MinRecoveryFlowRate {
+		t.Errorf("recoverable %v at %d mB/t, at least %d, under a %v rpm limit", cut.Recoverable, cut.RecoveryFlowRate, cut.MinRecoveryFlowRate, config.MaxSafeRPM)
+	}
+	limited.SetNominalFlowRate(cut.RecoveryFlowRate)
+	if rpm := limited.FinalRPMNoLoad(); rpm > config.MaxSafeRPM {
+		t.Errorf("recovery flow %d mB/t spins the rotor to %v rpm, over %v", cut.RecoveryFlowRate, rpm, config.MaxSafeRPM)
+	}
+
+	// a limit under the loaded rpm leaves no way back up
+	config.MaxSafeRPM = thresholds.DisengageRPM
+	limited.SetNominalFlowRate(40000)
+	if limited.CoilThresholds().Recoverable {
+		t.Error("recoverable with the max safe rpm under the engage rpm")
+	}
+}
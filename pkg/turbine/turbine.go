@@ -0,0 +1,656 @@
+package turbine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Size struct {
+	X, Y, Z int32
+}
+
+type Vec4 struct {
+	W, X, Y, Z int32
+}
+
+type CoilData struct {
+	Efficiency     float64 `json:"efficiency"`
+	Bonus          float64 `json:"bonus"`
+	ExtractionRate float64 `json:"extractionRate"`
+}
+
+type VentState int64
+
+const (
+	VentStateOverflow VentState = iota
+	VentStateAll
+	VentStateClosed
+)
+
+type Precision int64
+
+const (
+	// fasterPow, good enough to rank candidates
+	PrecisionFast Precision = iota
+	// math.Pow, for the numbers shown to the user
+	PrecisionExact
+)
+
+func ParsePrecision(name string) (Precision, error) {
+	switch strings.ToLower(name) {
+	case "fast":
+		return PrecisionFast, nil
+	case "exact":
+		return PrecisionExact, nil
+	default:
+		return 0, fmt.Errorf("Unknown precision %q", name)
+	}
+}
+
+type Turbine struct {
+	config *Config
+
+	// inner size of the turbine
+	size Size
+
+	active bool
+
+	coilSize   int64
+	coilLayers int32
+	coil       CoilData
+	// coils on the outermost ring of the top coil layer
+	outerRingCoils int64
+	// the layout of the layers below the top one and of the top one, kept to work out partial engagement
+	coilLayout, topCoilLayout []CoilPlacement
+	// coil layers counted from the bottom that make power, the ones above are left idle
+	engagedCoilLayers int32
+
+	inductionEfficiency          float64
+	inductorDragCoefficient      float64
+	inductionEnergyExponentBonus float64
+
+	rotorCapacityPerRPM float64
+
+	maxFlowRate    int64
+	maxMaxFlowRate int64
+
+	rotorShafts int32
+	// blade lengths on every level of the shaft, from the bottom
+	rotors []Vec4
+	blade  BladeMaterial
+	fluid  FluidMaterial
+
+	rotorAxialMass                 float64
+	rotorMass                      float64
+	linearBladeMetersPerRevolution float64
+
+	coilEngaged bool
+
+	precision Precision
+
+	rotorEnergy       float64
+	fluidTankCapacity float64
+	batteryCapacity   float64
+
+	energyGeneratedLastTick float64
+	rotorEfficiencyLastTick float64
+
+	inductorDragLastTick   float64
+	frictionDragLastTick   float64
+	aeroDragLastTick       float64
+	coilEfficiencyLastTick float64
+
+	// the config's coil efficiency curve, worked out once as Tick needs it every tick
+	curve efficiencyCurve
+
+	// nil unless an audit is running, copies of the turbine add to the same audit
+	audit *EnergyAudit
+}
+
+// the mod's efficiency curve, a config leaving GridFrequency or EfficiencyPeaks at zero uses these
+const EffectiveGridFrequency float64 = 30
+const EfficiencyPeaks float64 = 2
+
+// EfficiencyPeakRPMs lists the rpms where the coil efficiency reaches 100% with the default curve, highest first.
+//
+// Deprecated: use Config.EfficiencyPeakRPMs, packs can move the peaks.
+func EfficiencyPeakRPMs() []float64 {
+	return BiggerReactorsConfig.EfficiencyPeakRPMs()
+}
+
+var log2 float64 = math.Log(2)
+
+// MinEfficiencyScale is how far below the top peak the default curve bottoms out.
+//
+// Deprecated: it only holds for the default curve.
+var MinEfficiencyScale float64 = math.Pow(2, EfficiencyPeaks-0.5)
+
+func NewTurbine(config *Config, height, width, coilLayers int32, coilType CoilData) (Turbine, error) {
+	return NewTurbineWithOuterRing(config, height, width, coilLayers, OuterRingSize(width), coilType)
+}
+
+// OuterRingSize is how many coils fit on the outermost ring around the shaft
+func OuterRingSize(width int32) int64 {
+	return 8 * int64((width-2)/2)
+}
+
+// NewTurbineWithOuterRing builds a turbine whose top coil layer only has outerRingCoils coils on its outermost ring,
+// the rings inside it and the layers below are full
+func NewTurbineWithOuterRing(config *Config, height, width, coilLayers int32, outerRingCoils int64, coilType CoilData) (Turbine, error) {
+	turbine, err := newTurbineShell(config, height, width, coilLayers)
+	if err != nil {
+		return turbine, err
+	}
+	if outerRingCoils < 0 || outerRingCoils > OuterRingSize(width) {
+		return turbine, fmt.Errorf("Turbine outer coil ring holds between 0 and %d coils", OuterRingSize(width))
+	}
+
+	turbine.coil = coilType
+	if coilLayers > 0 {
+		// the top layer is the full layout with the end of the outer ring left out
+		layout := turbine.FullCoilLayer(coilType)
+		turbine.coilLayout = layout
+		turbine.topCoilLayout = layout[:int64(len(layout))-OuterRingSize(width)+outerRingCoils]
+		turbine.addCoilLayers(turbine.coilLayout, coilLayers-1)
+		turbine.addCoilLayers(turbine.topCoilLayout, 1)
+		turbine.outerRingCoils = outerRingCoils
+	}
+
+	turbine.finishCoils()
+	return turbine, nil
+}
+
+// NewTurbineWithCoilLayout builds a turbine with the same hand placed layout on each of its coil layers
+func NewTurbineWithCoilLayout(config *Config, height, width, coilLayers int32, layout []CoilPlacement) (Turbine, error) {
+	turbine, err := newTurbineShell(config, height, width, coilLayers)
+	if err != nil {
+		return turbine, err
+	}
+	if len(layout) == 0 {
+		return turbine, errors.New("Turbine coil layout is empty")
+	}
+	if coilLayers < 1 {
+		return turbine, errors.New("Turbine needs at least one coil layer for its layout")
+	}
+	if err := turbine.SetCoilLayer(layout, coilLayers); err != nil {
+		return turbine, err
+	}
+
+	turbine.coilLayout = layout
+	turbine.topCoilLayout = layout
+	// neighbouring designs in explanations are built from the first coil's material
+	turbine.coil = layout[0].Coil
+	for _, placement := range layout {
+		if max(abs(placement.X), abs(placement.Z)) == turbine.size.X/2 {
+			turbine.outerRingCoils++
+		}
+	}
+
+	turbine.finishCoils()
+	return turbine, nil
+}
+
+// newTurbineShell checks the outer size and sets it up without any coils yet
+func newTurbineShell(config *Config, height, width, coilLayers int32) (Turbine, error) {
+	turbine := Turbine{config: config, fluid: config.DefaultFluid(), curve: config.efficiencyCurve()}
+
+	// the size comes first so height-3 below cannot wrap around
+	if height < config.MinHeight || width < config.MinWidth || height < minTurbineHeight || width < minTurbineWidth {
+		return turbine, errors.New("Turbine cannot be this small")
+	}
+	if height > config.MaxHeight || width > config.MaxWidth {
+		return turbine, errors.New("Turbine cannot be this big")
+	}
+	if width%2 == 0 {
+		return turbine, errors.New("Turbine width must be odd")
+	}
+	if coilLayers > height-3 {
+		return turbine, errors.New("Turbine cannot hold that many coil layers")
+	}
+	// zero is a flywheel, a rotor that stores energy until coils are added
+	if coilLayers < 0 {
+		return turbine, errors.New("Turbine cannot have a negative number of coil layers")
+	}
+	// the shaft runs the whole inner height
+	if config.MaxShaftLength > 0 && height-2 > config.MaxShaftLength {
+		return turbine, fmt.Errorf("Turbine rotor shaft cannot be longer than %d blocks", config.MaxShaftLength)
+	}
+	if config.ShaftLengthPerBearing > 0 && int64(height-2) > int64(config.Bearings)*int64(config.ShaftLengthPerBearing) {
+		return turbine, fmt.Errorf("Turbine %d bearings cannot carry a %d block shaft", config.Bearings, height-2)
+	}
+
+	// internal dimensions of the turbine
+	turbineDimensions := Size{width - 2, height - 2, width - 2}
+
+	turbine.Reset()
+	if err := turbine.Resize(turbineDimensions); err != nil {
+		return turbine, err
+	}
+	turbine.coilLayers = coilLayers
+
+	return turbine, nil
+}
+
+// finishCoils fills the rest of the shaft with blades once the coils are placed
+func (turbine *Turbine) finishCoils() {
+	rotors := []Vec4{}
+	for range turbine.size.Y - turbine.coilLayers {
+		bladeLength := turbine.size.X / 2
+		rotors = append(rotors, Vec4{bladeLength, bladeLength, bladeLength, bladeLength})
+	}
+	for range turbine.coilLayers {
+		rotors = append(rotors, Vec4{})
+	}
+	turbine.SetRotorConfiguration(rotors, turbine.config.DefaultBlade())
+
+	turbine.UpdateInternalValues()
+
+	turbine.active = true
+	turbine.coilEngaged = true
+	turbine.engagedCoilLayers = turbine.coilLayers
+	turbine.SetNominalFlowRate(0)
+}
+
+func (turbine *Turbine) Reset() {
+	turbine.rotorEnergy = 0.0
+}
+
+// Resize sets the inner size and clears the coils, failing if the size is empty or takes in more
+// fluid than an int64 counts
+func (turbine *Turbine) Resize(dim Size) error {
+	if dim.X < 1 || dim.Y < 1 || dim.Z < 1 {
+		return fmt.Errorf("Turbine inner size %dx%dx%d has to be at least one block each way", dim.X, dim.Y, dim.Z)
+	}
+	resized := *turbine
+	resized.size = dim
+	maxMaxFlowRate, err := resized.intakeFlowRate()
+	if err != nil {
+		return err
+	}
+
+	turbine.size = dim
+	turbine.coilSize = 0
+
+	turbine.inductionEfficiency = 0
+	turbine.inductorDragCoefficient = 0
+	turbine.inductionEnergyExponentBonus = 0
+
+	turbine.maxMaxFlowRate = maxMaxFlowRate
+	return nil
+}
+
+func (turbine *Turbine) SetNominalFlowRate(flowRate int64) {
+	turbine.maxFlowRate = min(turbine.maxMaxFlowRate, max(0, flowRate))
+}
+
+func (turbine *Turbine) SetRotorConfiguration(rotorConfiguration []Vec4, blade BladeMaterial) {
+	turbine.rotors = rotorConfiguration
+	turbine.blade = blade
+	turbine.rotorMass = 0
+	turbine.linearBladeMetersPerRevolution = 0
+
+	for _, bladeLevel := range rotorConfiguration {
+		sumRangeFromZero := func(x int32) int64 { return int64(x+1) * int64(x) / 2 }
+		turbine.linearBladeMetersPerRevolution += float64(sumRangeFromZero(bladeLevel.W))
+		turbine.linearBladeMetersPerRevolution += float64(sumRangeFromZero(bladeLevel.X))
+		turbine.linearBladeMetersPerRevolution += float64(sumRangeFromZero(bladeLevel.Y))
+		turbine.linearBladeMetersPerRevolution += float64(sumRangeFromZero(bladeLevel.Z))
+		turbine.rotorMass += float64(bladeLevel.W + bladeLevel.X + bladeLevel.Y + bladeLevel.Z)
+	}
+
+	turbine.rotorCapacityPerRPM = turbine.linearBladeMetersPerRevolution * turbine.config.FluidPerBladeLinerKilometre * blade.Capacity
+	turbine.rotorCapacityPerRPM /= 1000
+	turbine.rotorCapacityPerRPM *= 2 * math.Pi
+
+	turbine.rotorShafts = int32(len(rotorConfiguration))
+
+	turbine.rotorAxialMass = float64(turbine.rotorShafts) * turbine.config.RotorAxialMassPerShaft
+	turbine.rotorAxialMass += turbine.linearBladeMetersPerRevolution * turbine.config.RotorAxialMassPerBlade * blade.Mass
+
+	turbine.rotorMass *= turbine.config.RotorAxialMassPerBlade * blade.Mass
+	turbine.rotorMass += float64(turbine.rotorShafts) * turbine.config.RotorAxialMassPerShaft
+
+	if turbine.maxFlowRate == -1 {
+		turbine.SetNominalFlowRate(int64(turbine.rotorCapacityPerRPM * 1800))
+	}
+}
+
+func (turbine *Turbine) SetCoilData(x, y int32, coilData CoilData) {
+	turbine.inductionEfficiency += coilData.Efficiency
+	turbine.inductionEnergyExponentBonus += coilData.Bonus
+	turbine.inductorDragCoefficient += coilData.ExtractionRate * coilDragMultiplier(x, y)
+	turbine.coilSize++
+}
+
+// coilDragMultiplier scales a coil's drag by its distance from the shaft, the ring right around it drags fully
+func coilDragMultiplier(x, y int32) float64 {
+	distance := max(math.Abs(float64(x)), math.Abs(float64(y)))
+	if distance < 1 {
+		return 1
+	}
+	return 2 / (distance + 1)
+}
+
+func (turbine *Turbine) SetFullCoil(layerNumber int32, coilData CoilData) {
+	turbine.addCoilLayers(turbine.FullCoilLayer(coilData), layerNumber)
+}
+
+func (turbine *Turbine) UpdateInternalValues() {
+	turbine.inductorDragCoefficient *= turbine.config.CoilDragMultiplier
+
+	turbine.batteryCapacity = float64(turbine.coilSize+1) * turbine.config.BatterySizePerCoilBlock
+
+	if turbine.coilSize <= 0 {
+		turbine.inductionEfficiency = 0
+		turbine.inductorDragCoefficient = 0
+		turbine.inductionEnergyExponentBonus = 0
+	} else {
+		turbine.inductionEfficiency /= float64(turbine.coilSize)
+		turbine.inductionEnergyExponentBonus /= float64(turbine.coilSize)
+		turbine.inductorDragCoefficient /= float64(turbine.coilSize)
+	}
+
+	turbine.fluidTankCapacity = (float64(turbine.size.X)*float64(turbine.size.Y)*float64(turbine.size.Z) - (float64(turbine.rotorShafts) + float64(turbine.coilSize))) * turbine.config.TankVolumePerBlock
+}
+
+func (turbine *Turbine) RPM() float64 {
+	return turbine.rotorEnergy / turbine.rotorAxialMass
+}
+
+func fasterPow(x, y float64) float64 {
+	if x == 0 || y == 1 {
+		return x
+	}
+
+	// a = x
+	// x = y
+	// a + a (x - 1) log(a) + 1/2 a (x - 1)^2 log^2(a) + 1/6 a (x - 1)^3 log^3(a) + 1/24 a (x - 1)^4 log^4(a) + 1/120 a (x - 1)^5 log^5(a) + O((x - 1)^6)
+	// (Taylor series)
+	// (converges everywhere)
+
+	// 5 terms give relative error of about 1.8e-5 which should be acceptable for 7.5x speedup
+
+	// v1 1440ms
+	// return x + x*p + 0.5*x*p*p + 1.0/6.0*x*p*p*p + 1.0/24.0*x*p*p*p*p
+	// v2 750ms
+	// Don't question, it's just 2x faster...
+	p := (y - 1) * math.Log(x)
+	r := x
+	ret := x
+	r *= p
+	ret += r
+	r *= 0.5 * p
+	ret += r
+	r *= 0.33333333333 * p
+	ret += r
+	r *= 0.25 * p
+	ret += r
+	return ret
+}
+
+func (turbine *Turbine) Tick() {
+	config := turbine.config
+	rpm := turbine.RPM()
+	startEnergy := turbine.rotorEnergy
+	var steamEnergy, rotorInput float64
+
+	if turbine.active {
+		flowRate := float64(turbine.maxFlowRate)
+		effectiveFlowRate := flowRate
+
+		rotorCapacity := turbine.rotorCapacityPerRPM * max(100, rpm)
+
+		if flowRate > rotorCapacity {
+			excessFlow := flowRate - rotorCapacity
+			excessEfficiency := rotorCapacity / flowRate
+			effectiveFlowRate = rotorCapacity + excessFlow*excessEfficiency
+		}
+
+		if debugInvariants {
+			turbine.checkFlow(rpm, flowRate, effectiveFlowRate, rotorCapacity)
+		}
+
+		if flowRate != 0 {
+			turbine.rotorEfficiencyLastTick = effectiveFlowRate / flowRate
+		} else {
+			turbine.rotorEfficiencyLastTick = 0
+		}
+
+		steamEnergy = flowRate * turbine.fluid.LatentHeat * config.TurbineMultiplier
+		if effectiveFlowRate > 0 {
+			rotorInput = effectiveFlowRate * turbine.fluid.LatentHeat * config.TurbineMultiplier
+			turbine.rotorEnergy += rotorInput
+		}
+	} else {
+		turbine.rotorEfficiencyLastTick = 0
+	}
+
+	if turbine.coilEngaged {
+		inductionTorque := rpm * turbine.inductorDragCoefficient * float64(turbine.coilSize)
+		efficiency := turbine.curve.efficiency(rpm)
+		turbine.coilEfficiencyLastTick = efficiency
+
+		turbine.energyGeneratedLastTick = inductionEnergy(inductionTorque, turbine.inductionEnergyExponentBonus, turbine.inductionEfficiency, efficiency, turbine.precision)
+
+		turbine.inductorDragLastTick = inductionTorque
+		turbine.rotorEnergy -= inductionTorque
+	} else {
+		turbine.inductorDragLastTick = 0
+		turbine.energyGeneratedLastTick = 0
+	}
+
+	turbine.frictionDragLastTick = turbine.rotorMass * (rpm * config.FrictionDragMultiplier) * (rpm * config.FrictionDragMultiplier)
+	turbine.rotorEnergy -= turbine.frictionDragLastTick
+	turbine.aeroDragLastTick = turbine.linearBladeMetersPerRevolution * (rpm * config.AerodynamicDragMultiplier) * (rpm * config.AerodynamicDragMultiplier)
+	turbine.rotorEnergy -= turbine.aeroDragLastTick
+
+	clamped := 0.0
+	if turbine.rotorEnergy < 0 {
+		clamped = -turbine.rotorEnergy
+		turbine.rotorEnergy = 0
+	}
+
+	if turbine.audit != nil {
+		turbine.audit.record(turbine, startEnergy, steamEnergy, rotorInput, clamped)
+	}
+	if debugInvariants {
+		turbine.checkTick()
+	}
+}
+
+// inductionEnergy is the RF the coils make in a tick from the torque on them
+func inductionEnergy(torque, exponentBonus, inductionEfficiency, coilEfficiency float64, precision Precision) float64 {
+	var energy float64
+	if precision == PrecisionExact {
+		energy = math.Pow(torque, exponentBonus)
+	} else {
+		energy = fasterPow(torque, exponentBonus)
+	}
+	return energy * inductionEfficiency * coilEfficiency
+}
+
+func (turbine Turbine) FinalRPM() float64 {
+	rpm := turbine.steadyRPM(turbine.inductorDragCoefficient * float64(turbine.coilSize))
+	if debugInvariants {
+		turbine.checkFinalRPM(rpm)
+	}
+	return rpm
+}
+
+// FinalRPMNoLoad is where the rotor ends up with the coils disengaged, only friction and air slowing it down
+func (turbine Turbine) FinalRPMNoLoad() float64 {
+	return turbine.steadyRPM(0)
+}
+
+// steadyRPM solves for the rpm where the steam makes up for the drag, coilDrag is the coil drag per rpm
+func (turbine Turbine) steadyRPM(coilDrag float64) float64 {
+	return steadyRPM(float64(turbine.maxFlowRate), turbine.rotorCapacityPerRPM, turbine.rotorDragPerRPM2(), coilDrag, turbine.rfPerHeat())
+}
+
+// rotorDragPerRPM2 is the friction and air drag over rpm squared
+func (turbine Turbine) rotorDragPerRPM2() float64 {
+	config := turbine.config
+	return turbine.rotorMass*config.FrictionDragMultiplier*config.FrictionDragMultiplier + turbine.linearBladeMetersPerRevolution*config.AerodynamicDragMultiplier*config.AerodynamicDragMultiplier
+}
+
+// steadyRPM is the closed form behind FinalRPM on plain numbers, so batches can run it without a Turbine.
+// rotorDrag and coilDrag are the drag over rpm squared and over rpm.
+func steadyRPM(flowRate, rotorCapacityPerRPM, rotorDrag, coilDrag, RFPerHeat float64) float64 {
+	// first assume that we have: final rpm < 100
+	effectiveFlowRate := flowRate
+	rotorCapacity := rotorCapacityPerRPM * 100
+	if flowRate > rotorCapacity {
+		// excessFlow := flowRate - rotorCapacity
+		// excessEfficiency := rotorCapacity / flowRate
+		// effectiveFlowRate = rotorCapacity + excessFlow*excessEfficiency
+		effectiveFlowRate = rotorCapacity + rotorCapacity - rotorCapacity*rotorCapacity/flowRate
+	}
+
+	a := rotorDrag
+	b := coilDrag
+	c := -effectiveFlowRate * RFPerHeat
+
+	predictedRPM := (-b + math.Sqrt(b*b-4*a*c)) / (2 * a)
+
+	if predictedRPM < 100 {
+		// fmt.Println(a, b, c)
+		// fmt.Printf("Low speed %.2f\n", predictedRPM)
+		return predictedRPM
+	}
+
+	// now assume we have no restriction on flow
+	c = -flowRate * RFPerHeat
+
+	predictedRPM = (-b + math.Sqrt(b*b-4*a*c)) / (2 * a)
+	predictedRotorCapacity := rotorCapacityPerRPM * predictedRPM
+
+	if flowRate < predictedRotorCapacity {
+		// we indeed have enough capacity
+		// fmt.Println("Enough capacity")
+		return predictedRPM
+	}
+
+	// we have capacity issues so we take the third path
+
+	a += rotorCapacityPerRPM * rotorCapacityPerRPM / flowRate * RFPerHeat
+	b += -2 * rotorCapacityPerRPM * RFPerHeat
+	// c = 0
+
+	predictedRPM = -b / a
+
+	// fmt.Printf("Not enough capacity %.2f\n", predictedRPM)
+	return predictedRPM
+}
+
+// FlowForRPM inverts FinalRPM: it returns the flow rate whose steady state is the given rpm,
+// or false if no flow rate can hold the rotor there
+func (turbine Turbine) FlowForRPM(rpm float64) (float64, bool) {
+	return turbine.flowForRPM(rpm, turbine.inductorDragCoefficient*float64(turbine.coilSize))
+}
+
+// flowForRPM is FlowForRPM with coilDrag per rpm, zero for the coils disengaged
+func (turbine Turbine) flowForRPM(rpm, coilDrag float64) (float64, bool) {
+	RFPerHeat := turbine.rfPerHeat()
+
+	a := turbine.rotorDragPerRPM2()
+	b := coilDrag
+
+	// the steam has to make up for all the drag at that rpm
+	effectiveFlowRate := (a*rpm*rpm + b*rpm) / RFPerHeat
+	rotorCapacity := turbine.rotorCapacityPerRPM * max(100, rpm)
+
+	var flowRate float64
+	if effectiveFlowRate <= rotorCapacity {
+		flowRate = effectiveFlowRate
+	} else if effectiveFlowRate < 2*rotorCapacity {
+		// effectiveFlowRate = 2 * rotorCapacity - rotorCapacity^2 / flowRate
+		flowRate = rotorCapacity * rotorCapacity / (2*rotorCapacity - effectiveFlowRate)
+	} else {
+		// excess steam can never be more than the rotor capacity
+		return 0, false
+	}
+
+	if flowRate > float64(turbine.maxMaxFlowRate) {
+		return flowRate, false
+	}
+	return flowRate, true
+}
+
+// RotorCapacity is how much steam the blades can use at the steady state rpm of the current flow rate
+func (turbine Turbine) RotorCapacity() float64 {
+	return turbine.rotorCapacityPerRPM * max(100, turbine.FinalRPM())
+}
+
+// SweetSpotFlow is the highest flow rate the rotor still uses fully, any more and rotor efficiency drops below 100%.
+// It is capped at the max flow rate when the turbine can't take in enough steam to get there.
+func (turbine Turbine) SweetSpotFlow() int64 {
+	RFPerHeat := turbine.rfPerHeat()
+
+	a := turbine.rotorDragPerRPM2()
+	b := turbine.inductorDragCoefficient * float64(turbine.coilSize)
+
+	// the flow holding rpm is (a*rpm^2 + b*rpm) / RFPerHeat, it fits the capacity while a*rpm + b <= rotorCapacityPerRPM * RFPerHeat
+	rpm := max(100, (turbine.rotorCapacityPerRPM*RFPerHeat-b)/a)
+	return min(turbine.maxMaxFlowRate, int64(math.Floor(turbine.rotorCapacityPerRPM*rpm)))
+}
+
+func (turbine *Turbine) SetEnergyForRPM(rpm float64) {
+	turbine.rotorEnergy = turbine.rotorAxialMass * rpm
+}
+
+func (turbine *Turbine) SetActive(active bool) {
+	turbine.active = active
+}
+
+func (turbine *Turbine) SetCoilEngaged(engaged bool) {
+	turbine.coilEngaged = engaged
+}
+
+func (turbine *Turbine) SetPrecision(precision Precision) {
+	turbine.precision = precision
+}
+
+// Settle jumps to the steady state rpm for the current flow rate and ticks once to fill in the last tick values
+func (turbine *Turbine) Settle() {
+	turbine.SetEnergyForRPM(turbine.FinalRPM())
+	turbine.Tick()
+}
+
+const maxConvergenceTicks = 10000
+const convergenceTolerance = 1e-9
+
+// Converge settles the turbine with exact math and then keeps ticking until the rpm stops moving,
+// so the reported values match what the game would show after running for a while
+func (turbine *Turbine) Converge() {
+	turbine.SetPrecision(PrecisionExact)
+	turbine.Settle()
+
+	for range maxConvergenceTicks {
+		previousRPM := turbine.RPM()
+		turbine.Tick()
+		if math.Abs(turbine.RPM()-previousRPM) <= convergenceTolerance*max(1, previousRPM) {
+			return
+		}
+	}
+}
+
+func (turbine Turbine) PrintStats() {
+	fmt.Printf("\nHeight %d, Width %d, Coil layers: %d\n", turbine.size.Y+2, turbine.size.X+2, turbine.coilLayers)
+	fmt.Printf("Producing %.1f RF/t\n", turbine.energyGeneratedLastTick)
+	fmt.Printf("Current flow: %dmb/t; Current rpm: %.1f\n", turbine.maxFlowRate, turbine.RPM())
+	fmt.Printf("Current rotor capacity: %.1fmb/t\n", turbine.rotorCapacityPerRPM*turbine.RPM())
+	fmt.Printf("%.3f RF/mb; Rotor flow efficiency: %.1f%%\n", turbine.energyGeneratedLastTick/float64(turbine.maxFlowRate), turbine.rotorEfficiencyLastTick*100)
+	fmt.Printf("Coil efficiency at rpm: %.1f%%\n", turbine.coilEfficiencyLastTick*100)
+	totalDrag := turbine.frictionDragLastTick + turbine.aeroDragLastTick + turbine.inductorDragLastTick
+	usedDrag := turbine.inductorDragLastTick
+	fmt.Printf("Drag experienced = friction: %.1f; aero: %.1f; coil : %.1f\n", turbine.frictionDragLastTick, turbine.aeroDragLastTick, turbine.inductorDragLastTick)
+	fmt.Printf("Useful drag: %f%%\n\n", usedDrag/totalDrag*100)
+}
+
+func (turbine Turbine) PrintBuildCost() {
+	for _, item := range turbine.BuildCost() {
+		fmt.Printf("%s %s\n", item.StackText(), item.Name)
+	}
+}
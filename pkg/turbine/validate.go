@@ -0,0 +1,199 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ValidationError is an input that was turned down, with a value that would have been accepted
+type ValidationError struct {
+	Field   string
+	Message string
+	// empty when there is nothing sensible to offer
+	Suggestion string
+}
+
+func (err ValidationError) Error() string {
+	if err.Suggestion == "" {
+		return err.Message
+	}
+	return fmt.Sprintf("%s, did you mean %s?", err.Message, err.Suggestion)
+}
+
+// Coil looks up a coil material by name, suggesting the closest one if there is no such material
+func (config Config) Coil(name string) (CoilData, error) {
+	if coil, ok := config.Coils[name]; ok {
+		return coil, nil
+	}
+	if _, ok := config.lockedCoils[name]; ok {
+		return CoilData{}, ValidationError{"coil", fmt.Sprintf("Coil material %q isn't unlocked yet", name), ""}
+	}
+
+	names := make([]string, 0, len(config.Coils))
+	for _, material := range config.CoilMaterials() {
+		names = append(names, material.Name)
+	}
+	return CoilData{}, ValidationError{"coil", fmt.Sprintf("Unknown coil material %q", name), closestName(name, names)}
+}
+
+// closestName is the name nearest to name ignoring case, the first of them on a tie,
+// or empty if even that is too far off to be a typo
+func closestName(name string, names []string) string {
+	closest := ""
+	closestDistance := 0
+	for _, candidate := range names {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if closest == "" || distance < closestDistance {
+			closest = candidate
+			closestDistance = distance
+		}
+	}
+	// anything further off than half the name is a different word
+	if closestDistance > max(len(name), len(closest))/2 {
+		return ""
+	}
+	return closest
+}
+
+// the smallest turbine that still has one block of rotor inside its casing and room for a coil layer
+const minTurbineWidth int32 = 3
+const minTurbineHeight int32 = 4
+
+// the most a loaded profile may allow, far past any mod but small enough that no block count or flow rate overflows
+const maxConfigWidth int32 = 255
+const maxConfigHeight int32 = 4096
+const maxFlowRatePerBlock int64 = 1_000_000_000
+const maxFlowMultiplier = 1000
+const maxGridFrequency = 1000
+const maxEfficiencyPeaks = 16
+
+// ValidateLimits checks the sizes and rates of a config, loaded profiles can set them to anything
+func (config Config) ValidateLimits() error {
+	if config.MinWidth < minTurbineWidth || config.MinWidth > config.MaxWidth {
+		return ValidationError{"minWidth", fmt.Sprintf("Min width %d is outside %d to the max width of %d", config.MinWidth, minTurbineWidth, config.MaxWidth), ""}
+	}
+	if config.MaxWidth > maxConfigWidth {
+		return ValidationError{"maxWidth", fmt.Sprintf("Max width %d is over %d", config.MaxWidth, maxConfigWidth), fmt.Sprint(maxConfigWidth)}
+	}
+	if config.MinHeight < minTurbineHeight || config.MinHeight > config.MaxHeight {
+		return ValidationError{"minHeight", fmt.Sprintf("Min height %d is outside %d to the max height of %d", config.MinHeight, minTurbineHeight, config.MaxHeight), ""}
+	}
+	if config.MaxHeight > maxConfigHeight {
+		return ValidationError{"maxHeight", fmt.Sprintf("Max height %d is over %d", config.MaxHeight, maxConfigHeight), fmt.Sprint(maxConfigHeight)}
+	}
+	if config.FlowRatePerBlock < 0 || config.FlowRatePerBlock > maxFlowRatePerBlock {
+		return ValidationError{"flowRatePerBlock", fmt.Sprintf("Flow rate per block %d is outside 0 to %d", config.FlowRatePerBlock, maxFlowRatePerBlock), ""}
+	}
+	shaftLimits := []struct {
+		field string
+		value int32
+	}{{"maxShaftLength", config.MaxShaftLength}, {"bearings", config.Bearings}, {"shaftLengthPerBearing", config.ShaftLengthPerBearing}}
+	for _, limit := range shaftLimits {
+		if limit.value < 0 || limit.value > maxConfigHeight {
+			return ValidationError{limit.field, fmt.Sprintf("%s %d is outside 0 to %d", limit.field, limit.value, maxConfigHeight), ""}
+		}
+	}
+	if !(config.GridFrequency >= 0 && config.GridFrequency <= maxGridFrequency) {
+		return ValidationError{"gridFrequency", fmt.Sprintf("Grid frequency %g is outside 0 to %d", config.GridFrequency, maxGridFrequency), ""}
+	}
+	if config.EfficiencyPeaks < 0 || config.EfficiencyPeaks > maxEfficiencyPeaks {
+		return ValidationError{"efficiencyPeaks", fmt.Sprintf("Efficiency peaks %d is outside 0 to %d", config.EfficiencyPeaks, maxEfficiencyPeaks), ""}
+	}
+	// the steady state divides by these, zero or less makes every rpm NaN
+	positive := []struct {
+		field string
+		value float64
+	}{
+		{"latentHeat", config.LatentHeat},
+		{"turbineMultiplier", config.TurbineMultiplier},
+		{"fluidPerBladeLinerKilometre", config.FluidPerBladeLinerKilometre},
+		{"rotorAxialMassPerShaft", config.RotorAxialMassPerShaft},
+		{"rotorAxialMassPerBlade", config.RotorAxialMassPerBlade},
+	}
+	for _, constant := range positive {
+		if !(constant.value > 0) || math.IsInf(constant.value, 1) {
+			return ValidationError{constant.field, fmt.Sprintf("%s %g has to be a positive number", constant.field, constant.value), ""}
+		}
+	}
+	// a negative drag would push the rotor instead of slowing it
+	drags := []struct {
+		field string
+		value float64
+	}{
+		{"coilDragMultiplier", config.CoilDragMultiplier},
+		{"frictionDragMultiplier", config.FrictionDragMultiplier},
+		{"aerodynamicDragMultiplier", config.AerodynamicDragMultiplier},
+	}
+	for _, drag := range drags {
+		if !(drag.value >= 0) || math.IsInf(drag.value, 1) {
+			return ValidationError{drag.field, fmt.Sprintf("%s %g cannot be negative", drag.field, drag.value), ""}
+		}
+	}
+	for _, fluid := range config.FluidMaterials() {
+		if !(fluid.FlowMultiplier > 0 && fluid.FlowMultiplier <= maxFlowMultiplier) {
+			return ValidationError{"fluids", fmt.Sprintf("%s flow multiplier %g is outside 0 to %d", fluid.Name, fluid.FlowMultiplier, maxFlowMultiplier), ""}
+		}
+	}
+	return nil
+}
+
+// ValidateDesignSize checks the outer size of one turbine, suggesting the nearest size that can be built
+func (config Config) ValidateDesignSize(width, height int32) error {
+	if width < config.MinWidth || width > config.MaxWidth {
+		return ValidationError{"width", fmt.Sprintf("Width %d is outside %d to %d", width, config.MinWidth, config.MaxWidth), fmt.Sprint(config.nearestWidth(width))}
+	}
+	if width%2 == 0 {
+		return ValidationError{"width", fmt.Sprintf("Width %d is even, turbines have to be odd", width), fmt.Sprint(config.nearestWidth(width))}
+	}
+	if height < config.MinHeight || height > config.MaxHeight {
+		return ValidationError{"height", fmt.Sprintf("Height %d is outside %d to %d", height, config.MinHeight, config.MaxHeight), fmt.Sprint(min(max(height, config.MinHeight), config.MaxHeight))}
+	}
+	return nil
+}
+
+// nearestWidth is the closest odd width within the limits, rounding down when both neighbours are as close
+func (config Config) nearestWidth(width int32) int32 {
+	width = min(max(width, config.MinWidth), config.MaxWidth)
+	if width%2 == 1 {
+		return width
+	}
+	if width-1 >= config.MinWidth {
+		return width - 1
+	}
+	return width + 1
+}
+
+// ValidateMaxSize checks search bounds: they only need room for the smallest turbine,
+// anything over the config limits is clamped by the search
+func (config Config) ValidateMaxSize(maxWidth, maxHeight int32) error {
+	if maxWidth < config.MinWidth {
+		return ValidationError{"maxWidth", fmt.Sprintf("Max width %d is under the smallest turbine width of %d", maxWidth, config.MinWidth), fmt.Sprint(config.MinWidth)}
+	}
+	if maxHeight < config.MinHeight {
+		return ValidationError{"maxHeight", fmt.Sprintf("Max height %d is under the smallest turbine height of %d", maxHeight, config.MinHeight), fmt.Sprint(config.MinHeight)}
+	}
+	return nil
+}
+
+// editDistance is the levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
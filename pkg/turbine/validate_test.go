@@ -0,0 +1,75 @@
+package turbine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCoilSuggestions(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantSuggestion string
+	}{
+		{"gold", "Gold"},
+		{"Enderim", "Enderium"},
+		{"Ludicrit", "Ludicrite"},
+		{"Diamond", ""},
+	}
+
+	for _, tc := range tests {
+		_, err := BiggerReactorsConfig.Coil(tc.name)
+		var validationErr ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Coil(%q) error = %v", tc.name, err)
+		}
+		if validationErr.Field != "coil" || validationErr.Suggestion != tc.wantSuggestion {
+			t.Errorf("Coil(%q) = %+v, want suggestion %q", tc.name, validationErr, tc.wantSuggestion)
+		}
+	}
+
+	if coil, err := BiggerReactorsConfig.Coil("Gold"); err != nil || coil != biggerReactorsCoils["Gold"] {
+		t.Errorf("Coil(Gold) = %v, %v", coil, err)
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	for _, profile := range Profiles() {
+		if err := profile.Config.ValidateLimits(); err != nil {
+			t.Errorf("bundled profile %s: %v", profile.Name, err)
+		}
+	}
+
+	config := BiggerReactorsConfig.Clone()
+	config.MinWidth, config.MaxWidth = 33, 31
+	var validationErr ValidationError
+	if err := config.ValidateLimits(); !errors.As(err, &validationErr) || validationErr.Field != "minWidth" {
+		t.Errorf("min width over the max: %v", err)
+	}
+}
+
+func TestValidateDesignSize(t *testing.T) {
+	tests := []struct {
+		width, height  int32
+		wantField      string
+		wantSuggestion string
+	}{
+		{9, 10, "", ""},
+		{10, 10, "width", "9"},
+		{4, 10, "width", "5"},
+		{41, 10, "width", "31"},
+		{9, 200, "height", "192"},
+	}
+
+	for _, tc := range tests {
+		err := BiggerReactorsConfig.ValidateDesignSize(tc.width, tc.height)
+		var validationErr ValidationError
+		errors.As(err, &validationErr)
+		if validationErr.Field != tc.wantField || validationErr.Suggestion != tc.wantSuggestion {
+			t.Errorf("ValidateDesignSize(%d, %d) = %v, want %s %s", tc.width, tc.height, err, tc.wantField, tc.wantSuggestion)
+		}
+	}
+
+	if err := BiggerReactorsConfig.ValidateMaxSize(3, 10); err == nil {
+		t.Error("max width under the smallest turbine accepted")
+	}
+}
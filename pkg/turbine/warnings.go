@@ -0,0 +1,76 @@
+package turbine
+
+import "fmt"
+
+type WarningCode int64
+
+const (
+	// part of the steam goes past the blades without pushing them
+	LowRotorEfficiency WarningCode = iota
+	LowCoilEfficiency
+	// rpm above the highest efficiency peak, where the coil efficiency falls off quickly
+	PastLastPeak
+	// the blades could take a lot more steam at this rpm
+	FlowBelowCapacity
+	// rpm above Config.MaxSafeRPM
+	OverSafeRPM
+)
+
+var warningCodeNames = map[WarningCode]string{
+	LowRotorEfficiency: "lowRotorEfficiency",
+	LowCoilEfficiency:  "lowCoilEfficiency",
+	PastLastPeak:       "pastLastPeak",
+	FlowBelowCapacity:  "flowBelowCapacity",
+	OverSafeRPM:        "overSafeRPM",
+}
+
+func (code WarningCode) String() string {
+	if name, ok := warningCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("WarningCode(%d)", int64(code))
+}
+
+func (code WarningCode) MarshalText() ([]byte, error) {
+	return []byte(code.String()), nil
+}
+
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
+}
+
+const minRotorEfficiency = 0.95
+const minCoilEfficiency = 0.7
+
+// flow under this share of the rotor capacity counts as far below it
+const minCapacityUse = 0.5
+
+// Warnings flags the parts of the turbine's last tick that would be a problem in game
+func (turbine Turbine) Warnings() []Warning {
+	stats := turbine.Stats()
+	warnings := []Warning{}
+
+	if stats.FlowRate > 0 && stats.RotorEfficiency < minRotorEfficiency {
+		warnings = append(warnings, Warning{LowRotorEfficiency, fmt.Sprintf("Rotor efficiency is %.1f%%, the blades can't use all the steam", stats.RotorEfficiency*100)})
+	}
+	if stats.CoilEfficiency < minCoilEfficiency {
+		warnings = append(warnings, Warning{LowCoilEfficiency, fmt.Sprintf("Coil efficiency is %.1f%% at %.0f RPM", stats.CoilEfficiency*100, stats.RPM)})
+	}
+
+	lastPeak := turbine.config.EfficiencyPeakRPMs()[0]
+	if stats.RPM > lastPeak && stats.CoilEfficiency < nearPeakEfficiency {
+		warnings = append(warnings, Warning{PastLastPeak, fmt.Sprintf("Rotor runs at %.0f RPM, past the %.0f RPM peak", stats.RPM, lastPeak)})
+	}
+
+	rotorCapacity := turbine.rotorCapacityPerRPM * max(100, stats.RPM)
+	if float64(stats.FlowRate) < rotorCapacity*minCapacityUse {
+		warnings = append(warnings, Warning{FlowBelowCapacity, fmt.Sprintf("Flow of %d mB/t is far below the rotor capacity of %.0f mB/t", stats.FlowRate, rotorCapacity)})
+	}
+
+	if maxSafeRPM := turbine.config.MaxSafeRPM; maxSafeRPM > 0 && stats.RPM > maxSafeRPM {
+		warnings = append(warnings, Warning{OverSafeRPM, fmt.Sprintf("Rotor runs at %.0f RPM, over the safe limit of %.0f RPM", stats.RPM, maxSafeRPM)})
+	}
+
+	return warnings
+}
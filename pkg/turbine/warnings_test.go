@@ -0,0 +1,52 @@
+package turbine
+
+import (
+	"slices"
+	"testing"
+)
+
+func warningCodes(warnings []Warning) []WarningCode {
+	codes := []WarningCode{}
+	for _, warning := range warnings {
+		codes = append(codes, warning.Code)
+	}
+	return codes
+}
+
+func TestWarnings(t *testing.T) {
+	tests := []struct {
+		name       string
+		height     int32
+		width      int32
+		coilLayers int32
+		flowRate   int64
+		want       []WarningCode
+	}{
+		// too few coils to slow the rotor down
+		{"overspeed", 19, 15, 1, 840000, []WarningCode{LowCoilEfficiency, PastLastPeak, FlowBelowCapacity}},
+		// enough coils to hold the peak, but the blades can't keep up with the steam
+		{"flooded", 19, 15, 8, 840000, []WarningCode{LowRotorEfficiency}},
+		// not enough steam to spin up to a peak
+		{"slow", 16, 13, 3, 20000, []WarningCode{LowCoilEfficiency}},
+		// zero flow means the flow rate that holds the 1800 RPM peak
+		{"balanced", 16, 13, 3, 0, []WarningCode{}},
+	}
+
+	for _, test := range tests {
+		turbine, err := NewTurbine(&BiggerReactorsConfig, test.height, test.width, test.coilLayers, biggerReactorsCoils["Enderium"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		flowRate := test.flowRate
+		if flowRate == 0 {
+			peakFlow, _ := turbine.FlowForRPM(1800)
+			flowRate = int64(peakFlow)
+		}
+		turbine.SetNominalFlowRate(flowRate)
+		turbine.Converge()
+
+		if got := warningCodes(turbine.Warnings()); !slices.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v (%+v)", test.name, got, test.want, turbine.Stats())
+		}
+	}
+}
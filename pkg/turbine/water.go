@@ -0,0 +1,41 @@
+package turbine
+
+import (
+	"fmt"
+	"math"
+)
+
+// water to hold in reserve for the condensate to make it back through pipes and pumps, a second of flow
+const waterLoopTicks = 20
+
+// WaterLoop sizes the return of condensed steam to the reactor or boiler. Every mB of steam
+// the turbine takes in leaves as a mB of water, so a closed loop only has to move it back in time.
+type WaterLoop struct {
+	// water coming out of the turbine per tick
+	ReturnFlow int64 `json:"returnFlow"`
+	// what the pipes back to the reactor have to carry
+	PipeThroughput int64 `json:"pipeThroughput"`
+	// water to keep ahead of the reactor so it isn't starved while the loop fills up
+	WaterBuffer int64 `json:"waterBuffer"`
+	// ticks until the turbine's tank is full of water with nothing pumping it out, generation stops then
+	TicksToBackUp int64 `json:"ticksToBackUp"`
+
+	Message string `json:"message"`
+}
+
+func (turbine Turbine) WaterLoop() WaterLoop {
+	flowRate := turbine.maxFlowRate
+	loop := WaterLoop{
+		ReturnFlow:     flowRate,
+		PipeThroughput: flowRate,
+		WaterBuffer:    flowRate * waterLoopTicks,
+	}
+	if flowRate <= 0 {
+		loop.Message = "Turbine takes no steam, there is no water to return"
+		return loop
+	}
+
+	loop.TicksToBackUp = int64(math.Ceil(turbine.fluidTankCapacity / float64(flowRate)))
+	loop.Message = fmt.Sprintf("Pipe %d mB/t of water back and keep %d mB in reserve, the turbine backs up in %d ticks otherwise", loop.PipeThroughput, loop.WaterBuffer, loop.TicksToBackUp)
+	return loop
+}
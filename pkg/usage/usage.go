@@ -0,0 +1,175 @@
+// Package usage counts which calculations people run, without anything that identifies them,
+// so the site can show the most common designs. The site only reports when the user opted in.
+package usage
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Query is one optimizer run as the site reports it
+type Query struct {
+	Coil      string `json:"coil"`
+	MaxWidth  int32  `json:"maxWidth"`
+	MaxHeight int32  `json:"maxHeight"`
+
+	// the design the optimizer picked
+	Width      int32 `json:"width"`
+	Height     int32 `json:"height"`
+	CoilLayers int32 `json:"coilLayers"`
+}
+
+type Count struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type Design struct {
+	Coil       string `json:"coil"`
+	Width      int32  `json:"width"`
+	Height     int32  `json:"height"`
+	CoilLayers int32  `json:"coilLayers"`
+	Count      int64  `json:"count"`
+}
+
+type Popular struct {
+	Coils   []Count  `json:"coils"`
+	Sizes   []Count  `json:"sizes"`
+	Designs []Design `json:"designs"`
+}
+
+var (
+	coilsBucket   = []byte("coils")
+	sizesBucket   = []byte("sizes")
+	designsBucket = []byte("designs")
+)
+
+// max sizes are counted in ranges this wide, the exact numbers don't matter
+const sizeRange = 8
+
+// ErrInvalid matches the errors for a query Record won't count, as opposed to the store failing
+var ErrInvalid = errors.New("Invalid query")
+
+type Store struct {
+	db *bolt.DB
+}
+
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{coilsBucket, sizesBucket, designsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db}, nil
+}
+
+func (store *Store) Close() error {
+	return store.db.Close()
+}
+
+func rangeOf(size int32) string {
+	low := (size-1)/sizeRange*sizeRange + 1
+	return fmt.Sprintf("%d-%d", low, low+sizeRange-1)
+}
+
+func designKey(query Query) string {
+	return fmt.Sprintf("%s|%d|%d|%d", query.Coil, query.Width, query.Height, query.CoilLayers)
+}
+
+func increment(bucket *bolt.Bucket, key string) error {
+	count := uint64(0)
+	if value := bucket.Get([]byte(key)); len(value) == 8 {
+		count = binary.BigEndian.Uint64(value)
+	}
+	return bucket.Put([]byte(key), binary.BigEndian.AppendUint64(nil, count+1))
+}
+
+// Record counts a query run against config. Only its coils and sizes are counted, anything else would let
+// anyone grow the store without bound and fill the popular lists with made up designs.
+func (store *Store) Record(config *turbine.Config, query Query) error {
+	if _, err := config.Coil(query.Coil); err != nil || strings.Contains(query.Coil, "|") {
+		return fmt.Errorf("%w: %q is not a coil material of the config", ErrInvalid, query.Coil)
+	}
+	if query.MaxWidth < config.MinWidth || query.MaxWidth > config.MaxWidth ||
+		query.MaxHeight < config.MinHeight || query.MaxHeight > config.MaxHeight {
+		return fmt.Errorf("%w: the max sizes have to be between %dx%d and %dx%d", ErrInvalid,
+			config.MinWidth, config.MinHeight, config.MaxWidth, config.MaxHeight)
+	}
+	if query.Width < config.MinWidth || query.Width > query.MaxWidth ||
+		query.Height < config.MinHeight || query.Height > query.MaxHeight {
+		return fmt.Errorf("%w: the design has to fit in the max sizes", ErrInvalid)
+	}
+	// the same bound NewTurbine puts on the coil layers
+	if query.CoilLayers <= 0 || query.CoilLayers > query.Height-3 {
+		return fmt.Errorf("%w: a %d high turbine has between 1 and %d coil layers", ErrInvalid, query.Height, query.Height-3)
+	}
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		if err := increment(tx.Bucket(coilsBucket), query.Coil); err != nil {
+			return err
+		}
+		size := fmt.Sprintf("width %s, height %s", rangeOf(query.MaxWidth), rangeOf(query.MaxHeight))
+		if err := increment(tx.Bucket(sizesBucket), size); err != nil {
+			return err
+		}
+		return increment(tx.Bucket(designsBucket), designKey(query))
+	})
+}
+
+// topCounts returns the limit largest counts of the bucket, ties by name
+func topCounts(bucket *bolt.Bucket, limit int) ([]Count, error) {
+	counts := []Count{}
+	err := bucket.ForEach(func(key, value []byte) error {
+		counts = append(counts, Count{string(key), int64(binary.BigEndian.Uint64(value))})
+		return nil
+	})
+	slices.SortFunc(counts, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	return counts[:min(limit, len(counts))], err
+}
+
+func (store *Store) Popular(limit int) (Popular, error) {
+	popular := Popular{}
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		if popular.Coils, err = topCounts(tx.Bucket(coilsBucket), limit); err != nil {
+			return err
+		}
+		if popular.Sizes, err = topCounts(tx.Bucket(sizesBucket), limit); err != nil {
+			return err
+		}
+
+		designs, err := topCounts(tx.Bucket(designsBucket), limit)
+		popular.Designs = []Design{}
+		for _, design := range designs {
+			parsed := Design{Count: design.Count}
+			parts := strings.Split(design.Name, "|")
+			parsed.Coil = parts[0]
+			fmt.Sscan(strings.Join(parts[1:], " "), &parsed.Width, &parsed.Height, &parsed.CoilLayers)
+			popular.Designs = append(popular.Designs, parsed)
+		}
+		return err
+	})
+	return popular, err
+}
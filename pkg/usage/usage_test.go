@@ -0,0 +1,77 @@
+package usage
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/drabart/turbine-calculator-website/pkg/turbine"
+)
+
+func TestPopular(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "usage.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	queries := []Query{
+		{"Enderium", 15, 20, 15, 19, 8},
+		{"Enderium", 15, 18, 15, 18, 8},
+		{"Enderium", 15, 20, 15, 19, 8},
+		{"Gold", 9, 12, 9, 12, 3},
+	}
+	for _, query := range queries {
+		if err := store.Record(&turbine.BiggerReactorsConfig, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	popular, err := store.Popular(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []Count{{"Enderium", 3}, {"Gold", 1}}; !slices.Equal(popular.Coils, want) {
+		t.Errorf("coils %v, want %v", popular.Coils, want)
+	}
+	// 18 and 20 high fall in the same range
+	if want := []Count{{"width 9-16, height 17-24", 3}, {"width 9-16, height 9-16", 1}}; !slices.Equal(popular.Sizes, want) {
+		t.Errorf("sizes %v, want %v", popular.Sizes, want)
+	}
+	if want := []Design{{"Enderium", 15, 19, 8, 2}, {"Enderium", 15, 18, 8, 1}}; !slices.Equal(popular.Designs, want) {
+		t.Errorf("designs %v, want %v", popular.Designs, want)
+	}
+}
+
+func TestRecordRejectsBadQuery(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "usage.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	queries := []Query{
+		{"", 9, 12, 9, 12, 3},
+		{"Gold|x", 9, 12, 9, 12, 3},
+		{"Gold", 9, 12, 0, 12, 3},
+		// not in the coil table, made up names would fill the store
+		{"Goldd", 9, 12, 9, 12, 3},
+		{strings.Repeat("x", 64), 9, 12, 9, 12, 3},
+		// outside the config's limits
+		{"Gold", 2000, 12, 9, 12, 3},
+		{"Gold", 9, 1 << 30, 9, 12, 3},
+		{"Gold", 9, 12, 3, 12, 3},
+		// bigger than the max sizes or more coils than fit
+		{"Gold", 9, 12, 11, 12, 3},
+		{"Gold", 9, 12, 9, 13, 3},
+		{"Gold", 9, 12, 9, 12, 10},
+	}
+	for _, query := range queries {
+		if err := store.Record(&turbine.BiggerReactorsConfig, query); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%+v: expected an invalid query, got %v", query, err)
+		}
+	}
+}
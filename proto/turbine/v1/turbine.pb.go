@@ -0,0 +1,1249 @@
+// The turbine optimizer as a gRPC service, for integrations that can't run the wasm build. cmd/grpc serves it.
+//
+// turbine.pb.go and turbine_grpc.pb.go next to this file are generated from it with
+//
+//   protoc --go_out=. --go_opt=module=github.com/drabart/turbine-calculator-website \
+//     --go-grpc_out=. --go-grpc_opt=module=github.com/drabart/turbine-calculator-website \
+//     proto/turbine/v1/turbine.proto
+//
+// using protoc-gen-go v1.36.6 and protoc-gen-go-grpc v1.5.1. The messages follow the json the site gets from the
+// wasm build, field for field.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/turbine/v1/turbine.proto
+
+package turbinev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FlowMode int32
+
+const (
+	FlowMode_FLOW_MODE_UNSPECIFIED FlowMode = 0
+	// every turbine at its max flow rate
+	FlowMode_FLOW_MODE_MAX FlowMode = 1
+	// the best flow rate in steps of flow_value
+	FlowMode_FLOW_MODE_BEST FlowMode = 2
+	// every turbine at flow_value
+	FlowMode_FLOW_MODE_SET FlowMode = 3
+	// the best flow rate up to flow_value, the steam a source provides
+	FlowMode_FLOW_MODE_BEST_UNDER FlowMode = 4
+	// the flow rate holding flow_value rpm
+	FlowMode_FLOW_MODE_TARGET_RPM FlowMode = 5
+)
+
+// Enum value maps for FlowMode.
+var (
+	FlowMode_name = map[int32]string{
+		0: "FLOW_MODE_UNSPECIFIED",
+		1: "FLOW_MODE_MAX",
+		2: "FLOW_MODE_BEST",
+		3: "FLOW_MODE_SET",
+		4: "FLOW_MODE_BEST_UNDER",
+		5: "FLOW_MODE_TARGET_RPM",
+	}
+	FlowMode_value = map[string]int32{
+		"FLOW_MODE_UNSPECIFIED": 0,
+		"FLOW_MODE_MAX":         1,
+		"FLOW_MODE_BEST":        2,
+		"FLOW_MODE_SET":         3,
+		"FLOW_MODE_BEST_UNDER":  4,
+		"FLOW_MODE_TARGET_RPM":  5,
+	}
+)
+
+func (x FlowMode) Enum() *FlowMode {
+	p := new(FlowMode)
+	*p = x
+	return p
+}
+
+func (x FlowMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FlowMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_turbine_v1_turbine_proto_enumTypes[0].Descriptor()
+}
+
+func (FlowMode) Type() protoreflect.EnumType {
+	return &file_proto_turbine_v1_turbine_proto_enumTypes[0]
+}
+
+func (x FlowMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FlowMode.Descriptor instead.
+func (FlowMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{0}
+}
+
+type FitnessMetric int32
+
+const (
+	FitnessMetric_FITNESS_METRIC_UNSPECIFIED       FitnessMetric = 0
+	FitnessMetric_FITNESS_METRIC_ENERGY            FitnessMetric = 1
+	FitnessMetric_FITNESS_METRIC_ENERGY_PER_STEAM  FitnessMetric = 2
+	FitnessMetric_FITNESS_METRIC_ENERGY_PER_VOLUME FitnessMetric = 3
+)
+
+// Enum value maps for FitnessMetric.
+var (
+	FitnessMetric_name = map[int32]string{
+		0: "FITNESS_METRIC_UNSPECIFIED",
+		1: "FITNESS_METRIC_ENERGY",
+		2: "FITNESS_METRIC_ENERGY_PER_STEAM",
+		3: "FITNESS_METRIC_ENERGY_PER_VOLUME",
+	}
+	FitnessMetric_value = map[string]int32{
+		"FITNESS_METRIC_UNSPECIFIED":       0,
+		"FITNESS_METRIC_ENERGY":            1,
+		"FITNESS_METRIC_ENERGY_PER_STEAM":  2,
+		"FITNESS_METRIC_ENERGY_PER_VOLUME": 3,
+	}
+)
+
+func (x FitnessMetric) Enum() *FitnessMetric {
+	p := new(FitnessMetric)
+	*p = x
+	return p
+}
+
+func (x FitnessMetric) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FitnessMetric) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_turbine_v1_turbine_proto_enumTypes[1].Descriptor()
+}
+
+func (FitnessMetric) Type() protoreflect.EnumType {
+	return &file_proto_turbine_v1_turbine_proto_enumTypes[1]
+}
+
+func (x FitnessMetric) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FitnessMetric.Descriptor instead.
+func (FitnessMetric) EnumDescriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{1}
+}
+
+// A turbine to build, names come from the profile's tables
+type Design struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// outer dimensions, including casing
+	Width      int32  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height     int32  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	CoilLayers int32  `protobuf:"varint,3,opt,name=coil_layers,json=coilLayers,proto3" json:"coil_layers,omitempty"`
+	Coil       string `protobuf:"bytes,4,opt,name=coil,proto3" json:"coil,omitempty"`
+	// the profile's default when empty
+	Blade string `protobuf:"bytes,5,opt,name=blade,proto3" json:"blade,omitempty"`
+	Fluid string `protobuf:"bytes,6,opt,name=fluid,proto3" json:"fluid,omitempty"`
+	// mB/t
+	FlowRate      int64 `protobuf:"varint,7,opt,name=flow_rate,json=flowRate,proto3" json:"flow_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Design) Reset() {
+	*x = Design{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Design) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Design) ProtoMessage() {}
+
+func (x *Design) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Design.ProtoReflect.Descriptor instead.
+func (*Design) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Design) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Design) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Design) GetCoilLayers() int32 {
+	if x != nil {
+		return x.CoilLayers
+	}
+	return 0
+}
+
+func (x *Design) GetCoil() string {
+	if x != nil {
+		return x.Coil
+	}
+	return ""
+}
+
+func (x *Design) GetBlade() string {
+	if x != nil {
+		return x.Blade
+	}
+	return ""
+}
+
+func (x *Design) GetFluid() string {
+	if x != nil {
+		return x.Fluid
+	}
+	return ""
+}
+
+func (x *Design) GetFlowRate() int64 {
+	if x != nil {
+		return x.FlowRate
+	}
+	return 0
+}
+
+type EvaluateRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Design *Design                `protobuf:"bytes,1,opt,name=design,proto3" json:"design,omitempty"`
+	// the default profile when empty
+	Profile       string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EvaluateRequest) GetDesign() *Design {
+	if x != nil {
+		return x.Design
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+type OptimizeRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Profile string                 `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	// the room, depth is the same as the width when zero
+	MaxWidth  int32    `protobuf:"varint,2,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`
+	MaxHeight int32    `protobuf:"varint,3,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	MaxDepth  int32    `protobuf:"varint,4,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	Coil      string   `protobuf:"bytes,5,opt,name=coil,proto3" json:"coil,omitempty"`
+	Blade     string   `protobuf:"bytes,6,opt,name=blade,proto3" json:"blade,omitempty"`
+	Fluid     string   `protobuf:"bytes,7,opt,name=fluid,proto3" json:"fluid,omitempty"`
+	FlowMode  FlowMode `protobuf:"varint,8,opt,name=flow_mode,json=flowMode,proto3,enum=turbine.v1.FlowMode" json:"flow_mode,omitempty"`
+	FlowValue int64    `protobuf:"varint,9,opt,name=flow_value,json=flowValue,proto3" json:"flow_value,omitempty"`
+	// mB/t FLOW_MODE_BEST_UNDER narrows down to, zero for the default
+	FlowTolerance int64         `protobuf:"varint,10,opt,name=flow_tolerance,json=flowTolerance,proto3" json:"flow_tolerance,omitempty"`
+	Fitness       FitnessMetric `protobuf:"varint,11,opt,name=fitness,proto3,enum=turbine.v1.FitnessMetric" json:"fitness,omitempty"`
+	// RF/t floor for the efficiency metrics
+	MinEnergy float64 `protobuf:"fixed64,12,opt,name=min_energy,json=minEnergy,proto3" json:"min_energy,omitempty"`
+	// zero leaves that side open
+	MinCoilLayers int32 `protobuf:"varint,13,opt,name=min_coil_layers,json=minCoilLayers,proto3" json:"min_coil_layers,omitempty"`
+	MaxCoilLayers int32 `protobuf:"varint,14,opt,name=max_coil_layers,json=maxCoilLayers,proto3" json:"max_coil_layers,omitempty"`
+	// zero means no limit
+	MaxEvaluations int64 `protobuf:"varint,15,opt,name=max_evaluations,json=maxEvaluations,proto3" json:"max_evaluations,omitempty"`
+	TimeBudgetMs   int64 `protobuf:"varint,16,opt,name=time_budget_ms,json=timeBudgetMs,proto3" json:"time_budget_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OptimizeRequest) Reset() {
+	*x = OptimizeRequest{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeRequest) ProtoMessage() {}
+
+func (x *OptimizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeRequest.ProtoReflect.Descriptor instead.
+func (*OptimizeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OptimizeRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetCoil() string {
+	if x != nil {
+		return x.Coil
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetBlade() string {
+	if x != nil {
+		return x.Blade
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetFluid() string {
+	if x != nil {
+		return x.Fluid
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetFlowMode() FlowMode {
+	if x != nil {
+		return x.FlowMode
+	}
+	return FlowMode_FLOW_MODE_UNSPECIFIED
+}
+
+func (x *OptimizeRequest) GetFlowValue() int64 {
+	if x != nil {
+		return x.FlowValue
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetFlowTolerance() int64 {
+	if x != nil {
+		return x.FlowTolerance
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetFitness() FitnessMetric {
+	if x != nil {
+		return x.Fitness
+	}
+	return FitnessMetric_FITNESS_METRIC_UNSPECIFIED
+}
+
+func (x *OptimizeRequest) GetMinEnergy() float64 {
+	if x != nil {
+		return x.MinEnergy
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMinCoilLayers() int32 {
+	if x != nil {
+		return x.MinCoilLayers
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMaxCoilLayers() int32 {
+	if x != nil {
+		return x.MaxCoilLayers
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMaxEvaluations() int64 {
+	if x != nil {
+		return x.MaxEvaluations
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetTimeBudgetMs() int64 {
+	if x != nil {
+		return x.TimeBudgetMs
+	}
+	return 0
+}
+
+type OptimizeResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Result *Result                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// the search ran out of budget and this is the best turbine found so far
+	Truncated     bool     `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Evaluations   int64    `protobuf:"varint,3,opt,name=evaluations,proto3" json:"evaluations,omitempty"`
+	Notes         []string `protobuf:"bytes,4,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OptimizeResponse) Reset() {
+	*x = OptimizeResponse{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OptimizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeResponse) ProtoMessage() {}
+
+func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeResponse.ProtoReflect.Descriptor instead.
+func (*OptimizeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OptimizeResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *OptimizeResponse) GetEvaluations() int64 {
+	if x != nil {
+		return x.Evaluations
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+type SweepRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Turbine       *EvaluateRequest       `protobuf:"bytes,1,opt,name=turbine,proto3" json:"turbine,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Step          int64                  `protobuf:"varint,4,opt,name=step,proto3" json:"step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SweepRequest) Reset() {
+	*x = SweepRequest{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SweepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SweepRequest) ProtoMessage() {}
+
+func (x *SweepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SweepRequest.ProtoReflect.Descriptor instead.
+func (*SweepRequest) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SweepRequest) GetTurbine() *EvaluateRequest {
+	if x != nil {
+		return x.Turbine
+	}
+	return nil
+}
+
+func (x *SweepRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *SweepRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *SweepRequest) GetStep() int64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type Stats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Width           int32                  `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height          int32                  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Rpm             float64                `protobuf:"fixed64,3,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	CoilSize        int64                  `protobuf:"varint,4,opt,name=coil_size,json=coilSize,proto3" json:"coil_size,omitempty"`
+	CoilLayers      int32                  `protobuf:"varint,5,opt,name=coil_layers,json=coilLayers,proto3" json:"coil_layers,omitempty"`
+	OuterRingCoils  int64                  `protobuf:"varint,6,opt,name=outer_ring_coils,json=outerRingCoils,proto3" json:"outer_ring_coils,omitempty"`
+	FlowRate        int64                  `protobuf:"varint,7,opt,name=flow_rate,json=flowRate,proto3" json:"flow_rate,omitempty"`
+	MaxFlowRate     int64                  `protobuf:"varint,8,opt,name=max_flow_rate,json=maxFlowRate,proto3" json:"max_flow_rate,omitempty"`
+	RotorShafts     int32                  `protobuf:"varint,9,opt,name=rotor_shafts,json=rotorShafts,proto3" json:"rotor_shafts,omitempty"`
+	Blade           string                 `protobuf:"bytes,10,opt,name=blade,proto3" json:"blade,omitempty"`
+	Fluid           string                 `protobuf:"bytes,11,opt,name=fluid,proto3" json:"fluid,omitempty"`
+	EnergyGenerated float64                `protobuf:"fixed64,12,opt,name=energy_generated,json=energyGenerated,proto3" json:"energy_generated,omitempty"`
+	RotorEfficiency float64                `protobuf:"fixed64,13,opt,name=rotor_efficiency,json=rotorEfficiency,proto3" json:"rotor_efficiency,omitempty"`
+	InductorDrag    float64                `protobuf:"fixed64,14,opt,name=inductor_drag,json=inductorDrag,proto3" json:"inductor_drag,omitempty"`
+	FrictionDrag    float64                `protobuf:"fixed64,15,opt,name=friction_drag,json=frictionDrag,proto3" json:"friction_drag,omitempty"`
+	AeroDrag        float64                `protobuf:"fixed64,16,opt,name=aero_drag,json=aeroDrag,proto3" json:"aero_drag,omitempty"`
+	CoilEfficiency  float64                `protobuf:"fixed64,17,opt,name=coil_efficiency,json=coilEfficiency,proto3" json:"coil_efficiency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Stats) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Stats) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Stats) GetRpm() float64 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *Stats) GetCoilSize() int64 {
+	if x != nil {
+		return x.CoilSize
+	}
+	return 0
+}
+
+func (x *Stats) GetCoilLayers() int32 {
+	if x != nil {
+		return x.CoilLayers
+	}
+	return 0
+}
+
+func (x *Stats) GetOuterRingCoils() int64 {
+	if x != nil {
+		return x.OuterRingCoils
+	}
+	return 0
+}
+
+func (x *Stats) GetFlowRate() int64 {
+	if x != nil {
+		return x.FlowRate
+	}
+	return 0
+}
+
+func (x *Stats) GetMaxFlowRate() int64 {
+	if x != nil {
+		return x.MaxFlowRate
+	}
+	return 0
+}
+
+func (x *Stats) GetRotorShafts() int32 {
+	if x != nil {
+		return x.RotorShafts
+	}
+	return 0
+}
+
+func (x *Stats) GetBlade() string {
+	if x != nil {
+		return x.Blade
+	}
+	return ""
+}
+
+func (x *Stats) GetFluid() string {
+	if x != nil {
+		return x.Fluid
+	}
+	return ""
+}
+
+func (x *Stats) GetEnergyGenerated() float64 {
+	if x != nil {
+		return x.EnergyGenerated
+	}
+	return 0
+}
+
+func (x *Stats) GetRotorEfficiency() float64 {
+	if x != nil {
+		return x.RotorEfficiency
+	}
+	return 0
+}
+
+func (x *Stats) GetInductorDrag() float64 {
+	if x != nil {
+		return x.InductorDrag
+	}
+	return 0
+}
+
+func (x *Stats) GetFrictionDrag() float64 {
+	if x != nil {
+		return x.FrictionDrag
+	}
+	return 0
+}
+
+func (x *Stats) GetAeroDrag() float64 {
+	if x != nil {
+		return x.AeroDrag
+	}
+	return 0
+}
+
+func (x *Stats) GetCoilEfficiency() float64 {
+	if x != nil {
+		return x.CoilEfficiency
+	}
+	return 0
+}
+
+type PeakFlow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rpm   float64                `protobuf:"fixed64,1,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	// mB/t holding the rotor at rpm, zero if no flow rate can
+	FlowRate      int64 `protobuf:"varint,2,opt,name=flow_rate,json=flowRate,proto3" json:"flow_rate,omitempty"`
+	Reachable     bool  `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeakFlow) Reset() {
+	*x = PeakFlow{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeakFlow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeakFlow) ProtoMessage() {}
+
+func (x *PeakFlow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeakFlow.ProtoReflect.Descriptor instead.
+func (*PeakFlow) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PeakFlow) GetRpm() float64 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *PeakFlow) GetFlowRate() int64 {
+	if x != nil {
+		return x.FlowRate
+	}
+	return 0
+}
+
+func (x *PeakFlow) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+	return false
+}
+
+type Warning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warning) Reset() {
+	*x = Warning{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warning) ProtoMessage() {}
+
+func (x *Warning) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warning.ProtoReflect.Descriptor instead.
+func (*Warning) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Warning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Warning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Result struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Stats     *Stats                 `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	PeakFlows []*PeakFlow            `protobuf:"bytes,2,rep,name=peak_flows,json=peakFlows,proto3" json:"peak_flows,omitempty"`
+	// RF made from each mB of steam
+	EnergyPerSteam float64    `protobuf:"fixed64,3,opt,name=energy_per_steam,json=energyPerSteam,proto3" json:"energy_per_steam,omitempty"`
+	RotorCapacity  float64    `protobuf:"fixed64,4,opt,name=rotor_capacity,json=rotorCapacity,proto3" json:"rotor_capacity,omitempty"`
+	SweetSpotFlow  int64      `protobuf:"varint,5,opt,name=sweet_spot_flow,json=sweetSpotFlow,proto3" json:"sweet_spot_flow,omitempty"`
+	NoLoadRpm      float64    `protobuf:"fixed64,6,opt,name=no_load_rpm,json=noLoadRpm,proto3" json:"no_load_rpm,omitempty"`
+	Explanation    []string   `protobuf:"bytes,7,rep,name=explanation,proto3" json:"explanation,omitempty"`
+	Warnings       []*Warning `protobuf:"bytes,8,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Result) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *Result) GetPeakFlows() []*PeakFlow {
+	if x != nil {
+		return x.PeakFlows
+	}
+	return nil
+}
+
+func (x *Result) GetEnergyPerSteam() float64 {
+	if x != nil {
+		return x.EnergyPerSteam
+	}
+	return 0
+}
+
+func (x *Result) GetRotorCapacity() float64 {
+	if x != nil {
+		return x.RotorCapacity
+	}
+	return 0
+}
+
+func (x *Result) GetSweetSpotFlow() int64 {
+	if x != nil {
+		return x.SweetSpotFlow
+	}
+	return 0
+}
+
+func (x *Result) GetNoLoadRpm() float64 {
+	if x != nil {
+		return x.NoLoadRpm
+	}
+	return 0
+}
+
+func (x *Result) GetExplanation() []string {
+	if x != nil {
+		return x.Explanation
+	}
+	return nil
+}
+
+func (x *Result) GetWarnings() []*Warning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type FlowPoint struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FlowRate        int64                  `protobuf:"varint,1,opt,name=flow_rate,json=flowRate,proto3" json:"flow_rate,omitempty"`
+	Rpm             float64                `protobuf:"fixed64,2,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	EnergyGenerated float64                `protobuf:"fixed64,3,opt,name=energy_generated,json=energyGenerated,proto3" json:"energy_generated,omitempty"`
+	RotorEfficiency float64                `protobuf:"fixed64,4,opt,name=rotor_efficiency,json=rotorEfficiency,proto3" json:"rotor_efficiency,omitempty"`
+	CoilEfficiency  float64                `protobuf:"fixed64,5,opt,name=coil_efficiency,json=coilEfficiency,proto3" json:"coil_efficiency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FlowPoint) Reset() {
+	*x = FlowPoint{}
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowPoint) ProtoMessage() {}
+
+func (x *FlowPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_turbine_v1_turbine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowPoint.ProtoReflect.Descriptor instead.
+func (*FlowPoint) Descriptor() ([]byte, []int) {
+	return file_proto_turbine_v1_turbine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FlowPoint) GetFlowRate() int64 {
+	if x != nil {
+		return x.FlowRate
+	}
+	return 0
+}
+
+func (x *FlowPoint) GetRpm() float64 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *FlowPoint) GetEnergyGenerated() float64 {
+	if x != nil {
+		return x.EnergyGenerated
+	}
+	return 0
+}
+
+func (x *FlowPoint) GetRotorEfficiency() float64 {
+	if x != nil {
+		return x.RotorEfficiency
+	}
+	return 0
+}
+
+func (x *FlowPoint) GetCoilEfficiency() float64 {
+	if x != nil {
+		return x.CoilEfficiency
+	}
+	return 0
+}
+
+var File_proto_turbine_v1_turbine_proto protoreflect.FileDescriptor
+
+const file_proto_turbine_v1_turbine_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/turbine/v1/turbine.proto\x12\n" +
+	"turbine.v1\"\xb4\x01\n" +
+	"\x06Design\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vcoil_layers\x18\x03 \x01(\x05R\n" +
+	"coilLayers\x12\x12\n" +
+	"\x04coil\x18\x04 \x01(\tR\x04coil\x12\x14\n" +
+	"\x05blade\x18\x05 \x01(\tR\x05blade\x12\x14\n" +
+	"\x05fluid\x18\x06 \x01(\tR\x05fluid\x12\x1b\n" +
+	"\tflow_rate\x18\a \x01(\x03R\bflowRate\"W\n" +
+	"\x0fEvaluateRequest\x12*\n" +
+	"\x06design\x18\x01 \x01(\v2\x12.turbine.v1.DesignR\x06design\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\"\xb0\x04\n" +
+	"\x0fOptimizeRequest\x12\x18\n" +
+	"\aprofile\x18\x01 \x01(\tR\aprofile\x12\x1b\n" +
+	"\tmax_width\x18\x02 \x01(\x05R\bmaxWidth\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\x03 \x01(\x05R\tmaxHeight\x12\x1b\n" +
+	"\tmax_depth\x18\x04 \x01(\x05R\bmaxDepth\x12\x12\n" +
+	"\x04coil\x18\x05 \x01(\tR\x04coil\x12\x14\n" +
+	"\x05blade\x18\x06 \x01(\tR\x05blade\x12\x14\n" +
+	"\x05fluid\x18\a \x01(\tR\x05fluid\x121\n" +
+	"\tflow_mode\x18\b \x01(\x0e2\x14.turbine.v1.FlowModeR\bflowMode\x12\x1d\n" +
+	"\n" +
+	"flow_value\x18\t \x01(\x03R\tflowValue\x12%\n" +
+	"\x0eflow_tolerance\x18\n" +
+	" \x01(\x03R\rflowTolerance\x123\n" +
+	"\afitness\x18\v \x01(\x0e2\x19.turbine.v1.FitnessMetricR\afitness\x12\x1d\n" +
+	"\n" +
+	"min_energy\x18\f \x01(\x01R\tminEnergy\x12&\n" +
+	"\x0fmin_coil_layers\x18\r \x01(\x05R\rminCoilLayers\x12&\n" +
+	"\x0fmax_coil_layers\x18\x0e \x01(\x05R\rmaxCoilLayers\x12'\n" +
+	"\x0fmax_evaluations\x18\x0f \x01(\x03R\x0emaxEvaluations\x12$\n" +
+	"\x0etime_budget_ms\x18\x10 \x01(\x03R\ftimeBudgetMs\"\x94\x01\n" +
+	"\x10OptimizeResponse\x12*\n" +
+	"\x06result\x18\x01 \x01(\v2\x12.turbine.v1.ResultR\x06result\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\x12 \n" +
+	"\vevaluations\x18\x03 \x01(\x03R\vevaluations\x12\x14\n" +
+	"\x05notes\x18\x04 \x03(\tR\x05notes\"}\n" +
+	"\fSweepRequest\x125\n" +
+	"\aturbine\x18\x01 \x01(\v2\x1b.turbine.v1.EvaluateRequestR\aturbine\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x12\n" +
+	"\x04step\x18\x04 \x01(\x03R\x04step\"\xa5\x04\n" +
+	"\x05Stats\x12\x14\n" +
+	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x05R\x06height\x12\x10\n" +
+	"\x03rpm\x18\x03 \x01(\x01R\x03rpm\x12\x1b\n" +
+	"\tcoil_size\x18\x04 \x01(\x03R\bcoilSize\x12\x1f\n" +
+	"\vcoil_layers\x18\x05 \x01(\x05R\n" +
+	"coilLayers\x12(\n" +
+	"\x10outer_ring_coils\x18\x06 \x01(\x03R\x0eouterRingCoils\x12\x1b\n" +
+	"\tflow_rate\x18\a \x01(\x03R\bflowRate\x12\"\n" +
+	"\rmax_flow_rate\x18\b \x01(\x03R\vmaxFlowRate\x12!\n" +
+	"\frotor_shafts\x18\t \x01(\x05R\vrotorShafts\x12\x14\n" +
+	"\x05blade\x18\n" +
+	" \x01(\tR\x05blade\x12\x14\n" +
+	"\x05fluid\x18\v \x01(\tR\x05fluid\x12)\n" +
+	"\x10energy_generated\x18\f \x01(\x01R\x0fenergyGenerated\x12)\n" +
+	"\x10rotor_efficiency\x18\r \x01(\x01R\x0frotorEfficiency\x12#\n" +
+	"\rinductor_drag\x18\x0e \x01(\x01R\finductorDrag\x12#\n" +
+	"\rfriction_drag\x18\x0f \x01(\x01R\ffrictionDrag\x12\x1b\n" +
+	"\taero_drag\x18\x10 \x01(\x01R\baeroDrag\x12'\n" +
+	"\x0fcoil_efficiency\x18\x11 \x01(\x01R\x0ecoilEfficiency\"W\n" +
+	"\bPeakFlow\x12\x10\n" +
+	"\x03rpm\x18\x01 \x01(\x01R\x03rpm\x12\x1b\n" +
+	"\tflow_rate\x18\x02 \x01(\x03R\bflowRate\x12\x1c\n" +
+	"\treachable\x18\x03 \x01(\bR\treachable\"7\n" +
+	"\aWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd2\x02\n" +
+	"\x06Result\x12'\n" +
+	"\x05stats\x18\x01 \x01(\v2\x11.turbine.v1.StatsR\x05stats\x123\n" +
+	"\n" +
+	"peak_flows\x18\x02 \x03(\v2\x14.turbine.v1.PeakFlowR\tpeakFlows\x12(\n" +
+	"\x10energy_per_steam\x18\x03 \x01(\x01R\x0eenergyPerSteam\x12%\n" +
+	"\x0erotor_capacity\x18\x04 \x01(\x01R\rrotorCapacity\x12&\n" +
+	"\x0fsweet_spot_flow\x18\x05 \x01(\x03R\rsweetSpotFlow\x12\x1e\n" +
+	"\vno_load_rpm\x18\x06 \x01(\x01R\tnoLoadRpm\x12 \n" +
+	"\vexplanation\x18\a \x03(\tR\vexplanation\x12/\n" +
+	"\bwarnings\x18\b \x03(\v2\x13.turbine.v1.WarningR\bwarnings\"\xb9\x01\n" +
+	"\tFlowPoint\x12\x1b\n" +
+	"\tflow_rate\x18\x01 \x01(\x03R\bflowRate\x12\x10\n" +
+	"\x03rpm\x18\x02 \x01(\x01R\x03rpm\x12)\n" +
+	"\x10energy_generated\x18\x03 \x01(\x01R\x0fenergyGenerated\x12)\n" +
+	"\x10rotor_efficiency\x18\x04 \x01(\x01R\x0frotorEfficiency\x12'\n" +
+	"\x0fcoil_efficiency\x18\x05 \x01(\x01R\x0ecoilEfficiency*\x93\x01\n" +
+	"\bFlowMode\x12\x19\n" +
+	"\x15FLOW_MODE_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rFLOW_MODE_MAX\x10\x01\x12\x12\n" +
+	"\x0eFLOW_MODE_BEST\x10\x02\x12\x11\n" +
+	"\rFLOW_MODE_SET\x10\x03\x12\x18\n" +
+	"\x14FLOW_MODE_BEST_UNDER\x10\x04\x12\x18\n" +
+	"\x14FLOW_MODE_TARGET_RPM\x10\x05*\x95\x01\n" +
+	"\rFitnessMetric\x12\x1e\n" +
+	"\x1aFITNESS_METRIC_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15FITNESS_METRIC_ENERGY\x10\x01\x12#\n" +
+	"\x1fFITNESS_METRIC_ENERGY_PER_STEAM\x10\x02\x12$\n" +
+	" FITNESS_METRIC_ENERGY_PER_VOLUME\x10\x032\xd0\x01\n" +
+	"\x0eTurbineService\x12E\n" +
+	"\bOptimize\x12\x1b.turbine.v1.OptimizeRequest\x1a\x1c.turbine.v1.OptimizeResponse\x12;\n" +
+	"\bEvaluate\x12\x1b.turbine.v1.EvaluateRequest\x1a\x12.turbine.v1.Result\x12:\n" +
+	"\x05Sweep\x12\x18.turbine.v1.SweepRequest\x1a\x15.turbine.v1.FlowPoint0\x01BJZHgithub.com/drabart/turbine-calculator-website/proto/turbine/v1;turbinev1b\x06proto3"
+
+var (
+	file_proto_turbine_v1_turbine_proto_rawDescOnce sync.Once
+	file_proto_turbine_v1_turbine_proto_rawDescData []byte
+)
+
+func file_proto_turbine_v1_turbine_proto_rawDescGZIP() []byte {
+	file_proto_turbine_v1_turbine_proto_rawDescOnce.Do(func() {
+		file_proto_turbine_v1_turbine_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_turbine_v1_turbine_proto_rawDesc), len(file_proto_turbine_v1_turbine_proto_rawDesc)))
+	})
+	return file_proto_turbine_v1_turbine_proto_rawDescData
+}
+
+var file_proto_turbine_v1_turbine_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_turbine_v1_turbine_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_turbine_v1_turbine_proto_goTypes = []any{
+	(FlowMode)(0),            // 0: turbine.v1.FlowMode
+	(FitnessMetric)(0),       // 1: turbine.v1.FitnessMetric
+	(*Design)(nil),           // 2: turbine.v1.Design
+	(*EvaluateRequest)(nil),  // 3: turbine.v1.EvaluateRequest
+	(*OptimizeRequest)(nil),  // 4: turbine.v1.OptimizeRequest
+	(*OptimizeResponse)(nil), // 5: turbine.v1.OptimizeResponse
+	(*SweepRequest)(nil),     // 6: turbine.v1.SweepRequest
+	(*Stats)(nil),            // 7: turbine.v1.Stats
+	(*PeakFlow)(nil),         // 8: turbine.v1.PeakFlow
+	(*Warning)(nil),          // 9: turbine.v1.Warning
+	(*Result)(nil),           // 10: turbine.v1.Result
+	(*FlowPoint)(nil),        // 11: turbine.v1.FlowPoint
+}
+var file_proto_turbine_v1_turbine_proto_depIdxs = []int32{
+	2,  // 0: turbine.v1.EvaluateRequest.design:type_name -> turbine.v1.Design
+	0,  // 1: turbine.v1.OptimizeRequest.flow_mode:type_name -> turbine.v1.FlowMode
+	1,  // 2: turbine.v1.OptimizeRequest.fitness:type_name -> turbine.v1.FitnessMetric
+	10, // 3: turbine.v1.OptimizeResponse.result:type_name -> turbine.v1.Result
+	3,  // 4: turbine.v1.SweepRequest.turbine:type_name -> turbine.v1.EvaluateRequest
+	7,  // 5: turbine.v1.Result.stats:type_name -> turbine.v1.Stats
+	8,  // 6: turbine.v1.Result.peak_flows:type_name -> turbine.v1.PeakFlow
+	9,  // 7: turbine.v1.Result.warnings:type_name -> turbine.v1.Warning
+	4,  // 8: turbine.v1.TurbineService.Optimize:input_type -> turbine.v1.OptimizeRequest
+	3,  // 9: turbine.v1.TurbineService.Evaluate:input_type -> turbine.v1.EvaluateRequest
+	6,  // 10: turbine.v1.TurbineService.Sweep:input_type -> turbine.v1.SweepRequest
+	5,  // 11: turbine.v1.TurbineService.Optimize:output_type -> turbine.v1.OptimizeResponse
+	10, // 12: turbine.v1.TurbineService.Evaluate:output_type -> turbine.v1.Result
+	11, // 13: turbine.v1.TurbineService.Sweep:output_type -> turbine.v1.FlowPoint
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_turbine_v1_turbine_proto_init() }
+func file_proto_turbine_v1_turbine_proto_init() {
+	if File_proto_turbine_v1_turbine_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_turbine_v1_turbine_proto_rawDesc), len(file_proto_turbine_v1_turbine_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_turbine_v1_turbine_proto_goTypes,
+		DependencyIndexes: file_proto_turbine_v1_turbine_proto_depIdxs,
+		EnumInfos:         file_proto_turbine_v1_turbine_proto_enumTypes,
+		MessageInfos:      file_proto_turbine_v1_turbine_proto_msgTypes,
+	}.Build()
+	File_proto_turbine_v1_turbine_proto = out.File
+	file_proto_turbine_v1_turbine_proto_goTypes = nil
+	file_proto_turbine_v1_turbine_proto_depIdxs = nil
+}
@@ -0,0 +1,218 @@
+// The turbine optimizer as a gRPC service, for integrations that can't run the wasm build. cmd/grpc serves it.
+//
+// turbine.pb.go and turbine_grpc.pb.go next to this file are generated from it with
+//
+//   protoc --go_out=. --go_opt=module=github.com/drabart/turbine-calculator-website \
+//     --go-grpc_out=. --go-grpc_opt=module=github.com/drabart/turbine-calculator-website \
+//     proto/turbine/v1/turbine.proto
+//
+// using protoc-gen-go v1.36.6 and protoc-gen-go-grpc v1.5.1. The messages follow the json the site gets from the
+// wasm build, field for field.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/turbine/v1/turbine.proto
+
+package turbinev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TurbineService_Optimize_FullMethodName = "/turbine.v1.TurbineService/Optimize"
+	TurbineService_Evaluate_FullMethodName = "/turbine.v1.TurbineService/Evaluate"
+	TurbineService_Sweep_FullMethodName    = "/turbine.v1.TurbineService/Sweep"
+)
+
+// TurbineServiceClient is the client API for TurbineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TurbineServiceClient interface {
+	// Search the room for the fittest turbine, like runOptimizer
+	Optimize(ctx context.Context, in *OptimizeRequest, opts ...grpc.CallOption) (*OptimizeResponse, error)
+	// Build one design and run it to its steady state, like turbine.New
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*Result, error)
+	// Settle a design at every flow rate of a range, one point per message
+	Sweep(ctx context.Context, in *SweepRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FlowPoint], error)
+}
+
+type turbineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTurbineServiceClient(cc grpc.ClientConnInterface) TurbineServiceClient {
+	return &turbineServiceClient{cc}
+}
+
+func (c *turbineServiceClient) Optimize(ctx context.Context, in *OptimizeRequest, opts ...grpc.CallOption) (*OptimizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OptimizeResponse)
+	err := c.cc.Invoke(ctx, TurbineService_Optimize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *turbineServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*Result, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Result)
+	err := c.cc.Invoke(ctx, TurbineService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *turbineServiceClient) Sweep(ctx context.Context, in *SweepRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FlowPoint], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TurbineService_ServiceDesc.Streams[0], TurbineService_Sweep_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SweepRequest, FlowPoint]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TurbineService_SweepClient = grpc.ServerStreamingClient[FlowPoint]
+
+// TurbineServiceServer is the server API for TurbineService service.
+// All implementations must embed UnimplementedTurbineServiceServer
+// for forward compatibility.
+type TurbineServiceServer interface {
+	// Search the room for the fittest turbine, like runOptimizer
+	Optimize(context.Context, *OptimizeRequest) (*OptimizeResponse, error)
+	// Build one design and run it to its steady state, like turbine.New
+	Evaluate(context.Context, *EvaluateRequest) (*Result, error)
+	// Settle a design at every flow rate of a range, one point per message
+	Sweep(*SweepRequest, grpc.ServerStreamingServer[FlowPoint]) error
+	mustEmbedUnimplementedTurbineServiceServer()
+}
+
+// UnimplementedTurbineServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTurbineServiceServer struct{}
+
+func (UnimplementedTurbineServiceServer) Optimize(context.Context, *OptimizeRequest) (*OptimizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Optimize not implemented")
+}
+func (UnimplementedTurbineServiceServer) Evaluate(context.Context, *EvaluateRequest) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedTurbineServiceServer) Sweep(*SweepRequest, grpc.ServerStreamingServer[FlowPoint]) error {
+	return status.Errorf(codes.Unimplemented, "method Sweep not implemented")
+}
+func (UnimplementedTurbineServiceServer) mustEmbedUnimplementedTurbineServiceServer() {}
+func (UnimplementedTurbineServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeTurbineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TurbineServiceServer will
+// result in compilation errors.
+type UnsafeTurbineServiceServer interface {
+	mustEmbedUnimplementedTurbineServiceServer()
+}
+
+func RegisterTurbineServiceServer(s grpc.ServiceRegistrar, srv TurbineServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTurbineServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TurbineService_ServiceDesc, srv)
+}
+
+func _TurbineService_Optimize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OptimizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TurbineServiceServer).Optimize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TurbineService_Optimize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TurbineServiceServer).Optimize(ctx, req.(*OptimizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TurbineService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TurbineServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TurbineService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TurbineServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TurbineService_Sweep_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SweepRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TurbineServiceServer).Sweep(m, &grpc.GenericServerStream[SweepRequest, FlowPoint]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TurbineService_SweepServer = grpc.ServerStreamingServer[FlowPoint]
+
+// TurbineService_ServiceDesc is the grpc.ServiceDesc for TurbineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TurbineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "turbine.v1.TurbineService",
+	HandlerType: (*TurbineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Optimize",
+			Handler:    _TurbineService_Optimize_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _TurbineService_Evaluate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Sweep",
+			Handler:       _TurbineService_Sweep_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/turbine/v1/turbine.proto",
+}